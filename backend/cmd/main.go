@@ -9,6 +9,7 @@ import (
 
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/dhpollack/football-pool/internal/clock"
 	"github.com/dhpollack/football-pool/internal/config"
 	"github.com/dhpollack/football-pool/internal/database"
 	espnsync "github.com/dhpollack/football-pool/internal/espn-sync"
@@ -54,6 +55,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The configured season year is the first season; later seasons start with a rollover
+	if err := db.SeedSeason(cfg.ESPN.SeasonYear); err != nil {
+		slog.Error("Failed to record the configured season", "season", cfg.ESPN.SeasonYear, "error", err)
+		os.Exit(1)
+	}
+
 	// Load and create configured users
 	slog.Info("Loading user configuration")
 	users, err := config.LoadUserConfig()
@@ -68,21 +75,49 @@ func main() {
 		os.Exit(1)
 	}
 
+	srv, err := server.NewServer(db, cfg)
+	if err != nil {
+		slog.Error("Failed to create server", "error", err)
+		os.Exit(1)
+	}
+	scheduler := srv.Scheduler()
+
+	// Materialize the standings of a season scored before they were stored
+	if season, err := srv.Seasons().CurrentSeason(); err != nil {
+		slog.Error("Failed to load current season", "error", err)
+	} else if err := srv.Seasons().RecomputeIfMissing(season); err != nil {
+		slog.Error("Failed to materialize standings", "season", season, "error", err)
+	}
+
 	// Initialize ESPN sync service
-	syncService, err := initSyncService(db, cfg)
+	syncService, err := initSyncService(db, cfg, srv.Clock())
 	if err != nil {
 		slog.Error("Failed to initialize ESPN sync service", "error", err)
 		// Continue without sync service - it's not critical for server startup
 	}
 
-	// Start background sync process if service was initialized
+	// Register background jobs for the sync and week lifecycle services
 	if syncService != nil {
-		ctx := context.Background()
-		go syncService.Start(ctx, cfg.ESPN.SyncInterval)
+		srv.SetSyncService(syncService)
+		syncService.OnResults(srv.Seasons().ResultsChanged)
+		syncService.OnLiveScores(srv.Seasons().LiveScoresChanged)
+		if err := syncService.RegisterJobs(scheduler); err != nil {
+			slog.Error("Failed to register ESPN sync jobs", "error", err)
+		}
 	}
 
+	// The week lifecycle is left alone during E2E tests, which manage weeks themselves
+	if !cfg.E2E.Test {
+		if err := srv.Lifecycle().RegisterJobs(scheduler); err != nil {
+			slog.Error("Failed to register week lifecycle jobs", "error", err)
+		}
+	} else {
+		slog.Info("Week lifecycle service disabled for E2E tests")
+	}
+
+	go scheduler.Start(context.Background())
+
 	slog.Info("Starting server")
-	srv := server.NewServer(db, cfg)
 	srv.Start()
 }
 
@@ -146,7 +181,7 @@ func createConfiguredUsers(users []config.UserConfig, db *database.Database) err
 	return nil
 }
 
-func initSyncService(db *database.Database, cfg *config.Config) (*espnsync.SyncService, error) {
+func initSyncService(db *database.Database, cfg *config.Config, appClock clock.Clock) (*espnsync.SyncService, error) {
 	// Disable sync service during E2E tests to avoid interference
 	syncEnabled := cfg.ESPN.SyncEnabled
 	if cfg.E2E.Test {
@@ -164,5 +199,5 @@ func initSyncService(db *database.Database, cfg *config.Config) (*espnsync.SyncS
 		"cache_dir", cfg.ESPN.CacheDir,
 	)
 
-	return espnsync.NewSyncService(db, &tempConfig)
+	return espnsync.NewSyncServiceWithTimeProvider(db, &tempConfig, appClock)
 }
@@ -0,0 +1,97 @@
+// Package main runs a mock of the ESPN scoreboard and The Odds API, serving a simulated
+// season whose games progress with a simulated clock.
+//
+// The simulated clock is separate from the backend's application clock, which decides when
+// weeks lock and games are scored. With -backend, the mock moves the backend's clock to the
+// simulated time on start and every -backend-interval, signing in with the credentials of an
+// admin. The backend must run in E2E test mode for its clock endpoint to be served.
+// Without -backend, the backend runs at real time, and -start should be left at the current
+// time, with a speed of 1, for the games to line up with the backend.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/mockupstream"
+)
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	year := flag.Int("season", 2025, "year of the simulated season")
+	week1 := flag.String("week1", "", "kickoff day of week 1 (YYYY-MM-DD), defaults to the Thursday after Labor Day")
+	start := flag.String("start", "", "simulated time to start at (RFC3339), defaults to the start of week 1")
+	speed := flag.Float64("speed", 1, "how many times faster than real time the simulated clock runs")
+	fixtures := flag.String("fixtures", "", "directory of recorded fixtures to replay before generated data")
+	backend := flag.String("backend", "", "base URL of a backend whose application clock follows the simulated clock")
+	backendEmail := flag.String("backend-email", "", "email of a backend admin, used to move the backend clock")
+	backendPassword := flag.String("backend-password", "", "password of the backend admin")
+	backendInterval := flag.Duration("backend-interval", time.Second, "how often the backend clock is moved to the simulated time")
+	debug := flag.Bool("debug", false, "log every request")
+	flag.Parse()
+
+	level := slog.LevelInfo
+	if *debug {
+		level = slog.LevelDebug
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: level})))
+
+	firstWeek := mockupstream.DefaultWeek1(*year)
+	if *week1 != "" {
+		t, err := time.Parse(time.DateOnly, *week1)
+		if err != nil {
+			slog.Error("Invalid week1", "error", err)
+			os.Exit(1)
+		}
+		firstWeek = t
+	}
+	season := mockupstream.NewSeason(*year, firstWeek)
+
+	startTime := firstWeek
+	if *start != "" {
+		t, err := time.Parse(time.RFC3339, *start)
+		if err != nil {
+			slog.Error("Invalid start", "error", err)
+			os.Exit(1)
+		}
+		startTime = t
+	}
+	if *speed < 0 {
+		slog.Error("Invalid speed, it must not be negative", "speed", *speed)
+		os.Exit(1)
+	}
+
+	clock := mockupstream.NewClock(startTime, *speed)
+	srv, err := mockupstream.NewServer(season, clock, *fixtures)
+	if err != nil {
+		slog.Error("Failed to create mock upstream server", "error", err)
+		os.Exit(1)
+	}
+
+	if *backend != "" {
+		if *backendInterval <= 0 {
+			slog.Error("Invalid backend-interval, it must be positive", "backend_interval", *backendInterval)
+			os.Exit(1)
+		}
+		backendClock := mockupstream.NewBackendClock(*backend, *backendEmail, *backendPassword)
+		go backendClock.Follow(context.Background(), clock, *backendInterval)
+		slog.Info("Moving the backend clock with the simulated clock", "backend", *backend, "interval", *backendInterval)
+	}
+
+	slog.Info("Starting mock upstream server",
+		"addr", *addr,
+		"season", *year,
+		"start", startTime,
+		"speed", *speed,
+		"espn_base_url", "http://localhost"+*addr+mockupstream.ESPNBasePath,
+		"odds_api_base_url", "http://localhost"+*addr,
+	)
+	if err := http.ListenAndServe(*addr, srv.Handler()); err != nil {
+		slog.Error("Mock upstream server stopped", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,54 @@
+// Package main recomputes the materialized weekly scores and season standings of a season,
+// to correct them after picks or results were changed outside the API.
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/seasons"
+)
+
+func main() {
+	season := flag.Int("season", 0, "year of the season to recompute, defaults to the current season")
+	flag.Parse()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	dbConfig := cfg.Database.GetConfig()
+	if dbConfig == nil {
+		slog.Error("Invalid database configuration")
+		os.Exit(1)
+	}
+	db, err := database.New(cfg.Database.Type, dbConfig.GetDSN())
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+
+	service, err := seasons.NewService(db, cfg)
+	if err != nil {
+		slog.Error("Failed to initialize season service", "error", err)
+		os.Exit(1)
+	}
+	if *season == 0 {
+		if *season, err = service.CurrentSeason(); err != nil {
+			slog.Error("Failed to load current season", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := service.Recompute(*season); err != nil {
+		slog.Error("Failed to recompute standings", "season", *season, "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,83 @@
+// Package main rolls the football pool over to the next season: it archives the final
+// standings and survivor results of the current season and records the next one.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	espnsync "github.com/dhpollack/football-pool/internal/espn-sync"
+	"github.com/dhpollack/football-pool/internal/seasons"
+)
+
+func main() {
+	next := flag.Int("season", 0, "year of the next season, defaults to the year after the current season")
+	syncCalendar := flag.Bool("sync", false, "load the calendar of the next season from ESPN")
+	flag.Parse()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	dbConfig := cfg.Database.GetConfig()
+	if dbConfig == nil {
+		slog.Error("Invalid database configuration")
+		os.Exit(1)
+	}
+	db, err := database.New(cfg.Database.Type, dbConfig.GetDSN())
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	if err := db.SeedSeason(cfg.ESPN.SeasonYear); err != nil {
+		slog.Error("Failed to record the configured season", "season", cfg.ESPN.SeasonYear, "error", err)
+		os.Exit(1)
+	}
+
+	service, err := seasons.NewService(db, cfg)
+	if err != nil {
+		slog.Error("Failed to initialize season service", "error", err)
+		os.Exit(1)
+	}
+	if *next == 0 {
+		current, err := service.CurrentSeason()
+		if err != nil {
+			slog.Error("Failed to load current season", "error", err)
+			os.Exit(1)
+		}
+		*next = current + 1
+	}
+
+	season, err := service.Rollover(*next)
+	if errors.Is(err, seasons.ErrSeasonNotFinished) {
+		slog.Error("The current season cannot be archived until every game has a result", "error", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		slog.Error("Failed to roll over", "season", *next, "error", err)
+		os.Exit(1)
+	}
+
+	if *syncCalendar {
+		syncService, err := espnsync.NewSyncService(db, cfg)
+		if err != nil {
+			slog.Error("Failed to initialize ESPN sync service", "error", err)
+			os.Exit(1)
+		}
+		if err := syncService.SyncCalendar(context.Background(), season.Year); err != nil {
+			slog.Error("Failed to sync the calendar of the next season", "season", season.Year, "error", err)
+			os.Exit(1)
+		}
+	}
+
+	slog.Info("The pool is ready for the next season", "season", season.Year, "state", season.State)
+}
@@ -33,7 +33,10 @@ func TestIntegration(t *testing.T) {
 		t.Fatalf("Failed to connect to database: %v", err)
 	}
 	// Set up the server with database
-	srv := server.NewServer(db, cfg)
+	srv, err := server.NewServer(db, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
 	router := srv.NewRouter()
 
 	// Create a new test server
@@ -126,8 +129,8 @@ func createGames(t *testing.T, ts *httptest.Server, token string) {
 	favoriteHome := api.Home
 	underdogAway := api.Away
 	games := []api.GameRequest{
-		{Week: 1, Season: 2023, HomeTeam: "Team A", AwayTeam: "Team B", Spread: 3.5, StartTime: time.Now(), Favorite: &favoriteHome, Underdog: &underdogAway},
-		{Week: 1, Season: 2023, HomeTeam: "Team C", AwayTeam: "Team D", Spread: 7.0, StartTime: time.Now(), Favorite: &favoriteHome, Underdog: &underdogAway},
+		{Week: 1, Season: 2023, HomeTeam: "Team A", AwayTeam: "Team B", Spread: 3.5, StartTime: time.Now().Add(time.Hour), Favorite: &favoriteHome, Underdog: &underdogAway},
+		{Week: 1, Season: 2023, HomeTeam: "Team C", AwayTeam: "Team D", Spread: 7.0, StartTime: time.Now().Add(time.Hour), Favorite: &favoriteHome, Underdog: &underdogAway},
 	}
 	body, _ := json.Marshal(games)
 	req, _ := http.NewRequest("POST", ts.URL+"/api/admin/games/create", bytes.NewBuffer(body))
@@ -22,6 +22,25 @@ type Address struct {
 	State   *string `json:"state,omitempty"`
 }
 
+// CalendarEntry defines model for CalendarEntry.
+type CalendarEntry struct {
+	AlternateLabel *string       `json:"alternateLabel,omitempty"`
+	Detail         *string       `json:"detail,omitempty"`
+	EndDate        *ESPNDateTime `json:"endDate,omitempty"`
+	Label          *string       `json:"label,omitempty"`
+	StartDate      *ESPNDateTime `json:"startDate,omitempty"`
+	Value          *string       `json:"value,omitempty"`
+}
+
+// CalendarSeasonType defines model for CalendarSeasonType.
+type CalendarSeasonType struct {
+	EndDate   *ESPNDateTime    `json:"endDate,omitempty"`
+	Entries   *[]CalendarEntry `json:"entries,omitempty"`
+	Label     *string          `json:"label,omitempty"`
+	StartDate *ESPNDateTime    `json:"startDate,omitempty"`
+	Value     *string          `json:"value,omitempty"`
+}
+
 // Competition defines model for Competition.
 type Competition struct {
 	Attendance            *int          `json:"attendance,omitempty"`
@@ -78,12 +97,13 @@ type EventWeek struct {
 
 // League defines model for League.
 type League struct {
-	Abbreviation *string `json:"abbreviation,omitempty"`
-	Id           *string `json:"id,omitempty"`
-	Name         *string `json:"name,omitempty"`
-	Season       *Season `json:"season,omitempty"`
-	Slug         *string `json:"slug,omitempty"`
-	Uid          *string `json:"uid,omitempty"`
+	Abbreviation *string               `json:"abbreviation,omitempty"`
+	Calendar     *[]CalendarSeasonType `json:"calendar,omitempty"`
+	Id           *string               `json:"id,omitempty"`
+	Name         *string               `json:"name,omitempty"`
+	Season       *Season               `json:"season,omitempty"`
+	Slug         *string               `json:"slug,omitempty"`
+	Uid          *string               `json:"uid,omitempty"`
 }
 
 // Linescore defines model for Linescore.
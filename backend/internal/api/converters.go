@@ -3,8 +3,14 @@ package api
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/espn-sync"
+	"github.com/dhpollack/football-pool/internal/odds-sync"
+	"github.com/dhpollack/football-pool/internal/seasons"
+	"github.com/dhpollack/football-pool/internal/upstream"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -101,22 +107,153 @@ func UserFromRequest(req UserRequest) (database.User, error) {
 // GameToResponse converts a database Game to a GameResponse.
 func GameToResponse(game database.Game) GameResponse {
 	response := GameResponse{
-		Id:        game.ID,
-		Week:      game.Week,
-		Season:    game.Season,
-		HomeTeam:  game.HomeTeam,
-		AwayTeam:  game.AwayTeam,
+		Id:         game.ID,
+		Week:       game.Week,
+		Season:     game.Season,
+		SeasonType: game.SeasonType,
+		HomeTeam:   game.HomeTeam,
+		AwayTeam:   game.AwayTeam,
+		Spread:     game.Spread,
+		Favorite:   ConvertStringPointerToTeamDesignationPointer(game.Favorite),
+		Underdog:   ConvertStringPointerToTeamDesignationPointer(game.Underdog),
+		StartTime:  game.StartTime,
+		CreatedAt:  game.CreatedAt,
+		UpdatedAt:  game.UpdatedAt,
+
+		SpreadLockedAt: game.SpreadLockedAt,
+		HomeTeamId:     game.HomeTeamID,
+		AwayTeamId:     game.AwayTeamID,
+	}
+
+	if game.ESPNEventID != "" {
+		response.EspnEventId = &game.ESPNEventID
+	}
+	if game.Venue != "" {
+		response.Venue = &game.Venue
+	}
+	if game.SpreadStrategy != "" {
+		response.SpreadStrategy = &game.SpreadStrategy
+		bookmakers := splitBookmakers(game.SpreadBookmakers)
+		response.SpreadBookmakers = &bookmakers
+	}
+
+	return response
+}
+
+// TeamToResponse converts a database Team, with its aliases loaded, to a TeamResponse.
+func TeamToResponse(team database.Team) TeamResponse {
+	response := TeamResponse{
+		Id:           team.ID,
+		EspnId:       team.ESPNID,
+		Abbreviation: team.Abbreviation,
+		DisplayName:  team.DisplayName,
+		Location:     team.Location,
+		Name:         team.Name,
+		Aliases:      make([]TeamAliasResponse, len(team.Aliases)),
+	}
+	if team.Color != "" {
+		response.Color = &team.Color
+	}
+	if team.AlternateColor != "" {
+		response.AlternateColor = &team.AlternateColor
+	}
+	if team.LogoURL != "" {
+		response.LogoUrl = &team.LogoURL
+	}
+	for i, alias := range team.Aliases {
+		response.Aliases[i] = TeamAliasResponse{Provider: alias.Provider, Name: alias.Name}
+	}
+	return response
+}
+
+// SpreadSnapshotToResponse converts a database SpreadSnapshot to a SpreadSnapshotResponse.
+func SpreadSnapshotToResponse(snapshot database.SpreadSnapshot) SpreadSnapshotResponse {
+	return SpreadSnapshotResponse{
+		Strategy:   snapshot.Strategy,
+		Bookmakers: splitBookmakers(snapshot.Bookmakers),
+		Spread:     snapshot.Spread,
+		Favorite:   TeamDesignation(snapshot.Favorite),
+		FetchedAt:  snapshot.FetchedAt,
+	}
+}
+
+// splitBookmakers splits a comma-separated list of bookmaker keys.
+func splitBookmakers(bookmakers string) []string {
+	if bookmakers == "" {
+		return []string{}
+	}
+	return strings.Split(bookmakers, ",")
+}
+
+// ScheduleChangeToResponse converts a database ScheduleChange, with its game loaded, to a ScheduleChangeResponse.
+func ScheduleChangeToResponse(change database.ScheduleChange) ScheduleChangeResponse {
+	response := ScheduleChangeResponse{
+		Id:                change.ID,
+		GameId:            change.GameID,
+		Season:            change.Season,
+		HomeTeam:          change.Game.HomeTeam,
+		AwayTeam:          change.Game.AwayTeam,
+		PreviousWeek:      change.PreviousWeek,
+		Week:              change.Week,
+		PreviousStartTime: change.PreviousStartTime,
+		StartTime:         change.StartTime,
+		PicksAttached:     change.PicksAttached,
+		Alert:             change.IsAlert(),
+		DetectedAt:        change.DetectedAt,
+		AcknowledgedAt:    change.AcknowledgedAt,
+	}
+	if change.PreviousVenue != "" {
+		response.PreviousVenue = &change.PreviousVenue
+	}
+	if change.Venue != "" {
+		response.Venue = &change.Venue
+	}
+	return response
+}
+
+// UnmatchedSpreadToResponse converts a database UnmatchedSpread to an UnmatchedSpreadResponse.
+func UnmatchedSpreadToResponse(unmatched database.UnmatchedSpread) UnmatchedSpreadResponse {
+	response := UnmatchedSpreadResponse{
+		Provider:     unmatched.Provider,
+		HomeTeam:     unmatched.HomeTeam,
+		AwayTeam:     unmatched.AwayTeam,
+		CommenceTime: unmatched.CommenceTime,
+		Reason:       unmatched.Reason,
+		FetchedAt:    unmatched.FetchedAt,
+	}
+	if unmatched.EventID != "" {
+		response.EventId = &unmatched.EventID
+	}
+	return response
+}
+
+// SpreadHistoryToResponse converts a game and the lines fetched for it to a SpreadHistoryResponse.
+// Snapshots must be ordered oldest first.
+func SpreadHistoryToResponse(game database.Game, snapshots []database.SpreadSnapshot) SpreadHistoryResponse {
+	response := SpreadHistoryResponse{
+		GameId:    game.ID,
 		Spread:    game.Spread,
 		Favorite:  ConvertStringPointerToTeamDesignationPointer(game.Favorite),
-		Underdog:  ConvertStringPointerToTeamDesignationPointer(game.Underdog),
-		StartTime: game.StartTime,
-		CreatedAt: game.CreatedAt,
-		UpdatedAt: game.UpdatedAt,
+		LockedAt:  game.SpreadLockedAt,
+		Snapshots: make([]SpreadSnapshotResponse, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		response.Snapshots[i] = SpreadSnapshotToResponse(snapshot)
+	}
+	if len(snapshots) > 1 {
+		response.Movement = homeLine(snapshots[len(snapshots)-1]) - homeLine(snapshots[0])
 	}
-
 	return response
 }
 
+// homeLine returns a snapshot's line from the home team's side, negative when the home team is favored.
+func homeLine(snapshot database.SpreadSnapshot) float32 {
+	if snapshot.Favorite == "Home" {
+		return -snapshot.Spread
+	}
+	return snapshot.Spread
+}
+
 // GameFromRequest converts a GameRequest to a database Game.
 func GameFromRequest(req GameRequest) (database.Game, error) {
 	game := database.Game{
@@ -130,6 +267,13 @@ func GameFromRequest(req GameRequest) (database.Game, error) {
 		StartTime: req.StartTime,
 	}
 
+	// Derive the season type from the week number when not provided
+	if req.SeasonType != nil {
+		game.SeasonType = *req.SeasonType
+	} else {
+		game.SeasonType = database.SeasonTypeForWeek(req.Week)
+	}
+
 	if err := validate.Struct(game); err != nil {
 		return database.Game{}, err
 	}
@@ -190,6 +334,10 @@ func PickFromRequestWithOptions(req PickRequest, requireUserID bool) (database.P
 		return database.Pick{}, fmt.Errorf("user id is required")
 	}
 
+	if req.PredictedTotal != nil && *req.PredictedTotal < 0 {
+		return database.Pick{}, fmt.Errorf("predicted total cannot be negative")
+	}
+
 	// Skip UserID validation when not provided in request
 	// (it will be set later from authenticated user context)
 	if req.UserId == nil {
@@ -261,6 +409,7 @@ func SurvivorPickToResponse(pick database.SurvivorPick) SurvivorPickResponse {
 	response := SurvivorPickResponse{
 		Id:        pick.ID,
 		UserId:    pick.UserID,
+		Season:    pick.Season,
 		Week:      pick.Week,
 		Team:      pick.Team,
 		CreatedAt: pick.CreatedAt,
@@ -288,9 +437,29 @@ func SurvivorPickFromRequest(req SurvivorPickRequest) database.SurvivorPick {
 		pick.UserID = *req.UserId
 	}
 
+	// Handle optional Season
+	if req.Season != nil {
+		pick.Season = *req.Season
+	}
+
 	return pick
 }
 
+// TiebreakerToResponse converts a week's tiebreaker game and a player's prediction, which is
+// nil before they make one, to a TiebreakerResponse.
+func TiebreakerToResponse(game database.Game, tiebreaker *database.Tiebreaker) TiebreakerResponse {
+	response := TiebreakerResponse{
+		Season: game.Season,
+		Week:   game.Week,
+		GameId: game.ID,
+	}
+	if tiebreaker != nil {
+		response.PredictedTotal = &tiebreaker.PredictedTotal
+		response.SubmittedAt = &tiebreaker.SubmittedAt
+	}
+	return response
+}
+
 // PlayerToResponse converts a database Player to a PlayerResponse.
 func PlayerToResponse(player database.Player) PlayerResponse {
 	return PlayerResponse{
@@ -316,9 +485,11 @@ func WeekToResponse(week database.Week) WeekResponse {
 		Id:            week.ID,
 		WeekNumber:    week.WeekNumber,
 		Season:        week.Season,
+		SeasonType:    week.SeasonType,
 		WeekStartTime: week.WeekStartTime,
 		WeekEndTime:   week.WeekEndTime,
 		IsActive:      week.IsActive,
+		Status:        WeekStatus(week.Status),
 		CreatedAt:     week.CreatedAt,
 		UpdatedAt:     week.UpdatedAt,
 	}
@@ -334,6 +505,13 @@ func WeekFromRequest(req WeekRequest) (database.Week, error) {
 		IsActive:      req.IsActive,
 	}
 
+	// Derive the season type from the week number when not provided
+	if req.SeasonType != nil {
+		week.SeasonType = *req.SeasonType
+	} else {
+		week.SeasonType = database.SeasonTypeForWeek(req.WeekNumber)
+	}
+
 	// Validate the week
 	if err := validate.Struct(week); err != nil {
 		return database.Week{}, fmt.Errorf("invalid week data: %w", err)
@@ -341,3 +519,215 @@ func WeekFromRequest(req WeekRequest) (database.Week, error) {
 
 	return week, nil
 }
+
+// JobRunToResponse converts a database JobRun to a JobRunResponse.
+func JobRunToResponse(run database.JobRun) JobRunResponse {
+	response := JobRunResponse{
+		Id:          run.ID,
+		JobName:     run.JobName,
+		TriggeredBy: JobRunResponseTriggeredBy(run.TriggeredBy),
+		Status:      JobRunResponseStatus(run.Status),
+		Attempts:    run.Attempts,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+	}
+	if run.Error != "" {
+		response.Error = &run.Error
+	}
+	return response
+}
+
+// WeekSyncStatusToResponse converts a database WeekSyncStatus to a WeekSyncStatusResponse.
+func WeekSyncStatusToResponse(status database.WeekSyncStatus) WeekSyncStatusResponse {
+	response := WeekSyncStatusResponse{
+		Season:        status.Season,
+		Week:          status.Week,
+		LastAttemptAt: status.LastAttemptAt,
+		LastSuccessAt: status.LastSuccessAt,
+		GamesCreated:  status.GamesCreated,
+		GamesUpdated:  status.GamesUpdated,
+	}
+	if status.LastError != "" {
+		response.LastError = &status.LastError
+	}
+	return response
+}
+
+// UpstreamStatsToResponse converts upstream client stats to an UpstreamStatsResponse.
+func UpstreamStatsToResponse(stats upstream.Stats) UpstreamStatsResponse {
+	return UpstreamStatsResponse{
+		Name:     stats.Name,
+		State:    UpstreamStatsResponseState(stats.State),
+		Requests: stats.Requests,
+		Attempts: stats.Attempts,
+		Retries:  stats.Retries,
+		Failures: stats.Failures,
+		Rejected: stats.Rejected,
+	}
+}
+
+// OddsQuotaToResponse converts an Odds API quota to an OddsQuotaResponse.
+func OddsQuotaToResponse(quota oddssync.Quota) OddsQuotaResponse {
+	return OddsQuotaResponse{
+		Period:            quota.Period,
+		RequestsUsed:      quota.RequestsUsed,
+		RequestsRemaining: quota.RequestsRemaining,
+		BudgetFloor:       quota.BudgetFloor,
+		BudgetReached:     quota.BudgetReached(),
+		LastRequestAt:     quota.LastRequestAt,
+	}
+}
+
+// SyncCacheToResponse converts the ESPN cache statistics and entries to a SyncCacheResponse.
+func SyncCacheToResponse(stats espnsync.CacheStats, entries []espnsync.CacheEntry, now time.Time) SyncCacheResponse {
+	response := SyncCacheResponse{
+		Backend:   stats.Backend,
+		Hits:      stats.Hits,
+		Misses:    stats.Misses,
+		Sets:      stats.Sets,
+		Evictions: stats.Evictions,
+		Entries:   make([]CacheEntryResponse, len(entries)),
+	}
+	for i, entry := range entries {
+		response.Entries[i] = CacheEntryResponse{
+			Season:    entry.Season,
+			Week:      entry.Week,
+			Events:    entry.Events,
+			CachedAt:  entry.CachedAt,
+			ExpiresAt: entry.ExpiresAt,
+			Expired:   entry.Expired(now),
+		}
+	}
+	return response
+}
+
+// SeasonToResponse converts a database Season to a SeasonResponse.
+func SeasonToResponse(season database.Season) SeasonResponse {
+	return SeasonResponse{
+		Year:       season.Year,
+		State:      SeasonState(season.State),
+		ArchivedAt: season.ArchivedAt,
+	}
+}
+
+// ArchivedStandingToResponse converts a database ArchivedStanding to a StandingResponse.
+func ArchivedStandingToResponse(standing database.ArchivedStanding) StandingResponse {
+	return StandingResponse{
+		PlayerId:   standing.UserID,
+		PlayerName: standing.PlayerName,
+		Score:      standing.Score,
+		Rank:       standing.Rank,
+	}
+}
+
+// ArchivedSurvivorResultToResponse converts a database ArchivedSurvivorResult to a SurvivorResultResponse.
+func ArchivedSurvivorResultToResponse(result database.ArchivedSurvivorResult) SurvivorResultResponse {
+	return SurvivorResultResponse{
+		PlayerId:   result.UserID,
+		PlayerName: result.PlayerName,
+		Week:       result.Week,
+		Team:       result.Team,
+		Outcome:    SurvivorResultResponseOutcome(result.Outcome),
+	}
+}
+
+// WeeklyScoreToResponse converts a database WeeklyScore to a WeeklyResult. gamesRemaining is
+// the number of games of the week without a result.
+func WeeklyScoreToResponse(score database.WeeklyScore, gamesRemaining int) WeeklyResult {
+	return WeeklyResult{
+		PlayerId:       score.UserID,
+		PlayerName:     score.PlayerName,
+		Score:          score.Points,
+		Rank:           score.Rank,
+		SeasonRank:     score.SeasonRank,
+		Movement:       movement(score.PreviousSeasonRank, score.SeasonRank),
+		Correct:        score.Correct,
+		Incorrect:      score.Incorrect,
+		Pushes:         score.Pushes,
+		GamesRemaining: gamesRemaining,
+		MaxPoints:      score.MaxPoints,
+		QuickPick:      score.QuickPick,
+	}
+}
+
+// SeasonStandingToResponse converts a database SeasonStanding to a SeasonResult.
+// gamesRemaining is the number of games counting toward the pool without a result.
+func SeasonStandingToResponse(standing database.SeasonStanding, gamesRemaining int) SeasonResult {
+	return SeasonResult{
+		PlayerId:       standing.UserID,
+		PlayerName:     standing.PlayerName,
+		Score:          standing.Points,
+		Rank:           standing.Rank,
+		Movement:       movement(standing.PreviousRank, standing.Rank),
+		Correct:        standing.Correct,
+		Incorrect:      standing.Incorrect,
+		Pushes:         standing.Pushes,
+		GamesRemaining: gamesRemaining,
+		MaxPoints:      standing.MaxPoints,
+	}
+}
+
+// movement returns the places gained from the previous rank to the current one. Players
+// without a previous rank have not moved.
+func movement(previous, current int) int {
+	if previous == 0 || current == 0 {
+		return 0
+	}
+	return previous - current
+}
+
+// LiveWeekToResponse converts the provisional leaderboard of a week to a LiveWeekResponse.
+func LiveWeekToResponse(live seasons.LiveWeek) LiveWeekResponse {
+	response := LiveWeekResponse{
+		Season:  live.Season,
+		Week:    live.Week,
+		Games:   make([]LiveGameResponse, len(live.Games)),
+		Players: make([]LivePlayerResult, len(live.Players)),
+	}
+	for i, game := range live.Games {
+		response.Games[i] = LiveGameToResponse(game)
+	}
+	for i, player := range live.Players {
+		response.Players[i] = LivePlayerResult{
+			PlayerId:      player.UserID,
+			PlayerName:    player.PlayerName,
+			Rank:          player.Rank,
+			Score:         player.Points,
+			PointsSecured: player.SecuredPoints,
+			PointsAtRisk:  player.AtRiskPoints,
+			MaxPoints:     player.MaxPoints,
+			Correct:       player.Correct,
+			Incorrect:     player.Incorrect,
+			Pushes:        player.Pushes,
+			Pending:       player.Pending,
+		}
+	}
+	return response
+}
+
+// LiveGameToResponse converts a game with its live score to a LiveGameResponse. Games with a
+// result are final, and games entered by hand are scheduled until then.
+func LiveGameToResponse(game seasons.LiveGame) LiveGameResponse {
+	response := LiveGameResponse{
+		GameId:    game.ID,
+		HomeTeam:  game.HomeTeam,
+		AwayTeam:  game.AwayTeam,
+		Favorite:  ConvertStringPointerToTeamDesignationPointer(game.Favorite),
+		Spread:    game.Spread,
+		State:     GameState(game.State),
+		HomeScore: game.HomeScore,
+		AwayScore: game.AwayScore,
+		Period:    game.Period,
+		Clock:     game.Clock,
+	}
+	switch {
+	case game.Final:
+		response.State = GameFinal
+	case game.State == "":
+		response.State = GameScheduled
+	}
+	if game.Outcome != "" {
+		response.Outcome = &game.Outcome
+	}
+	return response
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/seasons"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -124,6 +125,7 @@ func TestGameToResponse(t *testing.T) {
 	assert.Equal(t, expectedUnd, response.Underdog)
 	assert.Equal(t, game.Spread, response.Spread)
 	assert.Equal(t, game.StartTime, response.StartTime)
+	assert.Equal(t, game.SeasonType, response.SeasonType)
 }
 
 func TestGameFromRequest(t *testing.T) {
@@ -154,6 +156,47 @@ func TestGameFromRequest(t *testing.T) {
 	assert.Equal(t, expectedUnd, game.Underdog)
 	assert.Equal(t, req.Spread, game.Spread)
 	assert.Equal(t, req.StartTime, game.StartTime)
+	assert.Equal(t, database.SeasonTypeRegular, game.SeasonType)
+}
+
+func TestGameFromRequestSeasonType(t *testing.T) {
+	now := time.Now()
+
+	// Postseason weeks are derived from the week number
+	game, err := GameFromRequest(GameRequest{
+		Week:      database.WildCardWeek,
+		Season:    2025,
+		HomeTeam:  "Lions",
+		AwayTeam:  "Chiefs",
+		StartTime: now,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, database.SeasonTypePostseason, game.SeasonType)
+
+	// An explicit season type is kept
+	preseason := database.SeasonTypePreseason
+	game, err = GameFromRequest(GameRequest{
+		Week:       2,
+		Season:     2025,
+		SeasonType: &preseason,
+		HomeTeam:   "Lions",
+		AwayTeam:   "Chiefs",
+		StartTime:  now,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, database.SeasonTypePreseason, game.SeasonType)
+
+	// Unknown season types are rejected
+	invalid := 7
+	_, err = GameFromRequest(GameRequest{
+		Week:       2,
+		Season:     2025,
+		SeasonType: &invalid,
+		HomeTeam:   "Lions",
+		AwayTeam:   "Chiefs",
+		StartTime:  now,
+	})
+	assert.Error(t, err)
 }
 
 func TestPickToResponse(t *testing.T) {
@@ -268,6 +311,7 @@ func TestSurvivorPickToResponse(t *testing.T) {
 			UpdatedAt: now,
 		},
 		UserID: 1,
+		Season: 2025,
 		Week:   1,
 		Team:   "Lions",
 	}
@@ -276,13 +320,16 @@ func TestSurvivorPickToResponse(t *testing.T) {
 
 	assert.Equal(t, pick.ID, response.Id)
 	assert.Equal(t, pick.UserID, response.UserId)
+	assert.Equal(t, pick.Season, response.Season)
 	assert.Equal(t, pick.Week, response.Week)
 	assert.Equal(t, pick.Team, response.Team)
 }
 
 func TestSurvivorPickFromRequest(t *testing.T) {
 	userID := uint(1)
+	season := 2025
 	req := SurvivorPickRequest{
+		Season: &season,
 		Week:   1,
 		Team:   "Lions",
 		UserId: &userID,
@@ -290,11 +337,25 @@ func TestSurvivorPickFromRequest(t *testing.T) {
 
 	pick := SurvivorPickFromRequest(req)
 
+	assert.Equal(t, season, pick.Season)
 	assert.Equal(t, req.Week, pick.Week)
 	assert.Equal(t, req.Team, pick.Team)
 	assert.Equal(t, *req.UserId, pick.UserID)
 }
 
+func TestTiebreakerToResponse(t *testing.T) {
+	game := database.Game{Season: 2025, Week: 3}
+	game.ID = 42
+
+	response := TiebreakerToResponse(game, nil)
+	assert.Equal(t, TiebreakerResponse{Season: 2025, Week: 3, GameId: 42}, response)
+
+	predictedTotal := 47
+	submittedAt := time.Date(2025, 9, 21, 12, 0, 0, 0, time.UTC)
+	response = TiebreakerToResponse(game, &database.Tiebreaker{PredictedTotal: predictedTotal, SubmittedAt: submittedAt})
+	assert.Equal(t, TiebreakerResponse{Season: 2025, Week: 3, GameId: 42, PredictedTotal: &predictedTotal, SubmittedAt: &submittedAt}, response)
+}
+
 func TestPlayerToResponse(t *testing.T) {
 	player := database.Player{
 		Model: gorm.Model{
@@ -883,3 +944,46 @@ func stringPtr(s string) *string {
 func uintPtr(u uint) *uint {
 	return &u
 }
+
+func TestWeeklyScoreToResponse(t *testing.T) {
+	score := database.WeeklyScore{UserID: 3, PlayerName: "Carol", Points: 12.5, Correct: 4, Pushes: 1, MaxPoints: 20, QuickPick: true, Rank: 1, SeasonRank: 2, PreviousSeasonRank: 5}
+
+	response := WeeklyScoreToResponse(score, 3)
+	assert.Equal(t, WeeklyResult{PlayerId: 3, PlayerName: "Carol", Score: 12.5, Rank: 1, SeasonRank: 2, Movement: 3, Correct: 4, Pushes: 1, GamesRemaining: 3, MaxPoints: 20, QuickPick: true}, response)
+
+	// Players have not moved in the first week
+	score.PreviousSeasonRank = 0
+	assert.Equal(t, 0, WeeklyScoreToResponse(score, 0).Movement)
+}
+
+func TestSeasonStandingToResponse(t *testing.T) {
+	standing := database.SeasonStanding{UserID: 4, PlayerName: "Dave", Points: 40, Correct: 10, Incorrect: 6, MaxPoints: 52, Rank: 4, PreviousRank: 2}
+
+	response := SeasonStandingToResponse(standing, 16)
+	assert.Equal(t, SeasonResult{PlayerId: 4, PlayerName: "Dave", Score: 40, Rank: 4, Movement: -2, Correct: 10, Incorrect: 6, GamesRemaining: 16, MaxPoints: 52}, response)
+}
+
+func TestLiveWeekToResponse(t *testing.T) {
+	live := seasons.LiveWeek{
+		Season: 2025,
+		Week:   1,
+		Games: []seasons.LiveGame{
+			{Game: database.Game{HomeTeam: "Eagles", AwayTeam: "Cowboys", Spread: 7, State: database.GameStateInProgress, HomeScore: 21, AwayScore: 10, Period: 3, Clock: "8:41"}, Outcome: "favorite"},
+			// A game entered by hand
+			{Game: database.Game{HomeTeam: "Chiefs", AwayTeam: "Broncos", Spread: 3}},
+			{Game: database.Game{HomeTeam: "Bills", AwayTeam: "Jets", Spread: 6.5, HomeScore: 30, AwayScore: 10}, Outcome: "favorite", Final: true},
+		},
+		Players: []seasons.LivePlayer{
+			{UserID: 2, PlayerName: "Bob", Rank: 1, Points: 9, SecuredPoints: 5, AtRiskPoints: 4, MaxPoints: 12, Correct: 2, Pending: 1},
+		},
+	}
+
+	response := LiveWeekToResponse(live)
+	assert.Equal(t, GameInProgress, response.Games[0].State)
+	assert.Equal(t, "favorite", *response.Games[0].Outcome)
+	assert.Equal(t, "8:41", response.Games[0].Clock)
+	assert.Equal(t, GameScheduled, response.Games[1].State)
+	assert.Nil(t, response.Games[1].Outcome)
+	assert.Equal(t, GameFinal, response.Games[2].State)
+	assert.Equal(t, []LivePlayerResult{{PlayerId: 2, PlayerName: "Bob", Rank: 1, Score: 9, PointsSecured: 5, PointsAtRisk: 4, MaxPoints: 12, Correct: 2, Pending: 1}}, response.Players)
+}
@@ -5,18 +5,137 @@ package api
 
 import (
 	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for GameState.
+const (
+	GameFinal      GameState = "post"
+	GameInProgress GameState = "in"
+	GameScheduled  GameState = "pre"
+)
+
+// Defines values for JobRunResponseStatus.
+const (
+	Failed    JobRunResponseStatus = "failed"
+	Running   JobRunResponseStatus = "running"
+	Succeeded JobRunResponseStatus = "succeeded"
+)
+
+// Defines values for JobRunResponseTriggeredBy.
+const (
+	Manual   JobRunResponseTriggeredBy = "manual"
+	Schedule JobRunResponseTriggeredBy = "schedule"
+	Startup  JobRunResponseTriggeredBy = "startup"
+)
+
+// Defines values for SeasonStandingsResponsePool.
+const (
+	SeasonStandingsResponsePoolPlayoffs SeasonStandingsResponsePool = "playoffs"
+	SeasonStandingsResponsePoolSeason   SeasonStandingsResponsePool = "season"
+)
+
+// Defines values for SeasonState.
+const (
+	SeasonActive   SeasonState = "active"
+	SeasonComplete SeasonState = "complete"
+	SeasonUpcoming SeasonState = "upcoming"
+)
+
+// Defines values for SurvivorResultResponseOutcome.
+const (
+	SurvivorLoss    SurvivorResultResponseOutcome = "loss"
+	SurvivorNoGame  SurvivorResultResponseOutcome = "no_game"
+	SurvivorPending SurvivorResultResponseOutcome = "pending"
+	SurvivorTie     SurvivorResultResponseOutcome = "tie"
+	SurvivorWin     SurvivorResultResponseOutcome = "win"
+)
+
 // Defines values for TeamDesignation.
 const (
 	Away TeamDesignation = "Away"
 	Home TeamDesignation = "Home"
 )
 
+// Defines values for UpstreamStatsResponseState.
+const (
+	UpstreamStatsResponseStateClosed   UpstreamStatsResponseState = "closed"
+	UpstreamStatsResponseStateHalfOpen UpstreamStatsResponseState = "half-open"
+	UpstreamStatsResponseStateOpen     UpstreamStatsResponseState = "open"
+)
+
+// Defines values for WeekStatus.
+const (
+	WeekStatusFinal    WeekStatus = "final"
+	WeekStatusLocked   WeekStatus = "locked"
+	WeekStatusOpen     WeekStatus = "open"
+	WeekStatusScoring  WeekStatus = "scoring"
+	WeekStatusUpcoming WeekStatus = "upcoming"
+)
+
+// Defines values for GetSeasonStandingsParamsPool.
+const (
+	GetSeasonStandingsParamsPoolPlayoffs GetSeasonStandingsParamsPool = "playoffs"
+	GetSeasonStandingsParamsPoolSeason   GetSeasonStandingsParamsPool = "season"
+)
+
+// BulkSpreadEntry defines model for BulkSpreadEntry.
+type BulkSpreadEntry struct {
+	AwayTeam string `json:"away_team"`
+
+	// Favorite Home, Away or the name of the favored team; may be omitted for an even spread
+	Favorite *string `json:"favorite,omitempty"`
+	HomeTeam string  `json:"home_team"`
+	Spread   float32 `json:"spread"`
+}
+
+// BulkSpreadsRequest defines model for BulkSpreadsRequest.
+type BulkSpreadsRequest struct {
+	Entries []BulkSpreadEntry `json:"entries"`
+}
+
+// BulkSpreadsResponse defines model for BulkSpreadsResponse.
+type BulkSpreadsResponse struct {
+	Unmatched []UnmatchedSpreadEntryResponse `json:"unmatched"`
+	Updated   []GameResponse                 `json:"updated"`
+}
+
+// CacheEntryResponse defines model for CacheEntryResponse.
+type CacheEntryResponse struct {
+	CachedAt time.Time `json:"cached_at"`
+
+	// Events Number of cached events
+	Events  int  `json:"events"`
+	Expired bool `json:"expired"`
+
+	// ExpiresAt Absent for entries that never expire
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Season    int        `json:"season"`
+	Week      int        `json:"week"`
+}
+
+// ClockRequest Exactly one of now and advance_seconds must be set
+type ClockRequest struct {
+	// AdvanceSeconds Seconds to move the clock forward, or back when negative
+	AdvanceSeconds *int64 `json:"advance_seconds,omitempty"`
+
+	// Now Time to move the clock to
+	Now *time.Time `json:"now,omitempty"`
+}
+
+// ClockResponse defines model for ClockResponse.
+type ClockResponse struct {
+	Now time.Time `json:"now"`
+
+	// OffsetSeconds How far the application clock is ahead of the system clock, or behind it when negative
+	OffsetSeconds int64 `json:"offset_seconds"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error   string  `json:"error"`
@@ -31,29 +150,153 @@ type GameListResponse struct {
 
 // GameRequest defines model for GameRequest.
 type GameRequest struct {
-	AwayTeam  string           `json:"away_team"`
-	Favorite  *TeamDesignation `json:"favorite,omitempty"`
-	HomeTeam  string           `json:"home_team"`
-	Season    int              `json:"season"`
-	Spread    float32          `json:"spread"`
-	StartTime time.Time        `json:"start_time"`
-	Underdog  *TeamDesignation `json:"underdog,omitempty"`
-	Week      int              `json:"week"`
+	AwayTeam string           `json:"away_team"`
+	Favorite *TeamDesignation `json:"favorite,omitempty"`
+	HomeTeam string           `json:"home_team"`
+	Season   int              `json:"season"`
+
+	// SeasonType 1 = preseason, 2 = regular season, 3 = postseason
+	SeasonType *int             `json:"season_type,omitempty"`
+	Spread     float32          `json:"spread"`
+	StartTime  time.Time        `json:"start_time"`
+	Underdog   *TeamDesignation `json:"underdog,omitempty"`
+	Week       int              `json:"week"`
 }
 
 // GameResponse defines model for GameResponse.
 type GameResponse struct {
-	AwayTeam  string           `json:"away_team"`
-	CreatedAt time.Time        `json:"created_at"`
+	AwayTeam string `json:"away_team"`
+
+	// AwayTeamId Registered away team, absent if its name could not be resolved
+	AwayTeamId *uint     `json:"away_team_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// EspnEventId ESPN event the game was synced from, absent for games entered by hand
+	EspnEventId *string          `json:"espn_event_id,omitempty"`
+	Favorite    *TeamDesignation `json:"favorite,omitempty"`
+	HomeTeam    string           `json:"home_team"`
+
+	// HomeTeamId Registered home team, absent if its name could not be resolved
+	HomeTeamId *uint `json:"home_team_id,omitempty"`
+	Id         uint  `json:"id"`
+	Season     int   `json:"season"`
+
+	// SeasonType 1 = preseason, 2 = regular season, 3 = postseason
+	SeasonType int     `json:"season_type"`
+	Spread     float32 `json:"spread"`
+
+	// SpreadBookmakers Bookmakers the pool line was derived from
+	SpreadBookmakers *[]string `json:"spread_bookmakers,omitempty"`
+
+	// SpreadLockedAt When the pool line was frozen, absent while it follows the market
+	SpreadLockedAt *time.Time `json:"spread_locked_at,omitempty"`
+
+	// SpreadStrategy Spread strategy that chose the pool line
+	SpreadStrategy *string          `json:"spread_strategy,omitempty"`
+	StartTime      time.Time        `json:"start_time"`
+	Underdog       *TeamDesignation `json:"underdog,omitempty"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	Venue          *string          `json:"venue,omitempty"`
+	Week           int              `json:"week"`
+}
+
+// GameState defines model for GameState.
+type GameState string
+
+// JobListResponse defines model for JobListResponse.
+type JobListResponse struct {
+	Jobs []JobResponse `json:"jobs"`
+}
+
+// JobResponse defines model for JobResponse.
+type JobResponse struct {
+	Description string          `json:"description"`
+	LastRun     *JobRunResponse `json:"last_run,omitempty"`
+	Name        string          `json:"name"`
+	NextRunAt   *time.Time      `json:"next_run_at,omitempty"`
+	Running     bool            `json:"running"`
+
+	// Schedule Cron expression or @every interval; empty for jobs that only run at startup or on demand
+	Schedule *string `json:"schedule,omitempty"`
+}
+
+// JobRunListResponse defines model for JobRunListResponse.
+type JobRunListResponse struct {
+	Runs []JobRunResponse `json:"runs"`
+}
+
+// JobRunResponse defines model for JobRunResponse.
+type JobRunResponse struct {
+	Attempts    int                       `json:"attempts"`
+	Error       *string                   `json:"error,omitempty"`
+	FinishedAt  *time.Time                `json:"finished_at,omitempty"`
+	Id          uint                      `json:"id"`
+	JobName     string                    `json:"job_name"`
+	StartedAt   time.Time                 `json:"started_at"`
+	Status      JobRunResponseStatus      `json:"status"`
+	TriggeredBy JobRunResponseTriggeredBy `json:"triggered_by"`
+}
+
+// JobRunResponseStatus defines model for JobRunResponse.Status.
+type JobRunResponseStatus string
+
+// JobRunResponseTriggeredBy defines model for JobRunResponse.TriggeredBy.
+type JobRunResponseTriggeredBy string
+
+// LiveGameResponse defines model for LiveGameResponse.
+type LiveGameResponse struct {
+	AwayScore int    `json:"away_score"`
+	AwayTeam  string `json:"away_team"`
+
+	// Clock Game clock of a game in progress
+	Clock     string           `json:"clock"`
 	Favorite  *TeamDesignation `json:"favorite,omitempty"`
+	GameId    uint             `json:"game_id"`
+	HomeScore int              `json:"home_score"`
 	HomeTeam  string           `json:"home_team"`
-	Id        uint             `json:"id"`
-	Season    int              `json:"season"`
-	Spread    float32          `json:"spread"`
-	StartTime time.Time        `json:"start_time"`
-	Underdog  *TeamDesignation `json:"underdog,omitempty"`
-	UpdatedAt time.Time        `json:"updated_at"`
-	Week      int              `json:"week"`
+
+	// Outcome Outcome against the spread, provisional while the game is in progress and absent before kickoff
+	Outcome *string `json:"outcome,omitempty"`
+
+	// Period Quarter of a game in progress
+	Period int       `json:"period"`
+	Spread float32   `json:"spread"`
+	State  GameState `json:"state"`
+}
+
+// LivePlayerResult defines model for LivePlayerResult.
+type LivePlayerResult struct {
+	Correct   int `json:"correct"`
+	Incorrect int `json:"incorrect"`
+
+	// MaxPoints The most the player can score if every pick without a final result is correct, counting the games they can still pick
+	MaxPoints float32 `json:"max_points"`
+
+	// Pending Picks on games that have not kicked off
+	Pending    int    `json:"pending"`
+	PlayerId   uint   `json:"player_id"`
+	PlayerName string `json:"player_name"`
+
+	// PointsAtRisk Points scored on the current score of the games in progress
+	PointsAtRisk float32 `json:"points_at_risk"`
+
+	// PointsSecured Points scored on final results
+	PointsSecured float32 `json:"points_secured"`
+	Pushes        int     `json:"pushes"`
+
+	// Rank Rank by provisional score. Tied players share a rank
+	Rank int `json:"rank"`
+
+	// Score Provisional score if the games in progress ended at their current score
+	Score float32 `json:"score"`
+}
+
+// LiveWeekResponse defines model for LiveWeekResponse.
+type LiveWeekResponse struct {
+	Games   []LiveGameResponse `json:"games"`
+	Players []LivePlayerResult `json:"players"`
+	Season  int                `json:"season"`
+	Week    int                `json:"week"`
 }
 
 // LoginRequest defines model for LoginRequest.
@@ -68,6 +311,21 @@ type LoginResponse struct {
 	User  UserResponse `json:"user"`
 }
 
+// OddsQuotaResponse The Odds API request quota of the current billing period
+type OddsQuotaResponse struct {
+	// BudgetFloor Remaining requests below which routine fetches are refused
+	BudgetFloor int `json:"budget_floor"`
+
+	// BudgetReached Whether routine fetches are being refused
+	BudgetReached bool      `json:"budget_reached"`
+	LastRequestAt time.Time `json:"last_request_at"`
+
+	// Period Start date of the billing period (YYYY-MM-DD)
+	Period            string `json:"period"`
+	RequestsRemaining int    `json:"requests_remaining"`
+	RequestsUsed      int    `json:"requests_used"`
+}
+
 // PaginationResponse defines model for PaginationResponse.
 type PaginationResponse struct {
 	Limit int   `json:"limit"`
@@ -84,11 +342,14 @@ type PickListResponse struct {
 
 // PickRequest defines model for PickRequest.
 type PickRequest struct {
-	GameId    uint   `json:"game_id"`
-	Picked    string `json:"picked"`
-	QuickPick bool   `json:"quick_pick"`
-	Rank      int    `json:"rank"`
-	UserId    *uint  `json:"user_id,omitempty"`
+	GameId uint   `json:"game_id"`
+	Picked string `json:"picked"`
+
+	// PredictedTotal Predicted total points of both teams in the week's tiebreaker game, submitted along with the sheet. Picks of the same week must not disagree
+	PredictedTotal *int  `json:"predicted_total,omitempty"`
+	QuickPick      bool  `json:"quick_pick"`
+	Rank           int   `json:"rank"`
+	UserId         *uint `json:"user_id,omitempty"`
 }
 
 // PickResponse defines model for PickResponse.
@@ -152,15 +413,135 @@ type ResultResponse struct {
 	UpdatedAt     time.Time     `json:"updated_at"`
 }
 
+// ScheduleChangeListResponse defines model for ScheduleChangeListResponse.
+type ScheduleChangeListResponse struct {
+	Changes []ScheduleChangeResponse `json:"changes"`
+}
+
+// ScheduleChangeResponse defines model for ScheduleChangeResponse.
+type ScheduleChangeResponse struct {
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+
+	// Alert Whether the change affects picks and has not been acknowledged
+	Alert      bool      `json:"alert"`
+	AwayTeam   string    `json:"away_team"`
+	DetectedAt time.Time `json:"detected_at"`
+	GameId     uint      `json:"game_id"`
+	HomeTeam   string    `json:"home_team"`
+	Id         uint      `json:"id"`
+
+	// PicksAttached Picks the game had when the change was detected
+	PicksAttached     int       `json:"picks_attached"`
+	PreviousStartTime time.Time `json:"previous_start_time"`
+	PreviousVenue     *string   `json:"previous_venue,omitempty"`
+	PreviousWeek      int       `json:"previous_week"`
+	Season            int       `json:"season"`
+	StartTime         time.Time `json:"start_time"`
+	Venue             *string   `json:"venue,omitempty"`
+	Week              int       `json:"week"`
+}
+
+// SeasonListResponse defines model for SeasonListResponse.
+type SeasonListResponse struct {
+	// CurrentSeason The season being played or prepared
+	CurrentSeason int              `json:"current_season"`
+	Seasons       []SeasonResponse `json:"seasons"`
+}
+
+// SeasonResponse defines model for SeasonResponse.
+type SeasonResponse struct {
+	ArchivedAt *time.Time  `json:"archived_at,omitempty"`
+	State      SeasonState `json:"state"`
+	Year       int         `json:"year"`
+}
+
 // SeasonResult defines model for SeasonResult.
 type SeasonResult struct {
+	Correct int `json:"correct"`
+
+	// GamesRemaining Games counting toward the pool without a result
+	GamesRemaining int `json:"games_remaining"`
+	Incorrect      int `json:"incorrect"`
+
+	// MaxPoints Most the player can score if every pending pick is correct and every game they can still pick, with the confidence ranks they have left, is picked correctly
+	MaxPoints float32 `json:"max_points"`
+
+	// Movement Places gained since the prior week, negative when the player dropped
+	Movement   int    `json:"movement"`
 	PlayerId   uint   `json:"player_id"`
 	PlayerName string `json:"player_name"`
-	Score      int    `json:"score"`
+	Pushes     int    `json:"pushes"`
+
+	// Rank Tied players share a rank and the next rank skips past them
+	Rank  int     `json:"rank"`
+	Score float32 `json:"score"`
+}
+
+// SeasonStandingsResponse defines model for SeasonStandingsResponse.
+type SeasonStandingsResponse struct {
+	// Archived Whether the standings are the frozen final standings of a complete season
+	Archived  bool                        `json:"archived"`
+	Pool      SeasonStandingsResponsePool `json:"pool"`
+	Season    int                         `json:"season"`
+	Standings []StandingResponse          `json:"standings"`
+}
+
+// SeasonStandingsResponsePool defines model for SeasonStandingsResponse.Pool.
+type SeasonStandingsResponsePool string
+
+// SeasonState defines model for SeasonState.
+type SeasonState string
+
+// SeasonSurvivorResponse defines model for SeasonSurvivorResponse.
+type SeasonSurvivorResponse struct {
+	// Archived Whether the results are the frozen results of a complete season
+	Archived bool                     `json:"archived"`
+	Results  []SurvivorResultResponse `json:"results"`
+	Season   int                      `json:"season"`
+}
+
+// SpreadHistoryResponse defines model for SpreadHistoryResponse.
+type SpreadHistoryResponse struct {
+	Favorite *TeamDesignation `json:"favorite,omitempty"`
+	GameId   uint             `json:"game_id"`
+
+	// LockedAt When the pool line was frozen, absent while it follows the market
+	LockedAt *time.Time `json:"locked_at,omitempty"`
+
+	// Movement Points the home team's line moved from the first to the latest snapshot. Negative when the line moved toward the home team.
+	Movement  float32                  `json:"movement"`
+	Snapshots []SpreadSnapshotResponse `json:"snapshots"`
+
+	// Spread The pool line
+	Spread float32 `json:"spread"`
+}
+
+// SpreadSnapshotResponse defines model for SpreadSnapshotResponse.
+type SpreadSnapshotResponse struct {
+	// Bookmakers Bookmakers the line was derived from
+	Bookmakers []string        `json:"bookmakers"`
+	Favorite   TeamDesignation `json:"favorite"`
+	FetchedAt  time.Time       `json:"fetched_at"`
+	Spread     float32         `json:"spread"`
+
+	// Strategy Spread strategy that chose the line
+	Strategy string `json:"strategy"`
+}
+
+// StandingResponse defines model for StandingResponse.
+type StandingResponse struct {
+	PlayerId   uint   `json:"player_id"`
+	PlayerName string `json:"player_name"`
+
+	// Rank Tied players share a rank
+	Rank  int     `json:"rank"`
+	Score float32 `json:"score"`
 }
 
 // SurvivorPickRequest defines model for SurvivorPickRequest.
 type SurvivorPickRequest struct {
+	// Season Season year, the current season by default
+	Season *int   `json:"season,omitempty"`
 	Team   string `json:"team"`
 	UserId *uint  `json:"user_id,omitempty"`
 	Week   int    `json:"week"`
@@ -170,6 +551,7 @@ type SurvivorPickRequest struct {
 type SurvivorPickResponse struct {
 	CreatedAt time.Time     `json:"created_at"`
 	Id        uint          `json:"id"`
+	Season    int           `json:"season"`
 	Team      string        `json:"team"`
 	UpdatedAt time.Time     `json:"updated_at"`
 	User      *UserResponse `json:"user,omitempty"`
@@ -177,9 +559,169 @@ type SurvivorPickResponse struct {
 	Week      int           `json:"week"`
 }
 
+// SurvivorResultResponse defines model for SurvivorResultResponse.
+type SurvivorResultResponse struct {
+	Outcome    SurvivorResultResponseOutcome `json:"outcome"`
+	PlayerId   uint                          `json:"player_id"`
+	PlayerName string                        `json:"player_name"`
+	Team       string                        `json:"team"`
+	Week       int                           `json:"week"`
+}
+
+// SurvivorResultResponseOutcome defines model for SurvivorResultResponse.Outcome.
+type SurvivorResultResponseOutcome string
+
+// SyncCacheResponse defines model for SyncCacheResponse.
+type SyncCacheResponse struct {
+	// Backend Cache backend: file, memory or database
+	Backend string               `json:"backend"`
+	Entries []CacheEntryResponse `json:"entries"`
+
+	// Evictions Entries removed to make room for new ones
+	Evictions int64 `json:"evictions"`
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Sets      int64 `json:"sets"`
+}
+
+// SyncStatusResponse defines model for SyncStatusResponse.
+type SyncStatusResponse struct {
+	Enabled       bool       `json:"enabled"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	LastError     *string    `json:"last_error,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+
+	// Live Whether games of the current week are in progress, which polls ESPN at the live interval
+	Live      bool       `json:"live"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+
+	// OddsQuota The Odds API request quota of the current billing period
+	OddsQuota *OddsQuotaResponse `json:"odds_quota,omitempty"`
+
+	// ScheduleAlerts Unacknowledged schedule changes of the season's games that have picks
+	ScheduleAlerts int                      `json:"schedule_alerts"`
+	Upstreams      []UpstreamStatsResponse  `json:"upstreams"`
+	Weeks          []WeekSyncStatusResponse `json:"weeks"`
+}
+
+// TeamAliasRequest defines model for TeamAliasRequest.
+type TeamAliasRequest struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+}
+
+// TeamAliasResponse defines model for TeamAliasResponse.
+type TeamAliasResponse struct {
+	// Name Normalized name
+	Name string `json:"name"`
+
+	// Provider Data source using the name, such as espn, theoddsapi, file or manual
+	Provider string `json:"provider"`
+}
+
 // TeamDesignation defines model for TeamDesignation.
 type TeamDesignation string
 
+// TeamListResponse defines model for TeamListResponse.
+type TeamListResponse struct {
+	Teams []TeamResponse `json:"teams"`
+}
+
+// TeamResponse defines model for TeamResponse.
+type TeamResponse struct {
+	Abbreviation   string              `json:"abbreviation"`
+	Aliases        []TeamAliasResponse `json:"aliases"`
+	AlternateColor *string             `json:"alternate_color,omitempty"`
+
+	// Color Primary color as a hex value without the leading #
+	Color       *string `json:"color,omitempty"`
+	DisplayName string  `json:"display_name"`
+	EspnId      string  `json:"espn_id"`
+	Id          uint    `json:"id"`
+	Location    string  `json:"location"`
+	LogoUrl     *string `json:"logo_url,omitempty"`
+	Name        string  `json:"name"`
+}
+
+// TiebreakerRequest defines model for TiebreakerRequest.
+type TiebreakerRequest struct {
+	// PredictedTotal Predicted total points of both teams in the tiebreaker game
+	PredictedTotal int `json:"predicted_total"`
+
+	// Season Season year, the current season by default
+	Season *int `json:"season,omitempty"`
+	Week   int  `json:"week"`
+}
+
+// TiebreakerResponse defines model for TiebreakerResponse.
+type TiebreakerResponse struct {
+	// GameId The week's last game to kick off
+	GameId uint `json:"game_id"`
+
+	// PredictedTotal Absent until the user makes a prediction
+	PredictedTotal *int       `json:"predicted_total,omitempty"`
+	Season         int        `json:"season"`
+	SubmittedAt    *time.Time `json:"submitted_at,omitempty"`
+	Week           int        `json:"week"`
+}
+
+// UnmatchedSpreadEntryResponse defines model for UnmatchedSpreadEntryResponse.
+type UnmatchedSpreadEntryResponse struct {
+	AwayTeam string `json:"away_team"`
+	HomeTeam string `json:"home_team"`
+	Reason   string `json:"reason"`
+
+	// Row Position of the entry in the request, starting at 1
+	Row int `json:"row"`
+}
+
+// UnmatchedSpreadListResponse defines model for UnmatchedSpreadListResponse.
+type UnmatchedSpreadListResponse struct {
+	Unmatched []UnmatchedSpreadResponse `json:"unmatched"`
+}
+
+// UnmatchedSpreadResponse defines model for UnmatchedSpreadResponse.
+type UnmatchedSpreadResponse struct {
+	AwayTeam string `json:"away_team"`
+
+	// CommenceTime Kickoff reported by the provider
+	CommenceTime *time.Time `json:"commence_time,omitempty"`
+
+	// EventId Provider's ID for the event
+	EventId   *string   `json:"event_id,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+	HomeTeam  string    `json:"home_team"`
+
+	// Provider Odds provider the event came from
+	Provider string `json:"provider"`
+	Reason   string `json:"reason"`
+}
+
+// UpstreamStatsResponse defines model for UpstreamStatsResponse.
+type UpstreamStatsResponse struct {
+	// Attempts HTTP attempts, including retries
+	Attempts int64 `json:"attempts"`
+
+	// Failures Attempts that ended in a transport error, 5xx or 429 response
+	Failures int64 `json:"failures"`
+
+	// Name Name of the upstream API
+	Name string `json:"name"`
+
+	// Rejected Requests rejected by the open circuit breaker
+	Rejected int64 `json:"rejected"`
+
+	// Requests Requests made to the upstream
+	Requests int64 `json:"requests"`
+	Retries  int64 `json:"retries"`
+
+	// State State of the upstream's circuit breaker
+	State UpstreamStatsResponseState `json:"state"`
+}
+
+// UpstreamStatsResponseState State of the upstream's circuit breaker
+type UpstreamStatsResponseState string
+
 // UserListResponse defines model for UserListResponse.
 type UserListResponse struct {
 	Pagination PaginationResponse `json:"pagination"`
@@ -225,8 +767,11 @@ type WeekListResponse struct {
 
 // WeekRequest defines model for WeekRequest.
 type WeekRequest struct {
-	IsActive      bool      `json:"is_active"`
-	Season        int       `json:"season"`
+	IsActive bool `json:"is_active"`
+	Season   int  `json:"season"`
+
+	// SeasonType 1 = preseason, 2 = regular season, 3 = postseason
+	SeasonType    *int      `json:"season_type,omitempty"`
 	WeekEndTime   time.Time `json:"week_end_time"`
 	WeekNumber    int       `json:"week_number"`
 	WeekStartTime time.Time `json:"week_start_time"`
@@ -234,29 +779,101 @@ type WeekRequest struct {
 
 // WeekResponse defines model for WeekResponse.
 type WeekResponse struct {
-	CreatedAt     time.Time `json:"created_at"`
-	Id            uint      `json:"id"`
-	IsActive      bool      `json:"is_active"`
-	Season        int       `json:"season"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	WeekEndTime   time.Time `json:"week_end_time"`
-	WeekNumber    int       `json:"week_number"`
-	WeekStartTime time.Time `json:"week_start_time"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        uint      `json:"id"`
+	IsActive  bool      `json:"is_active"`
+	Season    int       `json:"season"`
+
+	// SeasonType 1 = preseason, 2 = regular season, 3 = postseason
+	SeasonType    int        `json:"season_type"`
+	Status        WeekStatus `json:"status"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	WeekEndTime   time.Time  `json:"week_end_time"`
+	WeekNumber    int        `json:"week_number"`
+	WeekStartTime time.Time  `json:"week_start_time"`
+}
+
+// WeekStatus defines model for WeekStatus.
+type WeekStatus string
+
+// WeekSyncStatusResponse defines model for WeekSyncStatusResponse.
+type WeekSyncStatusResponse struct {
+	// GamesCreated Games created by the last successful sync
+	GamesCreated int `json:"games_created"`
+
+	// GamesUpdated Games updated by the last successful sync
+	GamesUpdated  int       `json:"games_updated"`
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+
+	// LastError Error of the last attempt, absent if it succeeded
+	LastError     *string    `json:"last_error,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	Season        int        `json:"season"`
+	Week          int        `json:"week"`
 }
 
 // WeeklyResult defines model for WeeklyResult.
 type WeeklyResult struct {
+	Correct int `json:"correct"`
+
+	// GamesRemaining Games of the week without a result
+	GamesRemaining int `json:"games_remaining"`
+	Incorrect      int `json:"incorrect"`
+
+	// MaxPoints Most the player can score if every pending pick is correct and every game they can still pick, with the confidence ranks they have left, is picked correctly
+	MaxPoints float32 `json:"max_points"`
+
+	// Movement Places gained in the season standings since the prior week, negative when the player dropped
+	Movement   int    `json:"movement"`
 	PlayerId   uint   `json:"player_id"`
 	PlayerName string `json:"player_name"`
-	Score      int    `json:"score"`
+
+	// PredictedTotal Predicted total points of the week's tiebreaker game, absent without a prediction
+	PredictedTotal *int `json:"predicted_total,omitempty"`
+	Pushes         int  `json:"pushes"`
+
+	// QuickPick Whether the player used the quick pick for their entry
+	QuickPick bool `json:"quick_pick"`
+
+	// Rank Ties are broken by the configured tiebreakers. Players still tied share a rank and the next rank skips past them
+	Rank  int     `json:"rank"`
+	Score float32 `json:"score"`
+
+	// SeasonRank Rank in the season standings after the week
+	SeasonRank int `json:"season_rank"`
 }
 
 // CreateGameJSONBody defines parameters for CreateGame.
 type CreateGameJSONBody = []GameRequest
 
+// ListJobRunsParams defines parameters for ListJobRuns.
+type ListJobRunsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // AdminSubmitPicksJSONBody defines parameters for AdminSubmitPicks.
 type AdminSubmitPicksJSONBody = []PickRequest
 
+// ListScheduleChangesParams defines parameters for ListScheduleChanges.
+type ListScheduleChangesParams struct {
+	Season *int `form:"season,omitempty" json:"season,omitempty"`
+
+	// Alerts Only list unacknowledged changes to games that have picks
+	Alerts *bool `form:"alerts,omitempty" json:"alerts,omitempty"`
+}
+
+// ClearSyncCacheParams defines parameters for ClearSyncCache.
+type ClearSyncCacheParams struct {
+	// Expired Only remove the expired entries
+	Expired *bool `form:"expired,omitempty" json:"expired,omitempty"`
+}
+
+// GetSyncStatusParams defines parameters for GetSyncStatus.
+type GetSyncStatusParams struct {
+	// Season Defaults to the configured season
+	Season *int `form:"season,omitempty" json:"season,omitempty"`
+}
+
 // CreateUsersJSONBody defines parameters for CreateUsers.
 type CreateUsersJSONBody = []UserRequest
 
@@ -265,6 +882,12 @@ type DeleteUserByEmailParams struct {
 	Email string `form:"email" json:"email"`
 }
 
+// BulkUpdateWeekSpreadsMultipartBody defines parameters for BulkUpdateWeekSpreads.
+type BulkUpdateWeekSpreadsMultipartBody struct {
+	// File CSV file of spreads
+	File openapi_types.File `json:"file"`
+}
+
 // GetGamesParams defines parameters for GetGames.
 type GetGamesParams struct {
 	Week   int `form:"week" json:"week"`
@@ -274,6 +897,18 @@ type GetGamesParams struct {
 // SubmitPicksJSONBody defines parameters for SubmitPicks.
 type SubmitPicksJSONBody = []PickRequest
 
+// GetTiebreakerParams defines parameters for GetTiebreaker.
+type GetTiebreakerParams struct {
+	// Season Season year, the current season by default
+	Season *int `form:"season,omitempty" json:"season,omitempty"`
+	Week   int  `form:"week" json:"week"`
+}
+
+// GetPlayoffResultsParams defines parameters for GetPlayoffResults.
+type GetPlayoffResultsParams struct {
+	Season int `form:"season" json:"season"`
+}
+
 // GetSeasonResultsParams defines parameters for GetSeasonResults.
 type GetSeasonResultsParams struct {
 	Season int `form:"season" json:"season"`
@@ -285,6 +920,29 @@ type GetWeeklyResultsParams struct {
 	Season int `form:"season" json:"season"`
 }
 
+// GetLiveWeeklyResultsParams defines parameters for GetLiveWeeklyResults.
+type GetLiveWeeklyResultsParams struct {
+	Week   int `form:"week" json:"week"`
+	Season int `form:"season" json:"season"`
+}
+
+// GetSeasonStandingsParams defines parameters for GetSeasonStandings.
+type GetSeasonStandingsParams struct {
+	Pool *GetSeasonStandingsParamsPool `form:"pool,omitempty" json:"pool,omitempty"`
+}
+
+// GetSeasonStandingsParamsPool defines parameters for GetSeasonStandings.
+type GetSeasonStandingsParamsPool string
+
+// GetSurvivorPicksParams defines parameters for GetSurvivorPicks.
+type GetSurvivorPicksParams struct {
+	// Season Season year, the current season by default
+	Season *int `form:"season,omitempty" json:"season,omitempty"`
+}
+
+// SetClockJSONRequestBody defines body for SetClock for application/json ContentType.
+type SetClockJSONRequestBody = ClockRequest
+
 // CreateGameJSONRequestBody defines body for CreateGame for application/json ContentType.
 type CreateGameJSONRequestBody = CreateGameJSONBody
 
@@ -294,6 +952,9 @@ type UpdateGameJSONRequestBody = GameRequest
 // AdminSubmitPicksJSONRequestBody defines body for AdminSubmitPicks for application/json ContentType.
 type AdminSubmitPicksJSONRequestBody = AdminSubmitPicksJSONBody
 
+// AddTeamAliasJSONRequestBody defines body for AddTeamAlias for application/json ContentType.
+type AddTeamAliasJSONRequestBody = TeamAliasRequest
+
 // CreateUsersJSONRequestBody defines body for CreateUsers for application/json ContentType.
 type CreateUsersJSONRequestBody = CreateUsersJSONBody
 
@@ -306,12 +967,21 @@ type CreateWeekJSONRequestBody = WeekRequest
 // UpdateWeekJSONRequestBody defines body for UpdateWeek for application/json ContentType.
 type UpdateWeekJSONRequestBody = WeekRequest
 
+// BulkUpdateWeekSpreadsJSONRequestBody defines body for BulkUpdateWeekSpreads for application/json ContentType.
+type BulkUpdateWeekSpreadsJSONRequestBody = BulkSpreadsRequest
+
+// BulkUpdateWeekSpreadsMultipartRequestBody defines body for BulkUpdateWeekSpreads for multipart/form-data ContentType.
+type BulkUpdateWeekSpreadsMultipartRequestBody BulkUpdateWeekSpreadsMultipartBody
+
 // LoginUserJSONRequestBody defines body for LoginUser for application/json ContentType.
 type LoginUserJSONRequestBody = LoginRequest
 
 // SubmitPicksJSONRequestBody defines body for SubmitPicks for application/json ContentType.
 type SubmitPicksJSONRequestBody = SubmitPicksJSONBody
 
+// SubmitTiebreakerJSONRequestBody defines body for SubmitTiebreaker for application/json ContentType.
+type SubmitTiebreakerJSONRequestBody = TiebreakerRequest
+
 // RegisterUserJSONRequestBody defines body for RegisterUser for application/json ContentType.
 type RegisterUserJSONRequestBody = RegisterRequest
 
@@ -23,7 +23,12 @@ var validate = validator.New()
 
 var jwtKey = []byte("my_secret_key")
 
+// tokenLifetime is how long an issued token stays valid.
+const tokenLifetime = 5 * time.Minute
+
 // Auth provides authentication and authorization services.
+// Tokens expire by the system clock rather than the application clock, so that moving the
+// application clock does not end every session.
 type Auth struct {
 	db *database.Database
 }
@@ -68,7 +73,7 @@ func (a *Auth) Login(w http.ResponseWriter, r *http.Request) {
 	}
 	slog.Debug("Password comparison successful for user:", "email", creds.Email)
 
-	expirationTime := time.Now().Add(5 * time.Minute)
+	expirationTime := time.Now().Add(tokenLifetime)
 	claims := &Claims{
 		Email: creds.Email,
 		RegisteredClaims: jwt.RegisteredClaims{
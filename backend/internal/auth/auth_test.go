@@ -211,6 +211,47 @@ func TestMiddleware(t *testing.T) {
 	}
 }
 
+func TestMiddlewareUsesSystemClock(t *testing.T) {
+	db, err := database.New("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	auth := NewAuth(db)
+
+	protectedHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Tokens expire by the system clock, whatever time the application clock shows
+	for _, tt := range []struct {
+		name           string
+		expiresIn      time.Duration
+		expectedStatus int
+	}{
+		{"Before expiry", time.Minute, http.StatusOK},
+		{"After expiry", -time.Minute, http.StatusUnauthorized},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := &Claims{
+				Email: "test@test.com",
+				RegisteredClaims: jwt.RegisteredClaims{
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(tt.expiresIn)),
+				},
+			}
+			tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tokenString)
+			rr := httptest.NewRecorder()
+			auth.Middleware(protectedHandler).ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
 func TestAdminMiddleware(t *testing.T) {
 	// Set up test database
 	db, err := database.New("sqlite", "file::memory:?cache=shared")
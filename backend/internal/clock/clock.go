@@ -0,0 +1,68 @@
+// Package clock provides the application clock shared by the server, handlers, auth and
+// background services, so that end-to-end tests can move the whole application through time.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the system clock.
+type Real struct{}
+
+// Now returns the current system time.
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Adjustable is a clock that runs alongside the system clock at an offset, which can be set
+// or advanced. It starts at the system time.
+type Adjustable struct {
+	mu     sync.RWMutex
+	offset time.Duration
+}
+
+// NewAdjustable creates an adjustable clock at the system time.
+func NewAdjustable() *Adjustable {
+	return &Adjustable{}
+}
+
+// Now returns the adjusted time.
+func (c *Adjustable) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Now().Add(c.offset)
+}
+
+// Offset returns how far the clock is ahead of the system clock, or behind it when negative.
+func (c *Adjustable) Offset() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offset
+}
+
+// Set moves the clock to the given time, from which it keeps running.
+func (c *Adjustable) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset = time.Until(now)
+}
+
+// Advance moves the clock forward by d, or back when d is negative.
+func (c *Adjustable) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset += d
+}
+
+// Reset moves the clock back to the system time.
+func (c *Adjustable) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset = 0
+}
@@ -0,0 +1,29 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdjustable(t *testing.T) {
+	c := NewAdjustable()
+	if offset := c.Offset(); offset != 0 {
+		t.Errorf("Expected a new clock at the system time, got offset %v", offset)
+	}
+
+	target := time.Date(2025, time.September, 7, 17, 0, 0, 0, time.UTC)
+	c.Set(target)
+	if now := c.Now(); now.Before(target) || now.Sub(target) > time.Second {
+		t.Errorf("Expected the clock at %v, got %v", target, now)
+	}
+
+	c.Advance(36 * time.Hour)
+	if now, want := c.Now(), target.Add(36*time.Hour); now.Before(want) || now.Sub(want) > time.Second {
+		t.Errorf("Expected the clock at %v, got %v", want, now)
+	}
+
+	c.Reset()
+	if offset := c.Offset(); offset != 0 {
+		t.Errorf("Expected the clock back at the system time, got offset %v", offset)
+	}
+}
@@ -60,6 +60,95 @@ func (w DatabaseConfigWrapper) GetConfig() DatabaseConfig {
 	return w.Config
 }
 
+// Spread strategies select a game's line from the lines quoted by the bookmakers.
+const (
+	// SpreadStrategyPreferred uses the first of the preferred bookmakers quoting the game,
+	// falling back to the order the bookmakers are listed in.
+	SpreadStrategyPreferred = "preferred"
+	// SpreadStrategyMedian uses the median of every bookmaker's line.
+	SpreadStrategyMedian = "median"
+	// SpreadStrategyMean uses the mean of every bookmaker's line.
+	SpreadStrategyMean = "mean"
+)
+
+// Odds providers supply the pool lines.
+const (
+	// OddsProviderTheOddsAPI fetches lines from The Odds API.
+	OddsProviderTheOddsAPI = "theoddsapi"
+	// OddsProviderFile reads lines from CSV or JSON files dropped in the odds file directory.
+	OddsProviderFile = "file"
+	// OddsProviderManual leaves the lines to be entered by an admin.
+	OddsProviderManual = "manual"
+)
+
+// Cache backends store the ESPN responses.
+const (
+	// CacheBackendFile writes the responses as JSON files in the cache directory.
+	CacheBackendFile = "file"
+	// CacheBackendMemory keeps the most recently used responses in memory.
+	CacheBackendMemory = "memory"
+	// CacheBackendDatabase stores the responses in the database, shared by every replica.
+	CacheBackendDatabase = "database"
+)
+
+// Fixture modes control whether the upstream clients use recorded responses.
+const (
+	// FixtureModeOff sends requests to the upstream APIs.
+	FixtureModeOff = "off"
+	// FixtureModeRecord sends requests to the upstream APIs and saves their responses as fixtures.
+	FixtureModeRecord = "record"
+	// FixtureModeReplay answers requests from the saved fixtures without using the network.
+	FixtureModeReplay = "replay"
+)
+
+// SpreadLockPickLock freezes the pool line when picks lock at the week's first kickoff.
+const SpreadLockPickLock = "pick_lock"
+
+// Playoff modes control how postseason weeks are handled by the pool.
+const (
+	// PlayoffModeNone skips the postseason entirely.
+	PlayoffModeNone = "none"
+	// PlayoffModeConfidence counts postseason weeks toward the season standings.
+	PlayoffModeConfidence = "confidence"
+	// PlayoffModeSeparate scores postseason weeks as a separate playoff pool.
+	PlayoffModeSeparate = "separate"
+)
+
+// Scoring rule sets decide how picks are graded and how many points they score.
+const (
+	// ScoringRulesConfidence grades picks against the spread and scores the rank of each correct pick.
+	ScoringRulesConfidence = "confidence"
+	// ScoringRulesStraightUp grades picks on the winner, ignoring the spread, and scores
+	// a fixed number of points for each correct pick.
+	ScoringRulesStraightUp = "straight_up"
+	// ScoringRulesFixed grades picks against the spread and scores a fixed number of points
+	// for each correct pick.
+	ScoringRulesFixed = "fixed"
+)
+
+// Push scoring decides what a pick on a push is worth.
+const (
+	// PushScoringHalf scores half the points of a correct pick.
+	PushScoringHalf = "half"
+	// PushScoringLoss scores a push as an incorrect pick.
+	PushScoringLoss = "loss"
+	// PushScoringWin scores a push as a correct pick.
+	PushScoringWin = "win"
+)
+
+// Tiebreakers order the players who tie for a week, applied in the configured order.
+const (
+	// TiebreakerClosestTotal favors the prediction closest to the total points of the week's
+	// tiebreaker game.
+	TiebreakerClosestTotal = "closest_total"
+	// TiebreakerMostCorrect favors the player with the most correct picks.
+	TiebreakerMostCorrect = "most_correct"
+	// TiebreakerHighestCorrectPick favors the player whose highest-ranked correct pick is higher.
+	TiebreakerHighestCorrectPick = "highest_correct_pick"
+	// TiebreakerEarliestSubmission favors the player who submitted their sheet first.
+	TiebreakerEarliestSubmission = "earliest_submission"
+)
+
 // Config holds all configuration for the application.
 type Config struct {
 	// Server configuration
@@ -83,20 +172,108 @@ type Config struct {
 		SyncEnabled  bool          `mapstructure:"sync_enabled"`
 		SyncInterval time.Duration `mapstructure:"sync_interval"`
 		CacheExpiry  time.Duration `mapstructure:"cache_expiry"`
-		SeasonYear   int           `mapstructure:"season_year"`
-		Week1Date    time.Time     `mapstructure:"week1_date"`
+		// SeasonYear is the first season of the pool. Once seasons are recorded, the current
+		// season is the latest one that is not complete and moves on with a rollover.
+		SeasonYear int       `mapstructure:"season_year"`
+		Week1Date  time.Time `mapstructure:"week1_date"`
+
+		// CacheBackend selects where responses are cached, and CacheMaxEntries bounds the memory cache.
+		// CacheExpiry applies to weeks that have not started, while weeks with games in progress are
+		// cached for CacheLiveTTL and completed weeks for CacheCompletedTTL; zero never expires
+		CacheBackend      string        `mapstructure:"cache_backend"`
+		CacheMaxEntries   int           `mapstructure:"cache_max_entries"`
+		CacheLiveTTL      time.Duration `mapstructure:"cache_live_ttl"`
+		CacheCompletedTTL time.Duration `mapstructure:"cache_completed_ttl"`
+
+		// SyncInterval is the polling interval while games of the current week are still to be
+		// played. The sync polls every LiveSyncInterval while games are in progress, and every
+		// IdleSyncInterval once the week's games are over, but always wakes up for the next kickoff
+		LiveSyncInterval time.Duration `mapstructure:"live_sync_interval"`
+		IdleSyncInterval time.Duration `mapstructure:"idle_sync_interval"`
 	} `mapstructure:"espn"`
 
+	// Pool configuration
+	Pool struct {
+		PlayoffMode string `mapstructure:"playoff_mode"`
+		// SpreadLock is when the pool line of a week is frozen: "pick_lock" for the
+		// week's first kickoff, or a weekday and time such as "Tue 12:00" for the last
+		// such time before the first kickoff
+		SpreadLock         string `mapstructure:"spread_lock"`
+		SpreadLockTimezone string `mapstructure:"spread_lock_timezone"`
+
+		// Scoring selects the rule set both leaderboards score picks with. PointsPerPick is
+		// what a correct pick scores under the fixed and straight up rules, and a player who
+		// picks every game of a week correctly scores PerfectWeekBonus on top; zero disables it
+		Scoring struct {
+			Rules            string  `mapstructure:"rules"`
+			Push             string  `mapstructure:"push"`
+			PointsPerPick    float32 `mapstructure:"points_per_pick"`
+			PerfectWeekBonus float32 `mapstructure:"perfect_week_bonus"`
+		} `mapstructure:"scoring"`
+
+		// Tiebreakers break ties in the weekly standings in order. Players enter a predicted
+		// total for the week's last game, which the closest total tiebreaker compares
+		Tiebreakers []string `mapstructure:"tiebreakers"`
+	} `mapstructure:"pool"`
+
+	// Week lifecycle configuration
+	Lifecycle struct {
+		Enabled  bool          `mapstructure:"enabled"`
+		Interval time.Duration `mapstructure:"interval"`
+	} `mapstructure:"lifecycle"`
+
+	// Background job configuration
+	Jobs struct {
+		Enabled      bool          `mapstructure:"enabled"`
+		LockTTL      time.Duration `mapstructure:"lock_ttl"`
+		MaxRetries   int           `mapstructure:"max_retries"`
+		RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+	} `mapstructure:"jobs"`
+
+	// Upstream HTTP client configuration, shared by the ESPN and Odds API clients
+	Upstream struct {
+		Timeout          time.Duration `mapstructure:"timeout"`
+		MaxRetries       int           `mapstructure:"max_retries"`
+		RetryBaseDelay   time.Duration `mapstructure:"retry_base_delay"`
+		RetryMaxDelay    time.Duration `mapstructure:"retry_max_delay"`
+		BreakerThreshold int           `mapstructure:"breaker_threshold"`
+		BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
+
+		// FixtureMode records the upstream responses to FixturesDir, or replays them from it,
+		// with a subdirectory for each upstream
+		FixtureMode string `mapstructure:"fixture_mode"`
+		FixturesDir string `mapstructure:"fixtures_dir"`
+	} `mapstructure:"upstream"`
+
 	// E2E testing configuration
 	E2E struct {
 		Test bool `mapstructure:"test"`
 	} `mapstructure:"e2e"`
 
+	// Odds provider configuration
+	Odds struct {
+		// Provider supplies the pool lines: "theoddsapi", "file" or "manual"
+		Provider string `mapstructure:"provider"`
+		// SeasonProviders overrides the provider for individual seasons, keyed by season year
+		SeasonProviders map[string]string `mapstructure:"season_providers"`
+		// FileDir is the directory the file provider reads <season>/week-<week>.csv or .json from
+		FileDir string `mapstructure:"file_dir"`
+	} `mapstructure:"odds"`
+
 	// TheOddsAPI configuration
 	TheOddsAPI struct {
 		BaseURL string `mapstructure:"base_url"`
 		APIKey  string `mapstructure:"api_key"`
 		Region  string `mapstructure:"region"`
+		// BudgetFloor is the number of remaining requests below which routine fetches are refused
+		BudgetFloor int `mapstructure:"budget_floor"`
+		// QuotaResetDay is the day of the month the request quota resets, which is the day the
+		// subscription started
+		QuotaResetDay int `mapstructure:"quota_reset_day"`
+		// SpreadStrategy selects how a game's line is chosen from the bookmakers' lines
+		SpreadStrategy      string   `mapstructure:"spread_strategy"`
+		PreferredBookmakers []string `mapstructure:"preferred_bookmakers"`
+		RoundToHalf         bool     `mapstructure:"round_to_half"`
 	} `mapstructure:"theoddsapi"`
 }
 
@@ -131,6 +308,7 @@ func LoadConfig() (*Config, error) {
 	if err := viper.Unmarshal(&config, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
 		databaseDecodeHook(),
 		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
 	))); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
@@ -158,14 +336,59 @@ func setDefaults() {
 	viper.SetDefault("espn.cache_expiry", "24h")
 	viper.SetDefault("espn.season_year", 2025)
 	viper.SetDefault("espn.week1_date", time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC))
+	viper.SetDefault("espn.cache_backend", CacheBackendFile)
+	viper.SetDefault("espn.cache_max_entries", 64)
+	viper.SetDefault("espn.cache_live_ttl", "2m")
+	viper.SetDefault("espn.cache_completed_ttl", 0)
+	viper.SetDefault("espn.live_sync_interval", "1m")
+	viper.SetDefault("espn.idle_sync_interval", "6h")
+
+	// Pool defaults
+	viper.SetDefault("pool.playoff_mode", PlayoffModeSeparate)
+	viper.SetDefault("pool.spread_lock", SpreadLockPickLock)
+	viper.SetDefault("pool.spread_lock_timezone", "America/New_York")
+	viper.SetDefault("pool.scoring.rules", ScoringRulesConfidence)
+	viper.SetDefault("pool.scoring.push", PushScoringHalf)
+	viper.SetDefault("pool.scoring.points_per_pick", 1)
+	viper.SetDefault("pool.scoring.perfect_week_bonus", 0)
+	viper.SetDefault("pool.tiebreakers", []string{TiebreakerClosestTotal, TiebreakerMostCorrect, TiebreakerHighestCorrectPick, TiebreakerEarliestSubmission})
+
+	// Week lifecycle defaults
+	viper.SetDefault("lifecycle.enabled", true)
+	viper.SetDefault("lifecycle.interval", "1m")
+
+	// Background job defaults
+	viper.SetDefault("jobs.enabled", true)
+	viper.SetDefault("jobs.lock_ttl", "10m")
+	viper.SetDefault("jobs.max_retries", 3)
+	viper.SetDefault("jobs.retry_backoff", "30s")
+
+	// Upstream HTTP client defaults
+	viper.SetDefault("upstream.timeout", "10s")
+	viper.SetDefault("upstream.max_retries", 3)
+	viper.SetDefault("upstream.retry_base_delay", "500ms")
+	viper.SetDefault("upstream.retry_max_delay", "30s")
+	viper.SetDefault("upstream.breaker_threshold", 5)
+	viper.SetDefault("upstream.breaker_cooldown", "1m")
+	viper.SetDefault("upstream.fixture_mode", FixtureModeOff)
+	viper.SetDefault("upstream.fixtures_dir", "assets/fixtures")
 
 	// E2E testing defaults
 	viper.SetDefault("e2e.test", false)
 
+	// Odds provider defaults
+	viper.SetDefault("odds.provider", OddsProviderTheOddsAPI)
+	viper.SetDefault("odds.file_dir", "assets/odds")
+
 	// TheOddsAPI defaults
-	viper.SetDefault("theoddsapi.base_url", "https://api.the-odds-api.com/v4")
+	viper.SetDefault("theoddsapi.base_url", "https://api.the-odds-api.com")
 	viper.SetDefault("theoddsapi.region", "us")
 	viper.SetDefault("theoddsapi.api_key", "")
+	viper.SetDefault("theoddsapi.budget_floor", 50)
+	viper.SetDefault("theoddsapi.quota_reset_day", 1)
+	viper.SetDefault("theoddsapi.spread_strategy", SpreadStrategyPreferred)
+	viper.SetDefault("theoddsapi.preferred_bookmakers", []string{})
+	viper.SetDefault("theoddsapi.round_to_half", true)
 }
 
 func bindEnvVars() {
@@ -194,14 +417,59 @@ func bindEnvVars() {
 	viper.BindEnv("espn.cache_expiry", "ESPN_CACHE_EXPIRY")
 	viper.BindEnv("espn.season_year", "ESPN_SEASON_YEAR")
 	viper.BindEnv("espn.week1_date", "ESPN_WEEK1_DATE")
+	viper.BindEnv("espn.cache_backend", "ESPN_CACHE_BACKEND")
+	viper.BindEnv("espn.cache_max_entries", "ESPN_CACHE_MAX_ENTRIES")
+	viper.BindEnv("espn.cache_live_ttl", "ESPN_CACHE_LIVE_TTL")
+	viper.BindEnv("espn.cache_completed_ttl", "ESPN_CACHE_COMPLETED_TTL")
+	viper.BindEnv("espn.live_sync_interval", "ESPN_LIVE_SYNC_INTERVAL")
+	viper.BindEnv("espn.idle_sync_interval", "ESPN_IDLE_SYNC_INTERVAL")
+
+	// Pool environment variables
+	viper.BindEnv("pool.playoff_mode", "POOL_PLAYOFF_MODE")
+	viper.BindEnv("pool.spread_lock", "POOL_SPREAD_LOCK")
+	viper.BindEnv("pool.spread_lock_timezone", "POOL_SPREAD_LOCK_TIMEZONE")
+	viper.BindEnv("pool.scoring.rules", "POOL_SCORING_RULES")
+	viper.BindEnv("pool.scoring.push", "POOL_SCORING_PUSH")
+	viper.BindEnv("pool.scoring.points_per_pick", "POOL_SCORING_POINTS_PER_PICK")
+	viper.BindEnv("pool.scoring.perfect_week_bonus", "POOL_SCORING_PERFECT_WEEK_BONUS")
+	viper.BindEnv("pool.tiebreakers", "POOL_TIEBREAKERS")
+
+	// Week lifecycle environment variables
+	viper.BindEnv("lifecycle.enabled", "LIFECYCLE_ENABLED")
+	viper.BindEnv("lifecycle.interval", "LIFECYCLE_INTERVAL")
+
+	// Background job environment variables
+	viper.BindEnv("jobs.enabled", "JOBS_ENABLED")
+	viper.BindEnv("jobs.lock_ttl", "JOBS_LOCK_TTL")
+	viper.BindEnv("jobs.max_retries", "JOBS_MAX_RETRIES")
+	viper.BindEnv("jobs.retry_backoff", "JOBS_RETRY_BACKOFF")
+
+	// Upstream HTTP client environment variables
+	viper.BindEnv("upstream.timeout", "UPSTREAM_TIMEOUT")
+	viper.BindEnv("upstream.max_retries", "UPSTREAM_MAX_RETRIES")
+	viper.BindEnv("upstream.retry_base_delay", "UPSTREAM_RETRY_BASE_DELAY")
+	viper.BindEnv("upstream.retry_max_delay", "UPSTREAM_RETRY_MAX_DELAY")
+	viper.BindEnv("upstream.breaker_threshold", "UPSTREAM_BREAKER_THRESHOLD")
+	viper.BindEnv("upstream.breaker_cooldown", "UPSTREAM_BREAKER_COOLDOWN")
+	viper.BindEnv("upstream.fixture_mode", "UPSTREAM_FIXTURE_MODE")
+	viper.BindEnv("upstream.fixtures_dir", "UPSTREAM_FIXTURES_DIR")
 
 	// E2E testing environment variables
 	viper.BindEnv("e2e.test", "E2E_TEST")
 
+	// Odds provider environment variables
+	viper.BindEnv("odds.provider", "ODDS_PROVIDER")
+	viper.BindEnv("odds.file_dir", "ODDS_FILE_DIR")
+
 	// TheOddsAPI environment variables
 	viper.BindEnv("theoddsapi.base_url", "THEODDSAPI_BASE_URL")
 	viper.BindEnv("theoddsapi.api_key", "THEODDSAPI_API_KEY")
 	viper.BindEnv("theoddsapi.region", "THEODDSAPI_REGION")
+	viper.BindEnv("theoddsapi.budget_floor", "THEODDSAPI_BUDGET_FLOOR")
+	viper.BindEnv("theoddsapi.quota_reset_day", "THEODDSAPI_QUOTA_RESET_DAY")
+	viper.BindEnv("theoddsapi.spread_strategy", "THEODDSAPI_SPREAD_STRATEGY")
+	viper.BindEnv("theoddsapi.preferred_bookmakers", "THEODDSAPI_PREFERRED_BOOKMAKERS")
+	viper.BindEnv("theoddsapi.round_to_half", "THEODDSAPI_ROUND_TO_HALF")
 }
 
 func databaseDecodeHook() mapstructure.DecodeHookFunc {
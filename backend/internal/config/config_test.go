@@ -29,6 +29,8 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, 1*time.Hour, cfg.ESPN.SyncInterval)
 	assert.Equal(t, 24*time.Hour, cfg.ESPN.CacheExpiry)
 	assert.False(t, cfg.E2E.Test)
+	assert.Equal(t, 50, cfg.TheOddsAPI.BudgetFloor)
+	assert.Equal(t, 1, cfg.TheOddsAPI.QuotaResetDay)
 }
 
 func TestLoadConfigWithEnvironmentVariables(t *testing.T) {
@@ -38,6 +40,7 @@ func TestLoadConfigWithEnvironmentVariables(t *testing.T) {
 	t.Setenv("FOOTBALL_POOL_DB_FILE", "test_override.db")
 	t.Setenv("FOOTBALL_POOL_LOG_LEVEL", "debug")
 	t.Setenv("ESPN_SYNC_ENABLED", "true")
+	t.Setenv("THEODDSAPI_PREFERRED_BOOKMAKERS", "draftkings,fanduel")
 
 	cfg, err := LoadConfig()
 	require.NoError(t, err)
@@ -50,6 +53,7 @@ func TestLoadConfigWithEnvironmentVariables(t *testing.T) {
 	assert.Equal(t, "test_override.db", dbConfig.GetDSN())
 	assert.Equal(t, "debug", cfg.Logging.Level)
 	assert.True(t, cfg.ESPN.SyncEnabled)
+	assert.Equal(t, []string{"draftkings", "fanduel"}, cfg.TheOddsAPI.PreferredBookmakers)
 }
 
 func TestLoadConfigWithMissingConfigFile(t *testing.T) {
@@ -80,6 +84,42 @@ func TestConfigDefaults(t *testing.T) {
 	assert.False(t, cfg.ESPN.SyncEnabled)
 	assert.Equal(t, 1*time.Hour, cfg.ESPN.SyncInterval)
 	assert.Equal(t, 24*time.Hour, cfg.ESPN.CacheExpiry)
+	assert.Equal(t, CacheBackendFile, cfg.ESPN.CacheBackend)
+	assert.Equal(t, 64, cfg.ESPN.CacheMaxEntries)
+	assert.Equal(t, 2*time.Minute, cfg.ESPN.CacheLiveTTL)
+	assert.Equal(t, time.Duration(0), cfg.ESPN.CacheCompletedTTL)
+	assert.Equal(t, time.Minute, cfg.ESPN.LiveSyncInterval)
+	assert.Equal(t, 6*time.Hour, cfg.ESPN.IdleSyncInterval)
+	assert.Equal(t, PlayoffModeSeparate, cfg.Pool.PlayoffMode)
+	assert.Equal(t, SpreadLockPickLock, cfg.Pool.SpreadLock)
+	assert.Equal(t, "America/New_York", cfg.Pool.SpreadLockTimezone)
+	assert.Equal(t, ScoringRulesConfidence, cfg.Pool.Scoring.Rules)
+	assert.Equal(t, PushScoringHalf, cfg.Pool.Scoring.Push)
+	assert.Equal(t, float32(1), cfg.Pool.Scoring.PointsPerPick)
+	assert.Equal(t, float32(0), cfg.Pool.Scoring.PerfectWeekBonus)
+	assert.Equal(t, []string{TiebreakerClosestTotal, TiebreakerMostCorrect, TiebreakerHighestCorrectPick, TiebreakerEarliestSubmission}, cfg.Pool.Tiebreakers)
+	assert.True(t, cfg.Lifecycle.Enabled)
+	assert.Equal(t, 1*time.Minute, cfg.Lifecycle.Interval)
+	assert.True(t, cfg.Jobs.Enabled)
+	assert.Equal(t, 10*time.Minute, cfg.Jobs.LockTTL)
+	assert.Equal(t, 3, cfg.Jobs.MaxRetries)
+	assert.Equal(t, 30*time.Second, cfg.Jobs.RetryBackoff)
+	assert.Equal(t, 10*time.Second, cfg.Upstream.Timeout)
+	assert.Equal(t, 3, cfg.Upstream.MaxRetries)
+	assert.Equal(t, 500*time.Millisecond, cfg.Upstream.RetryBaseDelay)
+	assert.Equal(t, 30*time.Second, cfg.Upstream.RetryMaxDelay)
+	assert.Equal(t, 5, cfg.Upstream.BreakerThreshold)
+	assert.Equal(t, time.Minute, cfg.Upstream.BreakerCooldown)
+	assert.Equal(t, FixtureModeOff, cfg.Upstream.FixtureMode)
+	assert.Equal(t, "assets/fixtures", cfg.Upstream.FixturesDir)
+	assert.Equal(t, OddsProviderTheOddsAPI, cfg.Odds.Provider)
+	assert.Empty(t, cfg.Odds.SeasonProviders)
+	assert.Equal(t, "assets/odds", cfg.Odds.FileDir)
+	assert.Equal(t, 50, cfg.TheOddsAPI.BudgetFloor)
+	assert.Equal(t, 1, cfg.TheOddsAPI.QuotaResetDay)
+	assert.Equal(t, SpreadStrategyPreferred, cfg.TheOddsAPI.SpreadStrategy)
+	assert.Empty(t, cfg.TheOddsAPI.PreferredBookmakers)
+	assert.True(t, cfg.TheOddsAPI.RoundToHalf)
 	assert.False(t, cfg.E2E.Test)
 }
 
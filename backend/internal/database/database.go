@@ -2,8 +2,10 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
@@ -50,11 +52,29 @@ func New(dbType, dsn string) (*Database, error) {
 // Migrate performs database schema migrations for all models.
 func (d *Database) Migrate() error {
 	slog.Debug("Attempting to migrate database schema...")
-	err := d.db.AutoMigrate(&User{}, &Player{}, &Game{}, &Pick{}, &Result{}, &SurvivorPick{}, &Week{})
+	err := d.db.AutoMigrate(&User{}, &Player{}, &Team{}, &TeamAlias{}, &Game{}, &SpreadSnapshot{}, &UnmatchedSpread{}, &ScheduleChange{}, &Pick{}, &Result{}, &SurvivorPick{}, &Tiebreaker{}, &Week{}, &WeekSyncStatus{}, &ESPNCacheEntry{}, &JobRun{}, &JobLock{}, &OddsAPIUsage{}, &Season{}, &ArchivedStanding{}, &ArchivedSurvivorResult{}, &WeeklyScore{}, &SeasonStanding{})
 	if err != nil {
 		slog.Debug("Failed to migrate database:", "error", err)
 		return err
 	}
+
+	// Survivor picks used to be unique per user and week, which would reject the picks of a
+	// second season
+	if d.db.Migrator().HasIndex(&SurvivorPick{}, "idx_user_week") {
+		if err := d.db.Migrator().DropIndex(&SurvivorPick{}, "idx_user_week"); err != nil {
+			slog.Debug("Failed to drop survivor pick index:", "error", err)
+			return err
+		}
+	}
+
+	// The ESPN event ID used to have a plain index, which is replaced by the unique index
+	if d.db.Migrator().HasIndex(&Game{}, "idx_games_espn_event_id") {
+		if err := d.db.Migrator().DropIndex(&Game{}, "idx_games_espn_event_id"); err != nil {
+			slog.Debug("Failed to drop ESPN event ID index:", "error", err)
+			return err
+		}
+	}
+
 	slog.Debug("Database schema migrated successfully.")
 	return nil
 }
@@ -73,3 +93,56 @@ func (d *Database) WeekHasGames(season int, week int) (bool, error) {
 	}
 	return count > 0, nil
 }
+
+// GetGameByESPNEventID returns the game synced from an ESPN event, in whichever week it is scheduled.
+// A game deleted by an admin is returned as well, so that the sync can leave it deleted.
+func (d *Database) GetGameByESPNEventID(eventID string) (*Game, error) {
+	var game Game
+	if err := d.db.Unscoped().Where("espn_event_id = ?", eventID).First(&game).Error; err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+// GetWeek returns the week record for a season and week number.
+func (d *Database) GetWeek(season, weekNumber int) (*Week, error) {
+	var week Week
+	if err := d.db.Where("season = ? AND week_number = ?", season, weekNumber).First(&week).Error; err != nil {
+		return nil, err
+	}
+	return &week, nil
+}
+
+// GetWeekAt returns the week of a season whose boundaries contain the given time.
+// If the time falls before a week starts, the next upcoming week is returned.
+func (d *Database) GetWeekAt(season int, t time.Time) (*Week, error) {
+	var week Week
+	err := d.db.Where("season = ? AND week_end_time >= ?", season, t).
+		Order("week_start_time ASC").
+		First(&week).Error
+	if err != nil {
+		return nil, err
+	}
+	return &week, nil
+}
+
+// UpsertWeek creates or updates the week identified by its season and week number.
+// Existing weeks keep their active flag and status; only the season type and boundaries are updated.
+func (d *Database) UpsertWeek(week *Week) error {
+	existing, err := d.GetWeek(week.Season, week.WeekNumber)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return d.db.Create(week).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	week.ID = existing.ID
+	week.IsActive = existing.IsActive
+	week.Status = existing.Status
+	return d.db.Model(existing).Updates(map[string]interface{}{
+		"season_type":     week.SeasonType,
+		"week_start_time": week.WeekStartTime,
+		"week_end_time":   week.WeekEndTime,
+	}).Error
+}
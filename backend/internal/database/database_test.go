@@ -2,6 +2,7 @@ package database
 
 import (
 	"testing"
+	"time"
 )
 
 func TestConnect(t *testing.T) {
@@ -67,3 +68,99 @@ func TestWeekHasGames(t *testing.T) {
 		t.Fatal("expected week to have games, but it didn't")
 	}
 }
+
+func TestGetWeekAt(t *testing.T) {
+	db, err := New("sqlite", "file::memory:")
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+
+	season := 2025
+	week1 := &Week{
+		WeekNumber:    1,
+		Season:        season,
+		WeekStartTime: time.Date(2025, 9, 4, 7, 0, 0, 0, time.UTC),
+		WeekEndTime:   time.Date(2025, 9, 10, 6, 59, 0, 0, time.UTC),
+	}
+	week2 := &Week{
+		WeekNumber:    2,
+		Season:        season,
+		WeekStartTime: time.Date(2025, 9, 10, 7, 0, 0, 0, time.UTC),
+		WeekEndTime:   time.Date(2025, 9, 17, 6, 59, 0, 0, time.UTC),
+	}
+	for _, week := range []*Week{week2, week1} {
+		if err := db.GetDB().Create(week).Error; err != nil {
+			t.Fatalf("failed to create week: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		at           time.Time
+		expectedWeek int
+	}{
+		{name: "before the season", at: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), expectedWeek: 1},
+		{name: "during week 1", at: time.Date(2025, 9, 8, 0, 0, 0, 0, time.UTC), expectedWeek: 1},
+		{name: "during week 2", at: time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC), expectedWeek: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			week, err := db.GetWeekAt(season, tt.at)
+			if err != nil {
+				t.Fatalf("failed to get week: %v", err)
+			}
+			if week.WeekNumber != tt.expectedWeek {
+				t.Errorf("expected week %d, got %d", tt.expectedWeek, week.WeekNumber)
+			}
+		})
+	}
+
+	if _, err := db.GetWeekAt(season, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("expected an error after the last week, got nil")
+	}
+}
+
+func TestUpsertWeek(t *testing.T) {
+	db, err := New("sqlite", "file::memory:")
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+
+	week := &Week{
+		WeekNumber:    1,
+		Season:        2025,
+		WeekStartTime: time.Date(2025, 9, 4, 7, 0, 0, 0, time.UTC),
+		WeekEndTime:   time.Date(2025, 9, 10, 6, 59, 0, 0, time.UTC),
+		IsActive:      true,
+	}
+	if err := db.UpsertWeek(week); err != nil {
+		t.Fatalf("failed to create week: %v", err)
+	}
+
+	// Updating the boundaries must not reset the active flag
+	updated := &Week{
+		WeekNumber:    1,
+		Season:        2025,
+		SeasonType:    SeasonTypeRegular,
+		WeekStartTime: time.Date(2025, 9, 5, 7, 0, 0, 0, time.UTC),
+		WeekEndTime:   time.Date(2025, 9, 11, 6, 59, 0, 0, time.UTC),
+	}
+	if err := db.UpsertWeek(updated); err != nil {
+		t.Fatalf("failed to update week: %v", err)
+	}
+
+	var weeks []Week
+	if err := db.GetDB().Find(&weeks).Error; err != nil {
+		t.Fatalf("failed to query weeks: %v", err)
+	}
+	if len(weeks) != 1 {
+		t.Fatalf("expected 1 week, got %d", len(weeks))
+	}
+	if !weeks[0].IsActive {
+		t.Error("expected week to remain active")
+	}
+	if !weeks[0].WeekStartTime.Equal(updated.WeekStartTime) {
+		t.Errorf("expected start time %v, got %v", updated.WeekStartTime, weeks[0].WeekStartTime)
+	}
+}
@@ -7,6 +7,66 @@ import (
 	"gorm.io/gorm"
 )
 
+// Season types as reported by ESPN.
+const (
+	SeasonTypePreseason  = 1
+	SeasonTypeRegular    = 2
+	SeasonTypePostseason = 3
+)
+
+// Pool week numbers. Postseason rounds continue the regular season numbering
+// so that a week number is unique within a season.
+const (
+	LastRegularSeasonWeek = 18
+	WildCardWeek          = 19
+	DivisionalWeek        = 20
+	ConferenceWeek        = 21
+	SuperBowlWeek         = 22
+)
+
+// SeasonTypeForWeek returns the season type that a pool week number belongs to.
+func SeasonTypeForWeek(week int) int {
+	switch {
+	case week < 1:
+		return SeasonTypePreseason
+	case week > LastRegularSeasonWeek:
+		return SeasonTypePostseason
+	default:
+		return SeasonTypeRegular
+	}
+}
+
+// Week statuses in lifecycle order. Picks can be made while a week is open,
+// are locked from the first kickoff and the week is final once every game has a result.
+const (
+	WeekStatusUpcoming = "upcoming"
+	WeekStatusOpen     = "open"
+	WeekStatusLocked   = "locked"
+	WeekStatusScoring  = "scoring"
+	WeekStatusFinal    = "final"
+)
+
+// Season states. A season is upcoming until its first week opens, active while it is
+// played and complete once its standings are archived.
+const (
+	SeasonStateUpcoming = "upcoming"
+	SeasonStateActive   = "active"
+	SeasonStateComplete = "complete"
+)
+
+// Game states as reported by ESPN. Games entered by hand have no state.
+const (
+	GameStateScheduled  = "pre"
+	GameStateInProgress = "in"
+	GameStateFinal      = "post"
+)
+
+// Pools that standings are archived for.
+const (
+	PoolSeason   = "season"
+	PoolPlayoffs = "playoffs"
+)
+
 // User represents a user of the application
 // swagger:model
 type User struct {
@@ -31,14 +91,122 @@ type Player struct {
 // swagger:model
 type Game struct {
 	gorm.Model
-	Week      int       `gorm:"index:idx_game_week_season" validate:"required,ne=0"`
-	Season    int       `gorm:"index:idx_game_week_season" validate:"required,ne=0"`
-	HomeTeam  string    `gorm:"column:favorite_team" validate:"required"`
-	AwayTeam  string    `gorm:"column:underdog_team" validate:"required"`
-	Favorite  *string   `validate:"omitempty,oneof=Home Away"`
-	Underdog  *string   `validate:"omitempty,oneof=Home Away"`
-	Spread    float32   `validate:"gte=0"`
-	StartTime time.Time `validate:"required"`
+	Week       int       `gorm:"index:idx_game_week_season" validate:"required,ne=0"`
+	Season     int       `gorm:"index:idx_game_week_season" validate:"required,ne=0"`
+	SeasonType int       `gorm:"default:2" validate:"omitempty,oneof=1 2 3"`
+	HomeTeam   string    `gorm:"column:favorite_team" validate:"required"`
+	AwayTeam   string    `gorm:"column:underdog_team" validate:"required"`
+	Favorite   *string   `validate:"omitempty,oneof=Home Away"`
+	Underdog   *string   `validate:"omitempty,oneof=Home Away"`
+	Spread     float32   `validate:"gte=0"`
+	StartTime  time.Time `validate:"required"`
+	// HomeTeamID and AwayTeamID reference the registered teams, and are nil for
+	// games whose team names could not be resolved
+	HomeTeamID *uint `gorm:"index"`
+	AwayTeamID *uint `gorm:"index"`
+	// ESPNEventID is the ESPN event the game was synced from, empty for games entered by hand.
+	// Synced games are keyed on it, so a game that is flexed or moved to another week keeps its row.
+	// Event IDs are unique among synced games
+	ESPNEventID string `gorm:"column:espn_event_id;uniqueIndex:idx_games_espn_event,where:espn_event_id <> ''"`
+	Venue       string
+	// SpreadLockedAt is set once the pool line is frozen and no longer follows the market
+	SpreadLockedAt *time.Time
+	// SpreadStrategy and SpreadBookmakers record how the pool line was chosen from the
+	// bookmakers' lines; SpreadBookmakers is a comma-separated list of bookmaker keys
+	SpreadStrategy   string
+	SpreadBookmakers string
+	// State is the game state ESPN last reported, with the live score, quarter and game
+	// clock. A game in progress has no result until it is over
+	State     string
+	HomeScore int
+	AwayScore int
+	Period    int
+	Clock     string
+}
+
+// ScheduleChange records a change to the kickoff time, week or venue of a synced game.
+// Changes to games that already have picks are alerts, which stay open until an admin
+// acknowledges them.
+// swagger:model
+type ScheduleChange struct {
+	gorm.Model
+	GameID            uint `gorm:"index" validate:"required"`
+	Game              Game `validate:"-"`
+	Season            int
+	PreviousWeek      int
+	Week              int
+	PreviousStartTime time.Time
+	StartTime         time.Time
+	PreviousVenue     string
+	Venue             string
+	// PicksAttached is the number of picks the game had when the change was detected
+	PicksAttached  int
+	DetectedAt     time.Time
+	AcknowledgedAt *time.Time
+}
+
+// IsAlert reports whether the change affects picks and has not been acknowledged.
+func (c ScheduleChange) IsAlert() bool {
+	return c.PicksAttached > 0 && c.AcknowledgedAt == nil
+}
+
+// UnmatchedSpread is an odds event of a week that matched none of its games. The
+// unmatched events of a week are replaced each time its spreads are updated.
+// swagger:model
+type UnmatchedSpread struct {
+	gorm.Model
+	Season   int    `gorm:"index:idx_unmatched_spread_season_week"`
+	Week     int    `gorm:"index:idx_unmatched_spread_season_week"`
+	Provider string `validate:"required"`
+	// EventID is the provider's ID for the event, empty for providers without one
+	EventID  string
+	HomeTeam string
+	AwayTeam string
+	// CommenceTime is the kickoff reported by the provider, nil if it does not report one
+	CommenceTime *time.Time
+	Reason       string
+	FetchedAt    time.Time
+}
+
+// Team is an NFL franchise. Teams are registered from ESPN, and the names other
+// data sources use for a team are recorded as its aliases.
+// swagger:model
+type Team struct {
+	gorm.Model
+	ESPNID         string `gorm:"column:espn_id;uniqueIndex" validate:"required"`
+	Abbreviation   string
+	DisplayName    string `validate:"required"`
+	Location       string
+	Name           string
+	Color          string
+	AlternateColor string
+	LogoURL        string
+	Aliases        []TeamAlias `validate:"-"`
+}
+
+// TeamAlias is a name a data source uses for a team. Names are stored normalized,
+// and each name refers to a single team per source.
+// swagger:model
+type TeamAlias struct {
+	gorm.Model
+	TeamID   uint   `gorm:"index" validate:"required"`
+	Provider string `gorm:"uniqueIndex:idx_team_alias_provider_name" validate:"required"`
+	Name     string `gorm:"uniqueIndex:idx_team_alias_provider_name" validate:"required"`
+}
+
+// SpreadSnapshot records a line fetched for a game. The line used by the pool is
+// the Game's spread, these snapshots record how the market moved around it.
+// swagger:model
+type SpreadSnapshot struct {
+	gorm.Model
+	GameID   uint `gorm:"index:idx_spread_snapshot_game_fetched"`
+	Game     Game `validate:"-"`
+	Strategy string
+	// Bookmakers is a comma-separated list of the bookmakers the line was derived from
+	Bookmakers string
+	Spread     float32   `validate:"gte=0"`
+	Favorite   string    `validate:"oneof=Home Away"`
+	FetchedAt  time.Time `gorm:"index:idx_spread_snapshot_game_fetched"`
 }
 
 // Pick represents a user's pick for a game
@@ -52,6 +220,9 @@ type Pick struct {
 	Picked    string `validate:"required"`
 	Rank      int    `validate:"required"`
 	QuickPick bool
+	// SubmittedAt is when the player submitted the pick by the application clock, which the
+	// earliest submission tiebreaker compares
+	SubmittedAt time.Time
 }
 
 // Result represents the result of a game
@@ -69,19 +240,190 @@ type Result struct {
 // swagger:model
 type SurvivorPick struct {
 	gorm.Model
-	UserID uint `gorm:"index:idx_user_week,unique"`
+	UserID uint `gorm:"index:idx_survivor_pick_user_season_week,unique"`
 	User   User
-	Week   int `gorm:"index:idx_user_week,unique"`
+	Season int `gorm:"index:idx_survivor_pick_user_season_week,unique"`
+	Week   int `gorm:"index:idx_survivor_pick_user_season_week,unique"`
 	Team   string
 }
 
+// Tiebreaker is a player's predicted total points of a week's tiebreaker game, entered
+// with their pick sheet.
+// swagger:model
+type Tiebreaker struct {
+	gorm.Model
+	UserID         uint `gorm:"index:idx_tiebreaker_user_season_week,unique"`
+	User           User `validate:"-"`
+	Season         int  `gorm:"index:idx_tiebreaker_user_season_week,unique" validate:"required,ne=0"`
+	Week           int  `gorm:"index:idx_tiebreaker_user_season_week,unique" validate:"required,ne=0"`
+	PredictedTotal int  `validate:"gte=0"`
+	// SubmittedAt is when the player last submitted their prediction
+	SubmittedAt time.Time
+}
+
 // Week represents a week in the football season
 // swagger:model
 type Week struct {
 	gorm.Model
 	WeekNumber    int       `gorm:"index:idx_week_number_season,unique" validate:"required,ne=0"`
 	Season        int       `gorm:"index:idx_week_number_season,unique" validate:"required,ne=0"`
+	SeasonType    int       `gorm:"default:2" validate:"omitempty,oneof=1 2 3"`
 	WeekStartTime time.Time `validate:"required"`
 	WeekEndTime   time.Time `validate:"required"`
 	IsActive      bool      `gorm:"default:false"`
+	Status        string    `gorm:"default:upcoming" validate:"omitempty,oneof=upcoming open locked scoring final"`
+}
+
+// Season represents a season of the pool
+// swagger:model
+type Season struct {
+	gorm.Model
+	Year       int    `gorm:"uniqueIndex" validate:"required,ne=0"`
+	State      string `gorm:"default:upcoming" validate:"omitempty,oneof=upcoming active complete"`
+	ArchivedAt *time.Time
+}
+
+// ArchivedStanding is a player's final standing in a pool of a complete season
+// swagger:model
+type ArchivedStanding struct {
+	gorm.Model
+	Season     int    `gorm:"uniqueIndex:idx_archived_standing_season_pool_user"`
+	Pool       string `gorm:"uniqueIndex:idx_archived_standing_season_pool_user" validate:"oneof=season playoffs"`
+	UserID     uint   `gorm:"uniqueIndex:idx_archived_standing_season_pool_user"`
+	PlayerName string
+	Score      float32
+	Rank       int
+}
+
+// ArchivedSurvivorResult is the outcome of a survivor pick of a complete season
+// swagger:model
+type ArchivedSurvivorResult struct {
+	gorm.Model
+	Season     int  `gorm:"uniqueIndex:idx_archived_survivor_season_user_week"`
+	UserID     uint `gorm:"uniqueIndex:idx_archived_survivor_season_user_week"`
+	Week       int  `gorm:"uniqueIndex:idx_archived_survivor_season_user_week"`
+	PlayerName string
+	Team       string
+	Outcome    string
+}
+
+// WeeklyScore is a player's materialized score for a week, with their standing in the
+// week's pool after it. It is updated whenever a result of the week changes.
+// swagger:model
+type WeeklyScore struct {
+	gorm.Model
+	Season     int    `gorm:"uniqueIndex:idx_weekly_score_season_week_user"`
+	Week       int    `gorm:"uniqueIndex:idx_weekly_score_season_week_user"`
+	UserID     uint   `gorm:"uniqueIndex:idx_weekly_score_season_week_user"`
+	PlayerName string `gorm:"->;-:migration"`
+	SeasonType int
+	// Points includes the week bonus
+	Points    float32
+	Bonus     float32
+	Correct   int
+	Incorrect int
+	Pushes    int
+	Pending   int
+	// HighestCorrectRank is the rank of the player's highest-ranked correct pick
+	HighestCorrectRank int
+	// MaxPoints is the most the player can score in the week if every pending pick is correct,
+	// counting the games they can still pick while the week is open
+	MaxPoints float32
+	QuickPick bool
+	Rank      int
+	// SeasonPoints and SeasonRank are the player's standing in the week's pool after the
+	// week, so that past positions are preserved
+	SeasonPoints float32
+	SeasonRank   int
+	// PreviousSeasonRank is the player's standing after the prior week, or 0 in the first week
+	PreviousSeasonRank int
+}
+
+// SeasonStanding is a player's materialized standing in a pool of a season.
+// swagger:model
+type SeasonStanding struct {
+	gorm.Model
+	Season     int    `gorm:"uniqueIndex:idx_season_standing_season_pool_user"`
+	Pool       string `gorm:"uniqueIndex:idx_season_standing_season_pool_user" validate:"oneof=season playoffs"`
+	UserID     uint   `gorm:"uniqueIndex:idx_season_standing_season_pool_user"`
+	PlayerName string `gorm:"->;-:migration"`
+	Points     float32
+	Correct    int
+	Incorrect  int
+	Pushes     int
+	MaxPoints  float32
+	Rank       int
+	// PreviousRank is the player's rank before the latest week, or 0 after the first week
+	PreviousRank int
+}
+
+// WeekSyncStatus records the outcome of the latest ESPN sync of a week
+// swagger:model
+type WeekSyncStatus struct {
+	gorm.Model
+	Season        int `gorm:"index:idx_week_sync_season_week,unique"`
+	Week          int `gorm:"index:idx_week_sync_season_week,unique"`
+	LastAttemptAt time.Time
+	LastSuccessAt *time.Time
+	GamesCreated  int
+	GamesUpdated  int
+	LastError     string
+}
+
+// ESPNCacheEntry is a cached ESPN response for a week, stored in the database so that
+// replicas share it. Entries are deleted permanently, so a week can be cached again.
+type ESPNCacheEntry struct {
+	gorm.Model
+	Season    int `gorm:"index:idx_espn_cache_season_week,unique"`
+	Week      int `gorm:"index:idx_espn_cache_season_week,unique"`
+	Events    int
+	Data      []byte
+	CachedAt  time.Time
+	ExpiresAt *time.Time `gorm:"index"`
+}
+
+// Job run statuses.
+const (
+	JobRunStatusRunning   = "running"
+	JobRunStatusSucceeded = "succeeded"
+	JobRunStatusFailed    = "failed"
+)
+
+// Job run triggers.
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerStartup  = "startup"
+	JobTriggerManual   = "manual"
+)
+
+// JobRun records a single run of a background job
+// swagger:model
+type JobRun struct {
+	gorm.Model
+	JobName     string `gorm:"index:idx_job_run_name_started"`
+	TriggeredBy string `validate:"oneof=schedule startup manual"`
+	Status      string `validate:"oneof=running succeeded failed"`
+	Attempts    int    `gorm:"default:0"`
+	Error       string
+	StartedAt   time.Time `gorm:"index:idx_job_run_name_started"`
+	FinishedAt  *time.Time
+}
+
+// JobLock is held by the instance currently running a job so that
+// multiple replicas never run the same job at once.
+type JobLock struct {
+	Name      string `gorm:"primaryKey"`
+	Owner     string
+	ExpiresAt time.Time
+}
+
+// OddsAPIUsage tracks The Odds API request quota for a billing period, as
+// reported by the headers of the most recent response.
+// swagger:model
+type OddsAPIUsage struct {
+	gorm.Model
+	Period            string `gorm:"uniqueIndex"`
+	RequestsUsed      int
+	RequestsRemaining int
+	LastRequestAt     time.Time
 }
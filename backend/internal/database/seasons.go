@@ -0,0 +1,53 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// CurrentSeason returns the year of the latest season that is not complete, which is the
+// season being played or prepared. When no season has been recorded, fallback is returned.
+func CurrentSeason(db *gorm.DB, fallback int) (int, error) {
+	var season Season
+	err := db.Where("state <> ?", SeasonStateComplete).Order("year DESC").First(&season).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fallback, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return season.Year, nil
+}
+
+// GetSeason returns the season record of a year.
+func (d *Database) GetSeason(year int) (*Season, error) {
+	var season Season
+	if err := d.db.Where("year = ?", year).First(&season).Error; err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+// EnsureSeason returns the season record of a year, creating it as upcoming if it does not exist.
+func (d *Database) EnsureSeason(year int) (*Season, error) {
+	season := Season{Year: year, State: SeasonStateUpcoming}
+	if err := d.db.Where(Season{Year: year}).FirstOrCreate(&season).Error; err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+// SeedSeason records the given year as the first season when no season has been recorded yet.
+// Once seasons exist, the current season only changes through a rollover.
+func (d *Database) SeedSeason(year int) error {
+	var count int64
+	if err := d.db.Model(&Season{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	_, err := d.EnsureSeason(year)
+	return err
+}
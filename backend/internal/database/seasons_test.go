@@ -0,0 +1,64 @@
+package database
+
+import "testing"
+
+func TestCurrentSeason(t *testing.T) {
+	db, err := New("sqlite", "file::memory:")
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+
+	// The fallback is used until seasons are recorded
+	season, err := CurrentSeason(db.GetDB(), 2024)
+	if err != nil {
+		t.Fatalf("CurrentSeason() error = %v", err)
+	}
+	if season != 2024 {
+		t.Errorf("expected fallback season 2024, got %d", season)
+	}
+
+	if err := db.SeedSeason(2024); err != nil {
+		t.Fatalf("SeedSeason() error = %v", err)
+	}
+	// Seeding again once seasons exist does nothing
+	if err := db.SeedSeason(2030); err != nil {
+		t.Fatalf("SeedSeason() error = %v", err)
+	}
+	if _, err := db.GetSeason(2030); err == nil {
+		t.Error("expected seeding to be skipped once seasons exist")
+	}
+
+	// The latest season that is not complete is current
+	if err := db.GetDB().Create(&Season{Year: 2023, State: SeasonStateComplete}).Error; err != nil {
+		t.Fatalf("failed to create season: %v", err)
+	}
+	if _, err := db.EnsureSeason(2025); err != nil {
+		t.Fatalf("EnsureSeason() error = %v", err)
+	}
+	season, err = CurrentSeason(db.GetDB(), 2020)
+	if err != nil {
+		t.Fatalf("CurrentSeason() error = %v", err)
+	}
+	if season != 2025 {
+		t.Errorf("expected current season 2025, got %d", season)
+	}
+
+	// Ensuring an existing season keeps its state
+	if err := db.GetDB().Model(&Season{}).Where("year = ?", 2025).Update("state", SeasonStateComplete).Error; err != nil {
+		t.Fatalf("failed to complete season: %v", err)
+	}
+	ensured, err := db.EnsureSeason(2025)
+	if err != nil {
+		t.Fatalf("EnsureSeason() error = %v", err)
+	}
+	if ensured.State != SeasonStateComplete {
+		t.Errorf("expected season to stay complete, got %s", ensured.State)
+	}
+	season, err = CurrentSeason(db.GetDB(), 2020)
+	if err != nil {
+		t.Fatalf("CurrentSeason() error = %v", err)
+	}
+	if season != 2024 {
+		t.Errorf("expected current season 2024, got %d", season)
+	}
+}
@@ -0,0 +1,200 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// TeamSourceESPN is the data source the teams are registered from.
+const TeamSourceESPN = "espn"
+
+// Team resolution errors.
+var (
+	ErrTeamNotFound   = errors.New("team not found")
+	ErrAmbiguousTeam  = errors.New("team name matches more than one team")
+	ErrEmptyTeamAlias = errors.New("team alias name is empty")
+)
+
+// NormalizeTeamName lowercases a team name, drops punctuation and collapses whitespace,
+// so that "St. Louis  Rams" and "st louis rams" compare equal.
+func NormalizeTeamName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// TeamNameMatches reports whether name refers to team by its full name, its nickname
+// such as "Packers", or its location such as "Green Bay".
+func TeamNameMatches(name, team string) bool {
+	name, team = NormalizeTeamName(name), NormalizeTeamName(team)
+	if name == "" || team == "" {
+		return false
+	}
+	if name == team {
+		return true
+	}
+
+	split := strings.LastIndex(team, " ")
+	if split < 0 {
+		return false
+	}
+	return name == team[split+1:] || name == team[:split]
+}
+
+// TeamMatches reports whether team is a game's team with the given ID and name. A
+// registered team matches by ID, or by its display name when the game does not reference
+// its teams, while a team whose name could not be resolved matches by TeamNameMatches.
+func TeamMatches(team *Team, id *uint, name string) bool {
+	switch {
+	case team.ID != 0 && id != nil:
+		return team.ID == *id
+	case team.ID != 0:
+		return NormalizeTeamName(team.DisplayName) == NormalizeTeamName(name)
+	default:
+		return TeamNameMatches(team.DisplayName, name)
+	}
+}
+
+// TeamResolver resolves the names data sources use for teams. The teams and their
+// aliases are loaded once, so a resolver should be created for each batch of names.
+type TeamResolver struct {
+	db    *gorm.DB
+	teams []Team
+	err   error
+	ready bool
+}
+
+// NewTeamResolver creates a TeamResolver reading the teams from db.
+func NewTeamResolver(db *gorm.DB) *TeamResolver {
+	return &TeamResolver{db: db}
+}
+
+// Resolve returns the team a data source refers to by name. Aliases recorded for the
+// source take precedence over those of other sources, which take precedence over the
+// teams' abbreviations, names and locations. It returns ErrTeamNotFound if no team
+// matches, and ErrAmbiguousTeam if the name refers to more than one team.
+func (r *TeamResolver) Resolve(source, name string) (*Team, error) {
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	normalized := NormalizeTeamName(name)
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: %q", ErrTeamNotFound, name)
+	}
+
+	var sourceMatches, aliasMatches, nameMatches []*Team
+	for i := range r.teams {
+		team := &r.teams[i]
+		for _, alias := range team.Aliases {
+			if alias.Name != normalized {
+				continue
+			}
+			if alias.Provider == source {
+				sourceMatches = append(sourceMatches, team)
+			}
+			aliasMatches = append(aliasMatches, team)
+		}
+
+		if normalized == NormalizeTeamName(team.Abbreviation) || normalized == NormalizeTeamName(team.Name) ||
+			normalized == NormalizeTeamName(team.Location) || TeamNameMatches(name, team.DisplayName) {
+			nameMatches = append(nameMatches, team)
+		}
+	}
+
+	for _, matches := range [][]*Team{sourceMatches, aliasMatches, nameMatches} {
+		matches = uniqueTeams(matches)
+		switch {
+		case len(matches) == 1:
+			return matches[0], nil
+		case len(matches) > 1:
+			return nil, fmt.Errorf("%w: %q", ErrAmbiguousTeam, name)
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrTeamNotFound, name)
+}
+
+// load reads the teams and their aliases the first time they are needed.
+func (r *TeamResolver) load() error {
+	if !r.ready {
+		r.err = r.db.Preload("Aliases").Find(&r.teams).Error
+		r.ready = true
+	}
+	return r.err
+}
+
+// uniqueTeams removes repeated teams, keeping the first occurrence of each.
+func uniqueTeams(teams []*Team) []*Team {
+	var unique []*Team
+	for _, team := range teams {
+		seen := false
+		for _, other := range unique {
+			seen = seen || other.ID == team.ID
+		}
+		if !seen {
+			unique = append(unique, team)
+		}
+	}
+	return unique
+}
+
+// UpsertTeam creates or updates the team identified by its ESPN ID, recording its display
+// name as an ESPN alias so that games stored under a former name still resolve to it.
+func (d *Database) UpsertTeam(team *Team) error {
+	var existing Team
+	err := d.db.Where("espn_id = ?", team.ESPNID).First(&existing).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if err := d.db.Create(team).Error; err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		team.ID = existing.ID
+		team.CreatedAt = existing.CreatedAt
+		if err := d.db.Omit("Aliases").Save(team).Error; err != nil {
+			return err
+		}
+	}
+
+	return AddTeamAlias(d.db, team.ID, TeamSourceESPN, team.DisplayName)
+}
+
+// AddTeamAlias records a name a data source uses for a team. A name the source already
+// uses for the team is left unchanged, while a name it uses for another team is reassigned.
+func AddTeamAlias(db *gorm.DB, teamID uint, source, name string) error {
+	alias := TeamAlias{Provider: source, Name: NormalizeTeamName(name)}
+	if alias.Name == "" {
+		return ErrEmptyTeamAlias
+	}
+
+	if err := db.Where("provider = ? AND name = ?", alias.Provider, alias.Name).FirstOrInit(&alias).Error; err != nil {
+		return err
+	}
+	if alias.ID != 0 && alias.TeamID == teamID {
+		return nil
+	}
+	alias.TeamID = teamID
+	return db.Save(&alias).Error
+}
+
+// FindGame returns the game of a week between two teams. Games are matched by their team
+// IDs, or by the teams' display names for games that do not reference their teams. A team
+// with no ID, such as one whose name could not be resolved, only matches by name.
+func (d *Database) FindGame(season, week int, home, away *Team) (*Game, error) {
+	var game Game
+	err := d.db.Where("season = ? AND week = ?", season, week).
+		Where("(home_team_id = ? AND away_team_id = ?) OR (favorite_team = ? AND underdog_team = ?)",
+			home.ID, away.ID, home.DisplayName, away.DisplayName).
+		First(&game).Error
+	if err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
@@ -0,0 +1,182 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTeamNameMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		team     string
+		expected bool
+	}{
+		{"Green Bay Packers", "Green Bay Packers", true},
+		{"  green bay   PACKERS ", "Green Bay Packers", true},
+		{"Packers", "Green Bay Packers", true},
+		{"Green Bay", "Green Bay Packers", true},
+		{"St. Louis Rams", "St Louis Rams", true},
+		{"49ers", "San Francisco 49ers", true},
+		{"Bay Packers", "Green Bay Packers", false},
+		{"Green", "Green Bay Packers", false},
+		{"Bears", "Green Bay Packers", false},
+		{"", "Green Bay Packers", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TeamNameMatches(tt.name, tt.team); got != tt.expected {
+				t.Errorf("TeamNameMatches(%q, %q) = %v, want %v", tt.name, tt.team, got, tt.expected)
+			}
+		})
+	}
+}
+
+// setupTeamsTest creates a database with the registered teams of two games.
+func setupTeamsTest(t *testing.T) (*Database, []Team) {
+	t.Helper()
+	db, err := New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	teams := []Team{
+		{ESPNID: "19", Abbreviation: "NYG", DisplayName: "New York Giants", Location: "New York", Name: "Giants"},
+		{ESPNID: "20", Abbreviation: "NYJ", DisplayName: "New York Jets", Location: "New York", Name: "Jets"},
+		{ESPNID: "25", Abbreviation: "SF", DisplayName: "San Francisco 49ers", Location: "San Francisco", Name: "49ers"},
+	}
+	for i := range teams {
+		if err := db.UpsertTeam(&teams[i]); err != nil {
+			t.Fatalf("UpsertTeam() error = %v", err)
+		}
+	}
+	return db, teams
+}
+
+func TestTeamResolver_Resolve(t *testing.T) {
+	db, teams := setupTeamsTest(t)
+	giants, jets, niners := teams[0], teams[1], teams[2]
+
+	if err := AddTeamAlias(db.GetDB(), niners.ID, "theoddsapi", "SF 49ers"); err != nil {
+		t.Fatalf("AddTeamAlias() error = %v", err)
+	}
+	// The same name refers to different teams for different sources
+	if err := AddTeamAlias(db.GetDB(), giants.ID, "manual", "NY"); err != nil {
+		t.Fatalf("AddTeamAlias() error = %v", err)
+	}
+	if err := AddTeamAlias(db.GetDB(), jets.ID, "file", "NY"); err != nil {
+		t.Fatalf("AddTeamAlias() error = %v", err)
+	}
+
+	tests := []struct {
+		source   string
+		name     string
+		expected uint
+		err      error
+	}{
+		{"espn", "New York Giants", giants.ID, nil},
+		{"theoddsapi", "new york giants", giants.ID, nil},
+		{"manual", "NYJ", jets.ID, nil},
+		{"manual", "Jets", jets.ID, nil},
+		{"espn", "SF 49ers", niners.ID, nil},
+		{"manual", "NY", giants.ID, nil},
+		{"file", "NY", jets.ID, nil},
+		{"espn", "NY", 0, ErrAmbiguousTeam},
+		{"espn", "New York", 0, ErrAmbiguousTeam},
+		{"espn", "Dallas Cowboys", 0, ErrTeamNotFound},
+		{"espn", "", 0, ErrTeamNotFound},
+	}
+
+	resolver := NewTeamResolver(db.GetDB())
+	for _, tt := range tests {
+		t.Run(tt.source+"/"+tt.name, func(t *testing.T) {
+			team, err := resolver.Resolve(tt.source, tt.name)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Resolve() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if team.ID != tt.expected {
+				t.Errorf("Resolve() = %s, want team %d", team.DisplayName, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAddTeamAlias_Reassigns(t *testing.T) {
+	db, teams := setupTeamsTest(t)
+	giants, jets := teams[0], teams[1]
+
+	if err := AddTeamAlias(db.GetDB(), giants.ID, "manual", "Big Blue"); err != nil {
+		t.Fatalf("AddTeamAlias() error = %v", err)
+	}
+	if err := AddTeamAlias(db.GetDB(), jets.ID, "manual", "big  blue"); err != nil {
+		t.Fatalf("AddTeamAlias() error = %v", err)
+	}
+	if err := AddTeamAlias(db.GetDB(), jets.ID, "manual", " "); !errors.Is(err, ErrEmptyTeamAlias) {
+		t.Errorf("AddTeamAlias() error = %v, want %v", err, ErrEmptyTeamAlias)
+	}
+
+	var aliases []TeamAlias
+	db.GetDB().Where("provider = ? AND name = ?", "manual", "big blue").Find(&aliases)
+	if len(aliases) != 1 || aliases[0].TeamID != jets.ID {
+		t.Errorf("Expected the alias to be reassigned to team %d, got %+v", jets.ID, aliases)
+	}
+}
+
+func TestUpsertTeam_UpdatesByESPNID(t *testing.T) {
+	db, teams := setupTeamsTest(t)
+
+	renamed := Team{ESPNID: "19", Abbreviation: "NYG", DisplayName: "New York Football Giants"}
+	if err := db.UpsertTeam(&renamed); err != nil {
+		t.Fatalf("UpsertTeam() error = %v", err)
+	}
+	if renamed.ID != teams[0].ID {
+		t.Errorf("UpsertTeam() ID = %d, want %d", renamed.ID, teams[0].ID)
+	}
+
+	var count int64
+	db.GetDB().Model(&Team{}).Count(&count)
+	if count != 3 {
+		t.Errorf("Expected 3 teams, got %d", count)
+	}
+	db.GetDB().Model(&TeamAlias{}).Where("team_id = ?", renamed.ID).Count(&count)
+	if count != 2 {
+		t.Errorf("Expected the former and current names as aliases, got %d", count)
+	}
+}
+
+func TestFindGame(t *testing.T) {
+	db, teams := setupTeamsTest(t)
+	giants, jets, niners := &teams[0], &teams[1], &teams[2]
+
+	kickoff := time.Date(2025, 9, 14, 17, 0, 0, 0, time.UTC)
+	games := []Game{
+		{Season: 2025, Week: 2, HomeTeam: "NY Giants", AwayTeam: "New York Jets", HomeTeamID: &giants.ID, AwayTeamID: &jets.ID, StartTime: kickoff},
+		// A game stored before the teams were registered
+		{Season: 2025, Week: 2, HomeTeam: "San Francisco 49ers", AwayTeam: "Dallas Cowboys", StartTime: kickoff},
+	}
+	if err := db.GetDB().Create(&games).Error; err != nil {
+		t.Fatalf("Failed to create games: %v", err)
+	}
+
+	game, err := db.FindGame(2025, 2, giants, jets)
+	if err != nil || game.ID != games[0].ID {
+		t.Errorf("FindGame() = %v, %v, want game %d", game, err, games[0].ID)
+	}
+	game, err = db.FindGame(2025, 2, niners, &Team{DisplayName: "Dallas Cowboys"})
+	if err != nil || game.ID != games[1].ID {
+		t.Errorf("FindGame() = %v, %v, want game %d", game, err, games[1].ID)
+	}
+	if _, err := db.FindGame(2025, 2, jets, giants); err == nil {
+		t.Error("Expected no game with the teams swapped")
+	}
+	if _, err := db.FindGame(2025, 3, giants, jets); err == nil {
+		t.Error("Expected no game in another week")
+	}
+}
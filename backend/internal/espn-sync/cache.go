@@ -2,164 +2,160 @@
 package espnsync
 
 import (
-	"crypto/sha256"
-	"encoding/json"
 	"fmt"
-	"log/slog"
-	"os"
-	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
 )
 
-// Cache manages caching of ESPN API responses.
-type Cache struct {
-	cacheDir string
-	expiry   time.Duration
+// Cache stores the ESPN events of each week. Entries expire after the TTL they were stored
+// with, and entries stored with a TTL of zero or less never expire.
+type Cache interface {
+	// Get returns the cached events of a week, or false if they are missing or expired.
+	Get(season, week int) ([]apiespn.Event, bool)
+	// Set stores the events of a week for the given TTL.
+	Set(season, week int, events []apiespn.Event, ttl time.Duration) error
+	// Delete removes the cached events of a week. Deleting a missing entry is not an error.
+	Delete(season, week int) error
+	// Entries lists the cached weeks, including expired entries that have not been removed yet.
+	Entries() ([]CacheEntry, error)
+	// ClearExpired removes the expired entries.
+	ClearExpired() error
+	// ClearAll removes every entry.
+	ClearAll() error
+	// Stats returns the cache's counters since it was created.
+	Stats() CacheStats
 }
 
-// NewCache creates a new Cache instance.
-func NewCache(cacheDir string, expiry time.Duration) *Cache {
-	return &Cache{
-		cacheDir: cacheDir,
-		expiry:   expiry,
-	}
+// CacheEntry describes the cached events of a week.
+type CacheEntry struct {
+	Season   int
+	Week     int
+	Events   int
+	CachedAt time.Time
+	// ExpiresAt is nil for entries that never expire
+	ExpiresAt *time.Time
 }
 
-// cacheKey generates a cache key for a specific API call.
-func (c *Cache) cacheKey(season, week int) string {
-	hash := sha256.Sum256([]byte(fmt.Sprintf("espn-events-%d-%d", season, week)))
-	return fmt.Sprintf("%x.json", hash[:8])
+// Expired reports whether the entry has expired at the given time.
+func (e CacheEntry) Expired(now time.Time) bool {
+	return e.ExpiresAt != nil && now.After(*e.ExpiresAt)
 }
 
-// cachePath returns the full path to a cache file.
-func (c *Cache) cachePath(key string) string {
-	return filepath.Join(c.cacheDir, key)
+// CacheStats counts the lookups and writes of a cache.
+type CacheStats struct {
+	Backend string
+	Hits    int64
+	Misses  int64
+	Sets    int64
+	// Evictions counts the entries removed to make room for new ones
+	Evictions int64
 }
 
-// Get retrieves cached data for a specific season and week.
-func (c *Cache) Get(season, week int) ([]apiespn.Event, bool) {
-	key := c.cacheKey(season, week)
-	path := c.cachePath(key)
-
-	// Check if cache file exists
-	info, err := os.Stat(path)
-	if err != nil {
-		return nil, false
-	}
-
-	// Check if cache is expired (skip if expiry is negative = never expire)
-	if c.expiry >= 0 && time.Since(info.ModTime()) > c.expiry {
-		os.Remove(path) // Remove expired cache
-		return nil, false
-	}
-
-	// Read cache file
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, false
-	}
-	defer file.Close()
-
-	// Parse cached data
-	var events []apiespn.Event
-	if err := json.NewDecoder(file).Decode(&events); err != nil {
-		slog.Warn("Failed to parse cache file", "path", path, "error", err)
-		return nil, false
-	}
-
-	slog.Debug("Cache hit", "season", season, "week", week)
-	return events, true
+// cacheCounters tracks the statistics shared by the cache backends.
+type cacheCounters struct {
+	hits      atomic.Int64
+	misses    atomic.Int64
+	sets      atomic.Int64
+	evictions atomic.Int64
 }
 
-// Set stores data in the cache for a specific season and week.
-func (c *Cache) Set(season, week int, events []apiespn.Event) error {
-	key := c.cacheKey(season, week)
-	path := c.cachePath(key)
-
-	// Create cache directory if it doesn't exist
-	if err := os.MkdirAll(c.cacheDir, 0755); err != nil {
-		return err
+// stats returns a snapshot of the counters for a backend.
+func (c *cacheCounters) stats(backend string) CacheStats {
+	return CacheStats{
+		Backend:   backend,
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Sets:      c.sets.Load(),
+		Evictions: c.evictions.Load(),
 	}
+}
 
-	// Create cache file
-	file, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Write data to cache file
-	if err := json.NewEncoder(file).Encode(events); err != nil {
-		return err
+// lookup counts the outcome of a Get.
+func (c *cacheCounters) lookup(found bool) {
+	if found {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
 	}
-
-	slog.Debug("Cache set", "season", season, "week", week, "events", len(events))
-	return nil
 }
 
-// ClearExpired removes all expired cache files.
-func (c *Cache) ClearExpired() error {
-	// Skip if expiry is negative (never expire)
-	if c.expiry < 0 {
+// expiresAt returns when an entry stored at cachedAt with the given TTL expires, or nil if it never does.
+func expiresAt(cachedAt time.Time, ttl time.Duration) *time.Time {
+	if ttl <= 0 {
 		return nil
 	}
+	expiry := cachedAt.Add(ttl)
+	return &expiry
+}
 
-	entries, err := os.ReadDir(c.cacheDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil // Cache directory doesn't exist yet
+// newCache creates the cache backend selected in the configuration. Entries expire by the
+// application clock, so that a simulated season fetches fresh events as the clock moves.
+func newCache(cfg *config.Config, db *database.Database, timeProvider clock.Clock) (Cache, error) {
+	switch cfg.ESPN.CacheBackend {
+	case config.CacheBackendFile, "":
+		cache := NewFileCache(cfg.ESPN.CacheDir)
+		cache.clock = timeProvider
+		return cache, nil
+	case config.CacheBackendMemory:
+		if cfg.ESPN.CacheMaxEntries < 1 {
+			return nil, fmt.Errorf("the memory cache requires a positive maximum number of entries, got %d", cfg.ESPN.CacheMaxEntries)
 		}
-		return err
+		cache := NewMemoryCache(cfg.ESPN.CacheMaxEntries)
+		cache.clock = timeProvider
+		return cache, nil
+	case config.CacheBackendDatabase:
+		cache := NewDatabaseCache(db.GetDB())
+		cache.clock = timeProvider
+		return cache, nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", cfg.ESPN.CacheBackend)
 	}
+}
 
-	now := time.Now()
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		path := filepath.Join(c.cacheDir, entry.Name())
-		info, err := entry.Info()
-		if err != nil {
-			continue
-		}
+// cacheTTL returns how long the events of a week are cached. Weeks whose games have all
+// finished no longer change, while weeks with games in progress or partly played change
+// with every score. Weeks that have not started use the default expiry.
+func cacheTTL(cfg *config.Config, events []apiespn.Event) time.Duration {
+	if len(events) == 0 {
+		return cfg.ESPN.CacheExpiry
+	}
 
-		if now.Sub(info.ModTime()) > c.expiry {
-			if err := os.Remove(path); err != nil {
-				slog.Warn("Failed to remove expired cache file", "path", path, "error", err)
-			} else {
-				slog.Debug("Removed expired cache file", "path", path)
-			}
+	var started, finished int
+	for _, event := range events {
+		switch eventState(event) {
+		case database.GameStateInProgress:
+			started++
+		case database.GameStateFinal:
+			started++
+			finished++
 		}
 	}
 
-	return nil
+	switch {
+	case finished == len(events):
+		return cfg.ESPN.CacheCompletedTTL
+	case started > 0:
+		return cfg.ESPN.CacheLiveTTL
+	default:
+		return cfg.ESPN.CacheExpiry
+	}
 }
 
-// ClearAll removes all cache files.
-func (c *Cache) ClearAll() error {
-	entries, err := os.ReadDir(c.cacheDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil // Cache directory doesn't exist yet
-		}
-		return err
+// eventState returns ESPN's state of an event: "pre", "in" or "post".
+func eventState(event apiespn.Event) string {
+	if event.Status == nil || event.Status.Type == nil {
+		return database.GameStateScheduled
 	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		path := filepath.Join(c.cacheDir, entry.Name())
-		if err := os.Remove(path); err != nil {
-			slog.Warn("Failed to remove cache file", "path", path, "error", err)
-		} else {
-			slog.Debug("Removed cache file", "path", path)
-		}
+	if event.Status.Type.Completed != nil && *event.Status.Type.Completed {
+		return database.GameStateFinal
 	}
-
-	return nil
+	if event.Status.Type.State != nil {
+		return *event.Status.Type.State
+	}
+	return database.GameStateScheduled
 }
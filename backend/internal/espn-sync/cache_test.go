@@ -7,11 +7,14 @@ import (
 	"time"
 
 	"github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
 )
 
 func TestCache_GetSet(t *testing.T) {
 	tempDir := t.TempDir()
-	cache := NewCache(tempDir, time.Hour)
+	cache := NewFileCache(tempDir)
 
 	// Test data
 	events := []apiespn.Event{
@@ -26,7 +29,7 @@ func TestCache_GetSet(t *testing.T) {
 	}
 
 	// Test setting cache
-	err := cache.Set(2023, 1, events)
+	err := cache.Set(2023, 1, events, time.Hour)
 	if err != nil {
 		t.Fatalf("Failed to set cache: %v", err)
 	}
@@ -48,7 +51,7 @@ func TestCache_GetSet(t *testing.T) {
 
 func TestCache_Expired(t *testing.T) {
 	tempDir := t.TempDir()
-	cache := NewCache(tempDir, time.Millisecond) // Very short expiry
+	cache := NewFileCache(tempDir)
 
 	events := []apiespn.Event{
 		{
@@ -58,7 +61,7 @@ func TestCache_Expired(t *testing.T) {
 	}
 
 	// Set cache
-	err := cache.Set(2023, 1, events)
+	err := cache.Set(2023, 1, events, time.Millisecond) // Very short expiry
 	if err != nil {
 		t.Fatalf("Failed to set cache: %v", err)
 	}
@@ -78,7 +81,7 @@ func TestCache_Expired(t *testing.T) {
 
 func TestCache_NotFound(t *testing.T) {
 	tempDir := t.TempDir()
-	cache := NewCache(tempDir, time.Hour)
+	cache := NewFileCache(tempDir)
 
 	// Try to get non-existent cache
 	cachedEvents, found := cache.Get(2023, 1)
@@ -92,7 +95,7 @@ func TestCache_NotFound(t *testing.T) {
 
 func TestCache_ClearExpired(t *testing.T) {
 	tempDir := t.TempDir()
-	cache := NewCache(tempDir, time.Millisecond)
+	cache := NewFileCache(tempDir)
 
 	events := []apiespn.Event{
 		{
@@ -102,7 +105,7 @@ func TestCache_ClearExpired(t *testing.T) {
 	}
 
 	// Set cache that will expire
-	err := cache.Set(2023, 1, events)
+	err := cache.Set(2023, 1, events, time.Millisecond)
 	if err != nil {
 		t.Fatalf("Failed to set cache: %v", err)
 	}
@@ -127,7 +130,7 @@ func TestCache_ClearExpired(t *testing.T) {
 
 func TestCache_ClearAll(t *testing.T) {
 	tempDir := t.TempDir()
-	cache := NewCache(tempDir, time.Hour)
+	cache := NewFileCache(tempDir)
 
 	events := []apiespn.Event{
 		{
@@ -137,11 +140,11 @@ func TestCache_ClearAll(t *testing.T) {
 	}
 
 	// Set multiple caches
-	err := cache.Set(2023, 1, events)
+	err := cache.Set(2023, 1, events, time.Hour)
 	if err != nil {
 		t.Fatalf("Failed to set cache: %v", err)
 	}
-	err = cache.Set(2023, 2, events)
+	err = cache.Set(2023, 2, events, time.Hour)
 	if err != nil {
 		t.Fatalf("Failed to set cache: %v", err)
 	}
@@ -170,7 +173,7 @@ func TestCache_ClearAll(t *testing.T) {
 
 func TestCache_InvalidJSON(t *testing.T) {
 	tempDir := t.TempDir()
-	cache := NewCache(tempDir, time.Hour)
+	cache := NewFileCache(tempDir)
 
 	// Create invalid JSON file
 	key := cache.cacheKey(2023, 1)
@@ -195,3 +198,232 @@ func TestCache_InvalidJSON(t *testing.T) {
 		t.Error("Invalid cache should return empty events")
 	}
 }
+
+func TestCache_Delete(t *testing.T) {
+	cache := NewFileCache(t.TempDir())
+
+	events := []apiespn.Event{
+		{
+			Id:   &[]string{"event1"}[0],
+			Name: &[]string{"Game 1"}[0],
+		},
+	}
+
+	if err := cache.Set(2023, 1, events, time.Hour); err != nil {
+		t.Fatalf("Failed to set cache: %v", err)
+	}
+	if err := cache.Set(2023, 2, events, time.Hour); err != nil {
+		t.Fatalf("Failed to set cache: %v", err)
+	}
+
+	if err := cache.Delete(2023, 1); err != nil {
+		t.Fatalf("Failed to delete cache: %v", err)
+	}
+
+	if _, found := cache.Get(2023, 1); found {
+		t.Error("Expected week 1 to be removed from the cache")
+	}
+	if _, found := cache.Get(2023, 2); !found {
+		t.Error("Expected week 2 to remain in the cache")
+	}
+
+	// Deleting a missing entry is not an error
+	if err := cache.Delete(2023, 3); err != nil {
+		t.Errorf("Delete() of missing entry error = %v", err)
+	}
+}
+
+func TestCacheBackends(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	backends := map[string]Cache{
+		config.CacheBackendFile:     NewFileCache(t.TempDir()),
+		config.CacheBackendMemory:   NewMemoryCache(10),
+		config.CacheBackendDatabase: NewDatabaseCache(db.GetDB()),
+	}
+
+	events := []apiespn.Event{{Id: &[]string{"event1"}[0]}, {Id: &[]string{"event2"}[0]}}
+
+	for name, cache := range backends {
+		t.Run(name, func(t *testing.T) {
+			if _, found := cache.Get(2024, 1); found {
+				t.Error("Expected a miss for an empty cache")
+			}
+			if err := cache.Set(2024, 1, events, 0); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			if err := cache.Set(2024, 2, events, time.Millisecond); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			// Replacing an entry updates its TTL
+			if err := cache.Set(2024, 3, events, time.Millisecond); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			if err := cache.Set(2024, 3, events[:1], time.Hour); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			time.Sleep(5 * time.Millisecond)
+
+			cached, found := cache.Get(2024, 1)
+			if !found || len(cached) != 2 || *cached[1].Id != "event2" {
+				t.Errorf("Get() = %v, %v, want the cached events", cached, found)
+			}
+			if _, found := cache.Get(2024, 3); !found {
+				t.Error("Expected the replaced entry to be found")
+			}
+
+			entries, err := cache.Entries()
+			if err != nil {
+				t.Fatalf("Entries() error = %v", err)
+			}
+			if len(entries) != 3 {
+				t.Fatalf("Expected 3 entries, got %+v", entries)
+			}
+			for _, entry := range entries {
+				switch entry.Week {
+				case 1:
+					if entry.ExpiresAt != nil || entry.Events != 2 {
+						t.Errorf("Unexpected permanent entry: %+v", entry)
+					}
+				case 2:
+					if !entry.Expired(time.Now()) {
+						t.Errorf("Expected entry to be expired: %+v", entry)
+					}
+				case 3:
+					if entry.Expired(time.Now()) || entry.Events != 1 {
+						t.Errorf("Unexpected replaced entry: %+v", entry)
+					}
+				}
+			}
+
+			if err := cache.ClearExpired(); err != nil {
+				t.Fatalf("ClearExpired() error = %v", err)
+			}
+			if entries, _ := cache.Entries(); len(entries) != 2 {
+				t.Errorf("Expected 2 entries after clearing expired entries, got %d", len(entries))
+			}
+			if _, found := cache.Get(2024, 2); found {
+				t.Error("Expected the expired entry to be missing")
+			}
+
+			if err := cache.Delete(2024, 1); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if err := cache.Delete(2024, 9); err != nil {
+				t.Errorf("Delete() of missing entry error = %v", err)
+			}
+			if err := cache.Set(2024, 1, events, 0); err != nil {
+				t.Fatalf("Set() after Delete() error = %v", err)
+			}
+			if err := cache.ClearAll(); err != nil {
+				t.Fatalf("ClearAll() error = %v", err)
+			}
+			if entries, _ := cache.Entries(); len(entries) != 0 {
+				t.Errorf("Expected no entries after clearing the cache, got %d", len(entries))
+			}
+
+			stats := cache.Stats()
+			if stats.Backend != name || stats.Hits != 2 || stats.Misses != 2 || stats.Sets != 5 {
+				t.Errorf("Unexpected stats: %+v", stats)
+			}
+		})
+	}
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	events := []apiespn.Event{{Id: &[]string{"event1"}[0]}}
+
+	for week := 1; week <= 2; week++ {
+		if err := cache.Set(2024, week, events, time.Hour); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+	// Week 1 becomes the most recently used, so week 2 is evicted
+	if _, found := cache.Get(2024, 1); !found {
+		t.Fatal("Expected week 1 to be cached")
+	}
+	if err := cache.Set(2024, 3, events, time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if _, found := cache.Get(2024, 2); found {
+		t.Error("Expected week 2 to be evicted")
+	}
+	for _, week := range []int{1, 3} {
+		if _, found := cache.Get(2024, week); !found {
+			t.Errorf("Expected week %d to be cached", week)
+		}
+	}
+	if stats := cache.Stats(); stats.Evictions != 1 {
+		t.Errorf("Evictions = %d, want 1", stats.Evictions)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.ESPN.CacheExpiry = 24 * time.Hour
+	cfg.ESPN.CacheLiveTTL = 2 * time.Minute
+	cfg.ESPN.CacheCompletedTTL = 0
+
+	event := func(state string, completed bool) apiespn.Event {
+		return apiespn.Event{Status: &apiespn.Status{Type: &apiespn.StatusType{State: &state, Completed: &completed}}}
+	}
+
+	tests := []struct {
+		name     string
+		events   []apiespn.Event
+		expected time.Duration
+	}{
+		{"no events", nil, 24 * time.Hour},
+		{"not started", []apiespn.Event{event("pre", false), {}}, 24 * time.Hour},
+		{"in progress", []apiespn.Event{event("post", true), event("in", false)}, 2 * time.Minute},
+		{"between kickoffs", []apiespn.Event{event("post", true), event("pre", false)}, 2 * time.Minute},
+		{"completed", []apiespn.Event{event("post", true), event("post", true)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cacheTTL(cfg, tt.events); got != tt.expected {
+				t.Errorf("cacheTTL() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewCache(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	tests := []struct {
+		backend    string
+		maxEntries int
+		expectErr  bool
+	}{
+		{"", 0, false},
+		{config.CacheBackendFile, 0, false},
+		{config.CacheBackendMemory, 10, false},
+		{config.CacheBackendMemory, 0, true},
+		{config.CacheBackendDatabase, 0, false},
+		{"redis", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.ESPN.CacheBackend = tt.backend
+			cfg.ESPN.CacheMaxEntries = tt.maxEntries
+			cfg.ESPN.CacheDir = t.TempDir()
+
+			_, err := newCache(cfg, db, clock.Real{})
+			if (err != nil) != tt.expectErr {
+				t.Errorf("newCache() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package espnsync
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// calendarSyncInterval is how often the season calendar is refreshed during periodic syncs.
+const calendarSyncInterval = 24 * time.Hour
+
+// SyncCalendar fetches the season calendar from the ESPN scoreboard and stores the week boundaries.
+func (s *SyncService) SyncCalendar(ctx context.Context, season int) error {
+	slog.Info("Syncing season calendar", "season", season)
+
+	dates := strconv.Itoa(season)
+	params := &apiespn.GetScoreboardParams{
+		Dates: &dates,
+	}
+
+	response, err := s.espnClient.GetScoreboardWithResponse(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != 200 {
+		return fmt.Errorf("ESPN API returned status %d: %s", response.StatusCode(), response.Status())
+	}
+
+	if response.JSON200 == nil || response.JSON200.Leagues == nil {
+		return fmt.Errorf("ESPN API returned no calendar")
+	}
+
+	stored := 0
+	for _, league := range *response.JSON200.Leagues {
+		if league.Calendar == nil {
+			continue
+		}
+
+		calendarSeason := season
+		if league.Season != nil && league.Season.Year != nil {
+			calendarSeason = *league.Season.Year
+		}
+
+		for _, week := range s.weeksFromCalendar(*league.Calendar, calendarSeason) {
+			if err := s.db.UpsertWeek(&week); err != nil {
+				return fmt.Errorf("failed to store week %d: %w", week.WeekNumber, err)
+			}
+			stored++
+		}
+	}
+
+	if stored == 0 {
+		return fmt.Errorf("ESPN API returned no calendar")
+	}
+
+	s.lastCalendarSync = s.timeProvider.Now()
+	slog.Info("Synced season calendar", "season", season, "weeks", stored)
+	return nil
+}
+
+// weeksFromCalendar converts ESPN calendar entries into pool weeks.
+// Preseason and Pro Bowl entries are skipped since they are not part of the pool.
+func (s *SyncService) weeksFromCalendar(calendar []apiespn.CalendarSeasonType, season int) []database.Week {
+	var weeks []database.Week
+	for _, seasonType := range calendar {
+		if seasonType.Value == nil || seasonType.Entries == nil {
+			continue
+		}
+
+		seasonTypeValue, err := strconv.Atoi(*seasonType.Value)
+		if err != nil {
+			slog.Warn("Skipping calendar season type with invalid value", "value", *seasonType.Value)
+			continue
+		}
+
+		for _, entry := range *seasonType.Entries {
+			if entry.Value == nil || entry.StartDate == nil || entry.EndDate == nil {
+				continue
+			}
+
+			espnWeek, err := strconv.Atoi(*entry.Value)
+			if err != nil {
+				slog.Warn("Skipping calendar entry with invalid value", "value", *entry.Value)
+				continue
+			}
+
+			week, ok := poolWeekForESPNWeek(seasonTypeValue, espnWeek)
+			if !ok || week > s.lastWeek() {
+				continue
+			}
+
+			weeks = append(weeks, database.Week{
+				WeekNumber:    week,
+				Season:        season,
+				SeasonType:    seasonTypeValue,
+				WeekStartTime: entry.StartDate.Time,
+				WeekEndTime:   entry.EndDate.Time,
+			})
+		}
+	}
+	return weeks
+}
+
+// poolWeekForESPNWeek converts an ESPN season type and week number to a pool week number.
+// It returns false for weeks that are not part of the pool.
+func poolWeekForESPNWeek(seasonType, week int) (int, bool) {
+	switch seasonType {
+	case database.SeasonTypeRegular:
+		return week, week >= 1 && week <= database.LastRegularSeasonWeek
+	case database.SeasonTypePostseason:
+		switch {
+		case week == 5:
+			return database.SuperBowlWeek, true
+		case week >= 1 && week <= 3:
+			return database.LastRegularSeasonWeek + week, true
+		}
+	}
+	return 0, false
+}
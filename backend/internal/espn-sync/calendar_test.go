@@ -0,0 +1,152 @@
+package espnsync
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	apiespn "github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// newSampleCalendarService returns a sync service whose ESPN client serves the sample scoreboard.
+func newSampleCalendarService(t *testing.T, db *database.Database, config *config.Config, now time.Time) *SyncService {
+	sampleData, err := os.ReadFile("../../assets/test/scoreboard-sample.json")
+	if err != nil {
+		t.Fatalf("Failed to read sample data file: %v", err)
+	}
+
+	mockHTTPClient := &MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(string(sampleData))),
+			}, nil
+		},
+	}
+
+	client, err := apiespn.NewClientWithResponses(config.ESPN.BaseURL, apiespn.WithHTTPClient(mockHTTPClient))
+	if err != nil {
+		t.Fatalf("Failed to create ESPN client: %v", err)
+	}
+
+	service, err := NewSyncServiceWithTimeProvider(db, config, MockTimeProvider{
+		NowFunc: func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewSyncServiceWithTimeProvider() error = %v", err)
+	}
+	service.espnClient = client
+	return service
+}
+
+func TestSyncService_SyncCalendar(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	config := testConfig(t)
+	// A date in week 10 that the Week 1 date arithmetic would place in week 9
+	config.ESPN.Week1Date = time.Date(2025, 9, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 11, 9, 18, 0, 0, 0, time.UTC)
+	service := newSampleCalendarService(t, db, config, now)
+
+	if err := service.SyncCalendar(context.Background(), 2025); err != nil {
+		t.Fatalf("SyncCalendar() error = %v", err)
+	}
+
+	var weeks []database.Week
+	if err := db.GetDB().Order("week_number").Find(&weeks).Error; err != nil {
+		t.Fatalf("Failed to query weeks: %v", err)
+	}
+
+	// 18 regular season weeks plus Wild Card, Divisional, Conference and Super Bowl
+	if len(weeks) != database.SuperBowlWeek {
+		t.Fatalf("Expected %d weeks, got %d", database.SuperBowlWeek, len(weeks))
+	}
+
+	week10 := weeks[9]
+	if week10.WeekNumber != 10 || week10.SeasonType != database.SeasonTypeRegular {
+		t.Errorf("Unexpected week 10: number=%d season_type=%d", week10.WeekNumber, week10.SeasonType)
+	}
+	if !week10.WeekStartTime.Equal(time.Date(2025, 11, 5, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected week 10 start time: %v", week10.WeekStartTime)
+	}
+
+	superBowl := weeks[database.SuperBowlWeek-1]
+	if superBowl.WeekNumber != database.SuperBowlWeek || superBowl.SeasonType != database.SeasonTypePostseason {
+		t.Errorf("Unexpected Super Bowl week: number=%d season_type=%d", superBowl.WeekNumber, superBowl.SeasonType)
+	}
+	if !superBowl.WeekStartTime.Equal(time.Date(2026, 2, 5, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected Super Bowl start time: %v", superBowl.WeekStartTime)
+	}
+
+	// The current week is read from the synced calendar
+	if _, week := service.getCurrentSeasonAndWeek(); week != 10 {
+		t.Errorf("getCurrentSeasonAndWeek() week = %d, want 10", week)
+	}
+}
+
+func TestSyncService_SyncCalendarWithoutPlayoffs(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	config := testConfig(t)
+	config.Pool.PlayoffMode = "none"
+	service := newSampleCalendarService(t, db, config, time.Date(2025, 9, 5, 0, 0, 0, 0, time.UTC))
+
+	if err := service.SyncCalendar(context.Background(), 2025); err != nil {
+		t.Fatalf("SyncCalendar() error = %v", err)
+	}
+
+	var count int64
+	if err := db.GetDB().Model(&database.Week{}).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count weeks: %v", err)
+	}
+	if count != database.LastRegularSeasonWeek {
+		t.Errorf("Expected %d weeks, got %d", database.LastRegularSeasonWeek, count)
+	}
+}
+
+func TestPoolWeekForESPNWeek(t *testing.T) {
+	tests := []struct {
+		seasonType   int
+		espnWeek     int
+		expectedWeek int
+		expectedOK   bool
+	}{
+		{seasonType: database.SeasonTypePreseason, espnWeek: 2, expectedOK: false},
+		{seasonType: database.SeasonTypeRegular, espnWeek: 1, expectedWeek: 1, expectedOK: true},
+		{seasonType: database.SeasonTypeRegular, espnWeek: 18, expectedWeek: 18, expectedOK: true},
+		{seasonType: database.SeasonTypePostseason, espnWeek: 1, expectedWeek: database.WildCardWeek, expectedOK: true},
+		{seasonType: database.SeasonTypePostseason, espnWeek: 3, expectedWeek: database.ConferenceWeek, expectedOK: true},
+		{seasonType: database.SeasonTypePostseason, espnWeek: 4, expectedOK: false},
+		{seasonType: database.SeasonTypePostseason, espnWeek: 5, expectedWeek: database.SuperBowlWeek, expectedOK: true},
+	}
+
+	for _, tt := range tests {
+		week, ok := poolWeekForESPNWeek(tt.seasonType, tt.espnWeek)
+		if ok != tt.expectedOK || (ok && week != tt.expectedWeek) {
+			t.Errorf("poolWeekForESPNWeek(%d, %d) = (%d, %v), want (%d, %v)",
+				tt.seasonType, tt.espnWeek, week, ok, tt.expectedWeek, tt.expectedOK)
+		}
+
+		// Pool weeks round-trip back to the ESPN week
+		if ok {
+			seasonType, espnWeek := espnWeekForPoolWeek(week)
+			if seasonType != tt.seasonType || espnWeek != tt.espnWeek {
+				t.Errorf("espnWeekForPoolWeek(%d) = (%d, %d), want (%d, %d)",
+					week, seasonType, espnWeek, tt.seasonType, tt.espnWeek)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package espnsync
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DatabaseCache caches ESPN responses in the application database, so that replicas
+// share their entries and containers need no writable filesystem.
+type DatabaseCache struct {
+	db       *gorm.DB
+	clock    clock.Clock
+	counters cacheCounters
+}
+
+// NewDatabaseCache creates a cache storing its entries in db.
+func NewDatabaseCache(db *gorm.DB) *DatabaseCache {
+	return &DatabaseCache{db: db, clock: clock.Real{}}
+}
+
+// Get retrieves cached data for a specific season and week.
+func (c *DatabaseCache) Get(season, week int) ([]apiespn.Event, bool) {
+	var entry database.ESPNCacheEntry
+	err := c.db.Where("season = ? AND week = ?", season, week).
+		Where("expires_at IS NULL OR expires_at >= ?", c.clock.Now()).
+		First(&entry).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Warn("Failed to read cache entry", "season", season, "week", week, "error", err)
+		}
+		c.counters.lookup(false)
+		return nil, false
+	}
+
+	var events []apiespn.Event
+	if err := json.Unmarshal(entry.Data, &events); err != nil {
+		slog.Warn("Failed to parse cache entry", "season", season, "week", week, "error", err)
+		c.counters.lookup(false)
+		return nil, false
+	}
+
+	c.counters.lookup(true)
+	return events, true
+}
+
+// Set stores data in the cache for a specific season and week.
+func (c *DatabaseCache) Set(season, week int, events []apiespn.Event, ttl time.Duration) error {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	entry := database.ESPNCacheEntry{
+		Season:    season,
+		Week:      week,
+		Events:    len(events),
+		Data:      data,
+		CachedAt:  now,
+		ExpiresAt: expiresAt(now, ttl),
+	}
+	err = c.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "season"}, {Name: "week"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "events", "data", "cached_at", "expires_at"}),
+	}).Create(&entry).Error
+	if err != nil {
+		return err
+	}
+
+	c.counters.sets.Add(1)
+	return nil
+}
+
+// Delete removes the cached data for a specific season and week.
+func (c *DatabaseCache) Delete(season, week int) error {
+	return c.db.Unscoped().Where("season = ? AND week = ?", season, week).Delete(&database.ESPNCacheEntry{}).Error
+}
+
+// Entries lists the cached weeks in season and week order.
+func (c *DatabaseCache) Entries() ([]CacheEntry, error) {
+	var rows []database.ESPNCacheEntry
+	if err := c.db.Omit("data").Order("season, week").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]CacheEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = CacheEntry{
+			Season:    row.Season,
+			Week:      row.Week,
+			Events:    row.Events,
+			CachedAt:  row.CachedAt,
+			ExpiresAt: row.ExpiresAt,
+		}
+	}
+	return entries, nil
+}
+
+// ClearExpired removes the expired entries.
+func (c *DatabaseCache) ClearExpired() error {
+	return c.db.Unscoped().Where("expires_at < ?", c.clock.Now()).Delete(&database.ESPNCacheEntry{}).Error
+}
+
+// ClearAll removes every entry.
+func (c *DatabaseCache) ClearAll() error {
+	return c.db.Unscoped().Where("1 = 1").Delete(&database.ESPNCacheEntry{}).Error
+}
+
+// Stats returns the cache's counters.
+func (c *DatabaseCache) Stats() CacheStats {
+	return c.counters.stats(config.CacheBackendDatabase)
+}
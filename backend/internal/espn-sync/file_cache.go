@@ -0,0 +1,221 @@
+package espnsync
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+// FileCache caches ESPN responses as JSON files in a directory.
+type FileCache struct {
+	cacheDir string
+	clock    clock.Clock
+	counters cacheCounters
+}
+
+// fileCacheEntry is the content of a cache file.
+type fileCacheEntry struct {
+	Season    int             `json:"season"`
+	Week      int             `json:"week"`
+	CachedAt  time.Time       `json:"cached_at"`
+	ExpiresAt *time.Time      `json:"expires_at,omitempty"`
+	Events    []apiespn.Event `json:"events"`
+}
+
+// NewFileCache creates a cache writing to cacheDir, which is created when the first entry is stored.
+func NewFileCache(cacheDir string) *FileCache {
+	return &FileCache{cacheDir: cacheDir, clock: clock.Real{}}
+}
+
+// cacheKey generates a cache key for a specific API call.
+func (c *FileCache) cacheKey(season, week int) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("espn-events-%d-%d", season, week)))
+	return fmt.Sprintf("%x.json", hash[:8])
+}
+
+// cachePath returns the full path to a cache file.
+func (c *FileCache) cachePath(key string) string {
+	return filepath.Join(c.cacheDir, key)
+}
+
+// Get retrieves cached data for a specific season and week.
+func (c *FileCache) Get(season, week int) ([]apiespn.Event, bool) {
+	path := c.cachePath(c.cacheKey(season, week))
+
+	entry, err := c.read(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("Failed to read cache file", "path", path, "error", err)
+		}
+		c.counters.lookup(false)
+		return nil, false
+	}
+
+	if entry.ExpiresAt != nil && c.clock.Now().After(*entry.ExpiresAt) {
+		_ = os.Remove(path) // Remove expired cache
+		c.counters.lookup(false)
+		return nil, false
+	}
+
+	slog.Debug("Cache hit", "season", season, "week", week)
+	c.counters.lookup(true)
+	return entry.Events, true
+}
+
+// read parses a cache file.
+func (c *FileCache) read(path string) (*fileCacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry fileCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse cache file: %w", err)
+	}
+	return &entry, nil
+}
+
+// Set stores data in the cache for a specific season and week.
+func (c *FileCache) Set(season, week int, events []apiespn.Event, ttl time.Duration) error {
+	// Create cache directory if it doesn't exist
+	if err := os.MkdirAll(c.cacheDir, 0755); err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	data, err := json.Marshal(fileCacheEntry{
+		Season:    season,
+		Week:      week,
+		CachedAt:  now,
+		ExpiresAt: expiresAt(now, ttl),
+		Events:    events,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so readers never see a partially written entry
+	path := c.cachePath(c.cacheKey(season, week))
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	c.counters.sets.Add(1)
+	slog.Debug("Cache set", "season", season, "week", week, "events", len(events), "ttl", ttl)
+	return nil
+}
+
+// Delete removes the cached data for a specific season and week.
+func (c *FileCache) Delete(season, week int) error {
+	path := c.cachePath(c.cacheKey(season, week))
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Entries lists the cached weeks. Files that cannot be parsed are skipped.
+func (c *FileCache) Entries() ([]CacheEntry, error) {
+	paths, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]CacheEntry, 0, len(paths))
+	for _, path := range paths {
+		entry, err := c.read(path)
+		if err != nil {
+			slog.Warn("Failed to read cache file", "path", path, "error", err)
+			continue
+		}
+		entries = append(entries, CacheEntry{
+			Season:    entry.Season,
+			Week:      entry.Week,
+			Events:    len(entry.Events),
+			CachedAt:  entry.CachedAt,
+			ExpiresAt: entry.ExpiresAt,
+		})
+	}
+	return entries, nil
+}
+
+// ClearExpired removes all expired cache files, along with files that cannot be parsed.
+func (c *FileCache) ClearExpired() error {
+	paths, err := c.files()
+	if err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	for _, path := range paths {
+		entry, err := c.read(path)
+		if err == nil && (entry.ExpiresAt == nil || !now.After(*entry.ExpiresAt)) {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			slog.Warn("Failed to remove expired cache file", "path", path, "error", err)
+		} else {
+			slog.Debug("Removed expired cache file", "path", path)
+		}
+	}
+
+	return nil
+}
+
+// ClearAll removes all cache files.
+func (c *FileCache) ClearAll() error {
+	paths, err := c.files()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			slog.Warn("Failed to remove cache file", "path", path, "error", err)
+		} else {
+			slog.Debug("Removed cache file", "path", path)
+		}
+	}
+
+	return nil
+}
+
+// Stats returns the cache's counters.
+func (c *FileCache) Stats() CacheStats {
+	return c.counters.stats(config.CacheBackendFile)
+}
+
+// files returns the paths of the cache files.
+func (c *FileCache) files() ([]string, error) {
+	entries, err := os.ReadDir(c.cacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // Cache directory doesn't exist yet
+		}
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		paths = append(paths, filepath.Join(c.cacheDir, entry.Name()))
+	}
+	return paths, nil
+}
@@ -0,0 +1,158 @@
+package espnsync
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+// MemoryCache caches ESPN responses in memory, evicting the least recently used week once it
+// holds its maximum number of entries. It suits read-only filesystems, but every replica
+// keeps its own entries and they are lost on restart.
+type MemoryCache struct {
+	maxEntries int
+	clock      clock.Clock
+	counters   cacheCounters
+
+	mu      sync.Mutex
+	order   *list.List // front is the most recently used entry
+	entries map[[2]int]*list.Element
+}
+
+// memoryCacheEntry is an element of the LRU list.
+type memoryCacheEntry struct {
+	CacheEntry
+	events []apiespn.Event
+}
+
+// NewMemoryCache creates a cache holding at most maxEntries weeks.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		clock:      clock.Real{},
+		order:      list.New(),
+		entries:    make(map[[2]int]*list.Element),
+	}
+}
+
+// Get retrieves cached data for a specific season and week.
+func (c *MemoryCache) Get(season, week int) ([]apiespn.Event, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[[2]int{season, week}]
+	if !ok {
+		c.counters.lookup(false)
+		return nil, false
+	}
+
+	entry := element.Value.(*memoryCacheEntry)
+	if entry.Expired(c.clock.Now()) {
+		c.remove(element)
+		c.counters.lookup(false)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	c.counters.lookup(true)
+	return entry.events, true
+}
+
+// Set stores data in the cache for a specific season and week.
+func (c *MemoryCache) Set(season, week int, events []apiespn.Event, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	entry := &memoryCacheEntry{
+		CacheEntry: CacheEntry{
+			Season:    season,
+			Week:      week,
+			Events:    len(events),
+			CachedAt:  now,
+			ExpiresAt: expiresAt(now, ttl),
+		},
+		events: events,
+	}
+
+	key := [2]int{season, week}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+	} else {
+		c.entries[key] = c.order.PushFront(entry)
+	}
+
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+		c.counters.evictions.Add(1)
+	}
+
+	c.counters.sets.Add(1)
+	return nil
+}
+
+// Delete removes the cached data for a specific season and week.
+func (c *MemoryCache) Delete(season, week int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[[2]int{season, week}]; ok {
+		c.remove(element)
+	}
+	return nil
+}
+
+// Entries lists the cached weeks, most recently used first.
+func (c *MemoryCache) Entries() ([]CacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := make([]CacheEntry, 0, c.order.Len())
+	for element := c.order.Front(); element != nil; element = element.Next() {
+		entries = append(entries, element.Value.(*memoryCacheEntry).CacheEntry)
+	}
+	return entries, nil
+}
+
+// ClearExpired removes the expired entries.
+func (c *MemoryCache) ClearExpired() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for element := c.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*memoryCacheEntry).Expired(now) {
+			c.remove(element)
+		}
+		element = next
+	}
+	return nil
+}
+
+// ClearAll removes every entry.
+func (c *MemoryCache) ClearAll() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[[2]int]*list.Element)
+	return nil
+}
+
+// Stats returns the cache's counters.
+func (c *MemoryCache) Stats() CacheStats {
+	return c.counters.stats(config.CacheBackendMemory)
+}
+
+// remove deletes an element from the list and the index. The caller must hold the lock.
+func (c *MemoryCache) remove(element *list.Element) {
+	entry := element.Value.(*memoryCacheEntry)
+	delete(c.entries, [2]int{entry.Season, entry.Week})
+	c.order.Remove(element)
+}
@@ -0,0 +1,159 @@
+package espnsync
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// liveGameWindow is how long after kickoff a game is considered in progress until ESPN
+// reports it final. It covers games that kick off between two polls.
+const liveGameWindow = 4 * time.Hour
+
+// weekFeed is what the sync remembers of the latest scoreboard of a week.
+type weekFeed struct {
+	events []apiespn.Event
+	// etag and lastModified are the validators of the response the events came from,
+	// sent with the next request so that ESPN can answer 304 Not Modified
+	etag         string
+	lastModified string
+}
+
+// conditional adds the validators of the feed to a scoreboard request.
+func (f weekFeed) conditional(_ context.Context, req *http.Request) error {
+	if f.etag != "" {
+		req.Header.Set("If-None-Match", f.etag)
+	}
+	if f.lastModified != "" {
+		req.Header.Set("If-Modified-Since", f.lastModified)
+	}
+	return nil
+}
+
+// states maps the ESPN event IDs of the feed to their state.
+func (f weekFeed) states() map[string]string {
+	states := make(map[string]string, len(f.events))
+	for _, event := range f.events {
+		if event.Id != nil {
+			states[*event.Id] = eventState(event)
+		}
+	}
+	return states
+}
+
+// feed returns the latest scoreboard of a week, and false if the week has not been fetched.
+func (s *SyncService) feed(season, week int) (weekFeed, bool) {
+	s.feedsMu.Lock()
+	defer s.feedsMu.Unlock()
+
+	feed, ok := s.feeds[[2]int{season, week}]
+	if !ok {
+		return weekFeed{}, false
+	}
+	return *feed, true
+}
+
+// storeFeed records the latest events of a week. The validators are kept from the previous
+// response unless a header is given.
+func (s *SyncService) storeFeed(season, week int, events []apiespn.Event, header http.Header) {
+	s.feedsMu.Lock()
+	defer s.feedsMu.Unlock()
+
+	feed, ok := s.feeds[[2]int{season, week}]
+	if !ok {
+		feed = &weekFeed{}
+		s.feeds[[2]int{season, week}] = feed
+	}
+	feed.events = events
+	if header != nil {
+		feed.etag = header.Get("ETag")
+		feed.lastModified = header.Get("Last-Modified")
+	}
+}
+
+// forgetFeed drops the latest scoreboard of a week, so that it is fetched unconditionally.
+func (s *SyncService) forgetFeed(season, week int) {
+	s.feedsMu.Lock()
+	defer s.feedsMu.Unlock()
+
+	delete(s.feeds, [2]int{season, week})
+}
+
+// weekInProgress reports whether a week has games in progress at the given time. A game is
+// in progress if ESPN last reported it in progress, or if it kicked off within liveGameWindow
+// and ESPN has not reported it final.
+func (s *SyncService) weekInProgress(season, week int, now time.Time) (bool, error) {
+	var games []database.Game
+	if err := s.db.GetDB().Where("season = ? AND week = ? AND start_time <= ?", season, week, now).Find(&games).Error; err != nil {
+		return false, err
+	}
+	if len(games) == 0 {
+		return false, nil
+	}
+
+	feed, _ := s.feed(season, week)
+	states := feed.states()
+	for _, game := range games {
+		switch states[game.ESPNEventID] {
+		case database.GameStateInProgress:
+			return true, nil
+		case database.GameStateFinal:
+			continue
+		}
+		if now.Sub(game.StartTime) < liveGameWindow {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// nextSync returns when the ESPN sync should run after t. The current week is polled every
+// LiveSyncInterval while games are in progress, every SyncInterval while games are still to be
+// played, and every IdleSyncInterval once they are over. The sync always runs at the next
+// kickoff, so that polling speeds up as soon as a game starts.
+func (s *SyncService) nextSync(t time.Time) time.Time {
+	season, week := s.getCurrentSeasonAndWeek()
+
+	live, err := s.weekInProgress(season, week, t)
+	if err != nil {
+		slog.Warn("Failed to check for games in progress", "season", season, "week", week, "error", err)
+		return t.Add(s.config.ESPN.SyncInterval)
+	}
+	if live {
+		return t.Add(s.syncInterval(s.config.ESPN.LiveSyncInterval))
+	}
+
+	var remaining int64
+	if err := s.db.GetDB().Model(&database.Game{}).
+		Where("season = ? AND week = ? AND start_time > ?", season, week, t).
+		Count(&remaining).Error; err != nil {
+		slog.Warn("Failed to count remaining games", "season", season, "week", week, "error", err)
+		return t.Add(s.config.ESPN.SyncInterval)
+	}
+
+	next := t.Add(s.syncInterval(s.config.ESPN.IdleSyncInterval))
+	if remaining > 0 {
+		next = t.Add(s.config.ESPN.SyncInterval)
+	}
+
+	var kickoff database.Game
+	result := s.db.GetDB().Where("start_time > ?", t).Order("start_time ASC").Limit(1).Find(&kickoff)
+	if result.Error != nil {
+		slog.Warn("Failed to find the next kickoff", "error", result.Error)
+	} else if result.RowsAffected > 0 && kickoff.StartTime.Before(next) {
+		next = kickoff.StartTime
+	}
+	return next
+}
+
+// syncInterval returns the interval, falling back to SyncInterval if it is not set.
+func (s *SyncService) syncInterval(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return s.config.ESPN.SyncInterval
+	}
+	return interval
+}
@@ -0,0 +1,202 @@
+package espnsync
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apiespn "github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// testEvent returns an ESPN event with the given ID and state.
+func testEvent(id, state string) apiespn.Event {
+	completed := state == "post"
+	return apiespn.Event{
+		Id:     &id,
+		Status: &apiespn.Status{Type: &apiespn.StatusType{State: &state, Completed: &completed}},
+	}
+}
+
+func TestSyncService_NextSync(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	config := testConfig(t)
+	config.ESPN.LiveSyncInterval = time.Minute
+	config.ESPN.IdleSyncInterval = 6 * time.Hour
+
+	var now atomic.Value
+	service, err := NewSyncServiceWithTimeProvider(db, config, MockTimeProvider{
+		NowFunc: func() time.Time { return now.Load().(time.Time) },
+	})
+	if err != nil {
+		t.Fatalf("NewSyncServiceWithTimeProvider() error = %v", err)
+	}
+
+	games := []database.Game{
+		{Season: 2025, Week: 1, HomeTeam: "Eagles", AwayTeam: "Cowboys", StartTime: time.Date(2025, 9, 5, 0, 20, 0, 0, time.UTC), ESPNEventID: "1"},
+		{Season: 2025, Week: 1, HomeTeam: "Bills", AwayTeam: "Ravens", StartTime: time.Date(2025, 9, 8, 0, 20, 0, 0, time.UTC), ESPNEventID: "2"},
+		{Season: 2025, Week: 2, HomeTeam: "Packers", AwayTeam: "Commanders", StartTime: time.Date(2025, 9, 12, 0, 15, 0, 0, time.UTC), ESPNEventID: "3"},
+	}
+	if err := db.GetDB().Create(&games).Error; err != nil {
+		t.Fatalf("Failed to create games: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		events   []apiespn.Event
+		expected time.Time
+	}{
+		{
+			name:     "games still to be played",
+			now:      time.Date(2025, 9, 4, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 4, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "wakes up for the next kickoff",
+			now:      time.Date(2025, 9, 4, 23, 50, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 5, 0, 20, 0, 0, time.UTC),
+		},
+		{
+			name:     "kicked off before the feed reports it",
+			now:      time.Date(2025, 9, 5, 1, 0, 0, 0, time.UTC),
+			events:   []apiespn.Event{testEvent("1", "pre"), testEvent("2", "pre")},
+			expected: time.Date(2025, 9, 5, 1, 1, 0, 0, time.UTC),
+		},
+		{
+			name:     "game final",
+			now:      time.Date(2025, 9, 5, 1, 0, 0, 0, time.UTC),
+			events:   []apiespn.Event{testEvent("1", "post"), testEvent("2", "pre")},
+			expected: time.Date(2025, 9, 5, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "in progress past the live window",
+			now:      time.Date(2025, 9, 8, 5, 0, 0, 0, time.UTC),
+			events:   []apiespn.Event{testEvent("1", "post"), testEvent("2", "in")},
+			expected: time.Date(2025, 9, 8, 5, 1, 0, 0, time.UTC),
+		},
+		{
+			name:     "week over",
+			now:      time.Date(2025, 9, 8, 6, 0, 0, 0, time.UTC),
+			events:   []apiespn.Event{testEvent("1", "post"), testEvent("2", "post")},
+			expected: time.Date(2025, 9, 8, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "next week",
+			now:      time.Date(2025, 9, 11, 21, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 11, 22, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now.Store(tt.now)
+			service.forgetFeed(2025, 1)
+			if tt.events != nil {
+				service.storeFeed(2025, 1, tt.events, nil)
+			}
+
+			if next := service.nextSync(tt.now); !next.Equal(tt.expected) {
+				t.Errorf("nextSync(%v) = %v, want %v", tt.now, next, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSyncService_ConditionalRequests(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	config := testConfig(t)
+	config.ESPN.CacheLiveTTL = time.Hour
+
+	body := `{
+		"leagues": [],
+		"season": {"type": 2, "year": 2025},
+		"week": {"number": 1},
+		"events": [{
+			"id": "event1",
+			"name": "Test Game",
+			"date": "2025-09-05T00:20Z",
+			"status": {"type": {"state": "in", "completed": false}},
+			"competitions": [{
+				"competitors": [
+					{"homeAway": "home", "team": {"displayName": "Team A"}, "score": "7"},
+					{"homeAway": "away", "team": {"displayName": "Team B"}, "score": "3"}
+				]
+			}]
+		}]
+	}`
+
+	var requests []http.Header
+	client, err := apiespn.NewClientWithResponses(config.ESPN.BaseURL, apiespn.WithHTTPClient(&MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Header.Clone())
+			if req.Header.Get("If-None-Match") == `"v1"` {
+				return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: http.NoBody}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type":  []string{"application/json"},
+					"Etag":          []string{`"v1"`},
+					"Last-Modified": []string{"Fri, 05 Sep 2025 00:50:00 GMT"},
+				},
+				Body: io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ESPN client: %v", err)
+	}
+
+	service, err := NewSyncServiceWithTimeProvider(db, config, MockTimeProvider{
+		NowFunc: func() time.Time { return time.Date(2025, 9, 5, 1, 0, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("NewSyncServiceWithTimeProvider() error = %v", err)
+	}
+	service.espnClient = client
+
+	ctx := context.Background()
+	if err := service.SyncWeekData(ctx, 2025, 1); err != nil {
+		t.Fatalf("SyncWeekData() error = %v", err)
+	}
+	if len(requests) != 1 || requests[0].Get("If-None-Match") != "" {
+		t.Fatalf("Expected an unconditional request, got %v", requests)
+	}
+
+	// The game is in progress, so the cached events are skipped and ESPN answers not modified
+	if err := service.SyncWeekData(ctx, 2025, 1); err != nil {
+		t.Fatalf("SyncWeekData() error = %v", err)
+	}
+	if len(requests) != 2 || requests[1].Get("If-Modified-Since") != "Fri, 05 Sep 2025 00:50:00 GMT" {
+		t.Fatalf("Expected a conditional request, got %v", requests)
+	}
+
+	status, err := service.GetWeekSyncStatus(2025, 1)
+	if err != nil {
+		t.Fatalf("GetWeekSyncStatus() error = %v", err)
+	}
+	if status.LastError != "" || status.GamesUpdated != 1 {
+		t.Errorf("Expected the unchanged events to be stored, got %+v", status)
+	}
+
+	// Refreshing a week fetches it unconditionally
+	if err := service.RefreshWeek(ctx, 2025, 1); err != nil {
+		t.Fatalf("RefreshWeek() error = %v", err)
+	}
+	if len(requests) != 3 || requests[2].Get("If-None-Match") != "" {
+		t.Errorf("Expected an unconditional request, got %v", requests)
+	}
+}
@@ -0,0 +1,167 @@
+package espnsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/odds-sync"
+	"github.com/dhpollack/football-pool/internal/upstream"
+	"gorm.io/gorm"
+)
+
+// SyncStatus summarizes the state of the ESPN sync.
+type SyncStatus struct {
+	Enabled bool
+	// LastAttemptAt and LastSuccessAt are the latest attempt and success across all weeks.
+	LastAttemptAt *time.Time
+	LastSuccessAt *time.Time
+	// LastError is the error of the latest attempt, empty if it succeeded.
+	LastError string
+	// NextRunAt is the next scheduled sync, nil if the sync is not scheduled.
+	NextRunAt *time.Time
+	Weeks     []database.WeekSyncStatus
+	// Upstreams reports the request counters and circuit breaker state of each upstream API.
+	Upstreams []upstream.Stats
+	// OddsQuota is the Odds API request quota of the current billing period, nil if no request has been made.
+	OddsQuota *oddssync.Quota
+	// ScheduleAlerts is the number of unacknowledged schedule changes to the season's games that have picks.
+	ScheduleAlerts int
+	// Live reports whether games of the current week are in progress, which polls ESPN at the live interval.
+	Live bool
+}
+
+// GetSyncStatus returns the current status of the sync service and the sync status of every week.
+func (s *SyncService) GetSyncStatus(season int) (*SyncStatus, error) {
+	status := &SyncStatus{Enabled: s.syncEnabled}
+
+	if err := s.db.GetDB().Where("season = ?", season).Order("week ASC").Find(&status.Weeks).Error; err != nil {
+		return nil, fmt.Errorf("failed to load sync status: %w", err)
+	}
+
+	for i := range status.Weeks {
+		week := &status.Weeks[i]
+		if status.LastAttemptAt == nil || week.LastAttemptAt.After(*status.LastAttemptAt) {
+			status.LastAttemptAt = &week.LastAttemptAt
+			status.LastError = week.LastError
+		}
+		if week.LastSuccessAt != nil && (status.LastSuccessAt == nil || week.LastSuccessAt.After(*status.LastSuccessAt)) {
+			status.LastSuccessAt = week.LastSuccessAt
+		}
+	}
+
+	if s.scheduler != nil {
+		if next, ok := s.scheduler.NextRun(JobESPNSync); ok {
+			status.NextRunAt = &next
+		}
+	}
+
+	status.Upstreams = s.UpstreamStats()
+
+	quota, err := s.oddsService.Quota()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the Odds API quota: %w", err)
+	}
+	status.OddsQuota = quota
+
+	var alerts int64
+	if err := s.db.GetDB().Model(&database.ScheduleChange{}).
+		Where("season = ? AND picks_attached > 0 AND acknowledged_at IS NULL", season).
+		Count(&alerts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count schedule alerts: %w", err)
+	}
+	status.ScheduleAlerts = int(alerts)
+
+	currentSeason, currentWeek := s.getCurrentSeasonAndWeek()
+	live, err := s.weekInProgress(currentSeason, currentWeek, s.timeProvider.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for games in progress: %w", err)
+	}
+	status.Live = live
+
+	return status, nil
+}
+
+// UpstreamStats returns the request counters and circuit breaker state of the ESPN and Odds API clients.
+func (s *SyncService) UpstreamStats() []upstream.Stats {
+	return []upstream.Stats{s.espnHTTP.Stats(), s.oddsService.UpstreamStats()}
+}
+
+// GetWeekSyncStatus returns the sync status of a single week.
+func (s *SyncService) GetWeekSyncStatus(season, week int) (*database.WeekSyncStatus, error) {
+	var status database.WeekSyncStatus
+	if err := s.db.GetDB().Where("season = ? AND week = ?", season, week).First(&status).Error; err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// RefreshWeek syncs a week from ESPN, bypassing the cache and conditional requests.
+func (s *SyncService) RefreshWeek(ctx context.Context, season, week int) error {
+	s.forgetFeed(season, week)
+	if err := s.cache.Delete(season, week); err != nil {
+		slog.Warn("Failed to remove cached events", "season", season, "week", week, "error", err)
+	}
+	return s.SyncWeekData(ctx, season, week)
+}
+
+// RefreshSpreads fetches the latest spreads for a week. Refreshes are requested by
+// an admin, so they are sent even when the Odds API budget floor has been reached.
+func (s *SyncService) RefreshSpreads(ctx context.Context, season, week int) error {
+	return s.oddsService.UpdateGameSpreads(ctx, season, week, oddssync.PriorityEssential)
+}
+
+// ClearCache removes every cached ESPN response.
+func (s *SyncService) ClearCache() error {
+	return s.cache.ClearAll()
+}
+
+// ClearExpiredCache removes the expired ESPN responses.
+func (s *SyncService) ClearExpiredCache() error {
+	return s.cache.ClearExpired()
+}
+
+// DeleteCacheEntry removes the cached ESPN response of a week.
+func (s *SyncService) DeleteCacheEntry(season, week int) error {
+	return s.cache.Delete(season, week)
+}
+
+// CacheEntries lists the cached ESPN responses.
+func (s *SyncService) CacheEntries() ([]CacheEntry, error) {
+	return s.cache.Entries()
+}
+
+// CacheStats returns the hit and miss counters of the ESPN response cache.
+func (s *SyncService) CacheStats() CacheStats {
+	return s.cache.Stats()
+}
+
+// recordWeekSync stores the outcome of a week sync. Game counts are only
+// replaced by successful syncs.
+func (s *SyncService) recordWeekSync(season, week int, attemptedAt time.Time, created, updated int, syncErr error) {
+	var status database.WeekSyncStatus
+	err := s.db.GetDB().Where("season = ? AND week = ?", season, week).First(&status).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Failed to load week sync status", "season", season, "week", week, "error", err)
+		return
+	}
+
+	status.Season = season
+	status.Week = week
+	status.LastAttemptAt = attemptedAt
+	if syncErr != nil {
+		status.LastError = syncErr.Error()
+	} else {
+		status.LastSuccessAt = &attemptedAt
+		status.LastError = ""
+		status.GamesCreated = created
+		status.GamesUpdated = updated
+	}
+
+	if err := s.db.GetDB().Save(&status).Error; err != nil {
+		slog.Error("Failed to record week sync status", "season", season, "week", week, "error", err)
+	}
+}
@@ -3,52 +3,69 @@ package espnsync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/clock"
 	"github.com/dhpollack/football-pool/internal/config"
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/jobs"
 	"github.com/dhpollack/football-pool/internal/odds-sync"
+	"github.com/dhpollack/football-pool/internal/upstream"
 )
 
-// TimeProvider defines an interface for getting the current time.
-// This allows for dependency injection in tests.
-type TimeProvider interface {
-	Now() time.Time
-}
-
-// RealTimeProvider provides the actual current time.
-type RealTimeProvider struct{}
+// TimeProvider is the application clock. It is injected so that tests and E2E runs can
+// control the time.
+type TimeProvider = clock.Clock
 
-// Now returns the current time.
-func (r RealTimeProvider) Now() time.Time {
-	return time.Now()
-}
+// ResultsHandler is notified after a sync changes the results of a week.
+type ResultsHandler func(ctx context.Context, season, week int)
 
 // SyncService orchestrates the fetching, transformation, and storage of ESPN data.
 type SyncService struct {
 	db           *database.Database
 	espnClient   *apiespn.ClientWithResponses
+	espnHTTP     *upstream.Client
 	oddsService  *oddssync.OddsService
-	cache        *Cache
+	cache        Cache
 	transformer  *Transformer
 	syncEnabled  bool
 	config       *config.Config
 	timeProvider TimeProvider
+	scheduler    *jobs.Scheduler
+
+	lastCalendarSync time.Time
+
+	// onResults is notified when a sync changes the results of a week
+	onResults ResultsHandler
+	// onLiveScores is notified when a sync changes the live scores of a week
+	onLiveScores ResultsHandler
+
+	// feeds holds the latest scoreboard of each fetched week, keyed by season and week
+	feedsMu sync.Mutex
+	feeds   map[[2]int]*weekFeed
 }
 
 // NewSyncService creates a new SyncService instance.
 func NewSyncService(db *database.Database, config *config.Config) (*SyncService, error) {
-	return NewSyncServiceWithTimeProvider(db, config, RealTimeProvider{})
+	return NewSyncServiceWithTimeProvider(db, config, clock.Real{})
 }
 
 // NewSyncServiceWithTimeProvider creates a new SyncService instance with a custom time provider.
 // This is primarily for testing purposes.
 func NewSyncServiceWithTimeProvider(db *database.Database, config *config.Config, timeProvider TimeProvider) (*SyncService, error) {
 	// Create ESPN client
-	client, err := apiespn.NewClientWithResponses(config.ESPN.BaseURL)
+	espnHTTP, err := upstream.NewClient("espn", config)
+	if err != nil {
+		return nil, err
+	}
+	client, err := apiespn.NewClientWithResponses(config.ESPN.BaseURL, apiespn.WithHTTPClient(espnHTTP))
 	if err != nil {
 		return nil, err
 	}
@@ -60,65 +77,110 @@ func NewSyncServiceWithTimeProvider(db *database.Database, config *config.Config
 	}
 
 	// Create cache
-	cache := NewCache(config.ESPN.CacheDir, config.ESPN.CacheExpiry)
+	cache, err := newCache(config, db, timeProvider)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create transformer
-	transformer := NewTransformer(db)
+	transformer := NewTransformerWithTimeProvider(db, timeProvider)
 
 	return &SyncService{
 		db:           db,
 		espnClient:   client,
+		espnHTTP:     espnHTTP,
 		oddsService:  oddsService,
 		cache:        cache,
 		transformer:  transformer,
 		syncEnabled:  config.ESPN.SyncEnabled,
 		config:       config,
 		timeProvider: timeProvider,
+		feeds:        make(map[[2]int]*weekFeed),
 	}, nil
 }
 
-// Start begins the background synchronization process.
-func (s *SyncService) Start(ctx context.Context, interval time.Duration) {
-	if !s.syncEnabled {
-		slog.Info("ESPN sync service is disabled")
-		return
-	}
-
-	slog.Info("Starting ESPN sync service", "interval", interval.String())
-
-	// Run initial sync
-	s.syncData(ctx)
-
-	// Backfill missing weeks
-	go s.BackfillWeeks(ctx)
+// OnResults registers the handler notified when a sync changes the results of a week.
+func (s *SyncService) OnResults(handler ResultsHandler) {
+	s.onResults = handler
+}
 
-	// Check and update spreads for current and past week
-	go s.CheckAndUpdateSpreads(ctx)
+// OnLiveScores registers the handler notified when a sync changes the live scores or game
+// states of a week.
+func (s *SyncService) OnLiveScores(handler ResultsHandler) {
+	s.onLiveScores = handler
+}
 
-	// Start weekly spread updates at Monday 11pm EST
-	go s.StartWeeklySpreadUpdates(ctx)
+// Names of the background jobs registered by the sync service.
+const (
+	JobESPNSync      = "espn-sync"
+	JobBackfillWeeks = "espn-backfill-weeks"
+	JobCheckSpreads  = "check-spreads"
+	JobWeeklySpreads = "weekly-spreads"
+)
 
-	// Start periodic sync
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+// weeklySpreadsSchedule runs the weekly spread update every Monday at 11pm Eastern.
+const weeklySpreadsSchedule = "CRON_TZ=America/New_York 0 23 * * 1"
 
-	for {
-		select {
-		case <-ctx.Done():
-			slog.Info("Stopping ESPN sync service")
-			return
-		case <-ticker.C:
-			s.syncData(ctx)
-		}
+// RegisterJobs registers the sync service's background work with the scheduler.
+// The initial sync, backfill and spread check run at startup in that order.
+func (s *SyncService) RegisterJobs(scheduler *jobs.Scheduler) error {
+	if !s.syncEnabled {
+		slog.Info("ESPN sync service is disabled")
+		return nil
 	}
+
+	slog.Info("Registering ESPN sync jobs",
+		"interval", s.config.ESPN.SyncInterval.String(),
+		"live_interval", s.syncInterval(s.config.ESPN.LiveSyncInterval).String(),
+		"idle_interval", s.syncInterval(s.config.ESPN.IdleSyncInterval).String())
+	s.scheduler = scheduler
+
+	// The sync interval follows the current week's games, so the schedule only describes it
+	syncSchedule := fmt.Sprintf("@every %s (%s while games are in progress, %s when the week is over)",
+		s.config.ESPN.SyncInterval, s.syncInterval(s.config.ESPN.LiveSyncInterval), s.syncInterval(s.config.ESPN.IdleSyncInterval))
+
+	return errors.Join(
+		scheduler.Register(jobs.Job{
+			Name:        JobESPNSync,
+			Description: "Sync the season calendar and the current week's games and scores from ESPN",
+			Schedule:    syncSchedule,
+			Cadence:     jobs.ScheduleFunc(s.nextSync),
+			RunOnStart:  true,
+			Run:         s.syncData,
+		}),
+		scheduler.Register(jobs.Job{
+			Name:        JobBackfillWeeks,
+			Description: "Sync every week of the season that has no games",
+			RunOnStart:  true,
+			Run: func(ctx context.Context) error {
+				s.BackfillWeeks(ctx)
+				return nil
+			},
+		}),
+		scheduler.Register(jobs.Job{
+			Name:        JobCheckSpreads,
+			Description: "Fetch spreads for the current and previous week if they are missing",
+			RunOnStart:  true,
+			Run: func(ctx context.Context) error {
+				s.CheckAndUpdateSpreads(ctx)
+				return nil
+			},
+		}),
+		scheduler.Register(jobs.Job{
+			Name:        JobWeeklySpreads,
+			Description: "Update spreads for the upcoming week every Monday at 11pm Eastern",
+			Schedule:    weeklySpreadsSchedule,
+			Run:         s.UpdateUpcomingWeekSpreads,
+		}),
+	)
 }
 
 // BackfillWeeks checks for missing games in the database for all weeks of the season and syncs them if necessary.
-// This is a one-time operation at startup.
+// It runs once at startup and can be triggered again by admins.
 func (s *SyncService) BackfillWeeks(ctx context.Context) {
 	slog.Info("Starting backfill of missing weeks")
-	season := s.config.ESPN.SeasonYear
-	for week := 1; week <= 18; week++ {
+	season := s.CurrentSeason()
+	for week := 1; week <= s.lastWeek(); week++ {
 		hasGames, err := s.db.WeekHasGames(season, week)
 		if err != nil {
 			slog.Error("Failed to check if week has games", "season", season, "week", week, "error", err)
@@ -136,61 +198,170 @@ func (s *SyncService) BackfillWeeks(ctx context.Context) {
 }
 
 // syncData performs a single synchronization cycle.
-func (s *SyncService) syncData(ctx context.Context) {
+func (s *SyncService) syncData(ctx context.Context) error {
 	slog.Info("Starting ESPN data sync")
 
+	// Refresh the season calendar once a day to pick up schedule changes
+	if s.timeProvider.Now().Sub(s.lastCalendarSync) > calendarSyncInterval {
+		season := s.CurrentSeason()
+		if err := s.SyncCalendar(ctx, season); err != nil {
+			slog.Warn("Failed to refresh season calendar", "season", season, "error", err)
+		}
+	}
+
 	// Get current season and week
 	currentSeason, currentWeek := s.getCurrentSeasonAndWeek()
 
 	// Sync data for current week
 	if err := s.SyncWeekData(ctx, currentSeason, currentWeek); err != nil {
-		slog.Error("Failed to sync week data", "season", currentSeason, "week", currentWeek, "error", err)
-		return
+		return fmt.Errorf("failed to sync week %d: %w", currentWeek, err)
 	}
 
 	slog.Info("ESPN data sync completed successfully")
+	return nil
 }
 
 // SyncWeekData syncs data for a specific week and season.
+// The outcome is recorded in the week's sync status, and the results and live scores
+// handlers are notified when the week's results or live scores changed.
 func (s *SyncService) SyncWeekData(ctx context.Context, season, week int) error {
 	slog.Info("Syncing week data", "season", season, "week", week)
 
+	results, resultsErr := s.weekResults(season, week)
+	scores, scoresErr := s.weekLiveScores(season, week)
+
+	attemptedAt := s.timeProvider.Now()
+	created, updated, err := s.syncWeek(ctx, season, week)
+	s.recordWeekSync(season, week, attemptedAt, created, updated, err)
+	if err != nil {
+		return err
+	}
+
+	if s.onResults != nil && weekChanged(results, resultsErr, func() (map[uint]string, error) { return s.weekResults(season, week) }) {
+		s.onResults(ctx, season, week)
+	}
+	if s.onLiveScores != nil && weekChanged(scores, scoresErr, func() (map[uint]string, error) { return s.weekLiveScores(season, week) }) {
+		s.onLiveScores(ctx, season, week)
+	}
+	return nil
+}
+
+// weekChanged reports whether a snapshot of a week taken before a sync differs from the
+// snapshot after it. A snapshot that fails to load counts as a change.
+func weekChanged(before map[uint]string, beforeErr error, snapshot func() (map[uint]string, error)) bool {
+	after, afterErr := snapshot()
+	return beforeErr != nil || afterErr != nil || !maps.Equal(before, after)
+}
+
+// weekLiveScores returns the state, score, quarter and clock of each game of a week, keyed
+// by game.
+func (s *SyncService) weekLiveScores(season, week int) (map[uint]string, error) {
+	var games []database.Game
+	if err := s.db.GetDB().Where("season = ? AND week = ?", season, week).Find(&games).Error; err != nil {
+		return nil, err
+	}
+
+	scores := make(map[uint]string, len(games))
+	for _, game := range games {
+		scores[game.ID] = fmt.Sprintf("%s %d-%d Q%d %s", game.State, game.HomeScore, game.AwayScore, game.Period, game.Clock)
+	}
+	return scores, nil
+}
+
+// weekResults returns the scores and outcome of each result of a week, keyed by game.
+func (s *SyncService) weekResults(season, week int) (map[uint]string, error) {
+	var results []database.Result
+	err := s.db.GetDB().
+		Joins("JOIN games ON games.id = results.game_id AND games.deleted_at IS NULL").
+		Where("games.season = ? AND games.week = ?", season, week).
+		Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[uint]string, len(results))
+	for _, result := range results {
+		scores[result.GameID] = fmt.Sprintf("%d-%d %s", result.FavoriteScore, result.UnderdogScore, result.Outcome)
+	}
+	return scores, nil
+}
+
+// syncWeek fetches and stores the events of a week, returning the number of games created and updated.
+func (s *SyncService) syncWeek(ctx context.Context, season, week int) (int, int, error) {
 	// Fetch events from ESPN API
 	events, err := s.fetchEvents(ctx, season, week)
 	if err != nil {
-		return err
+		return 0, 0, err
+	}
+
+	var before, after int64
+	if err := s.db.GetDB().Model(&database.Game{}).Where("season = ? AND week = ?", season, week).Count(&before).Error; err != nil {
+		return 0, 0, err
 	}
 
 	// Transform and store events
-	if err := s.transformAndStoreEvents(events, season, week); err != nil {
-		return err
+	stored, err := s.transformAndStoreEvents(events, season, week)
+	if err != nil {
+		return 0, 0, err
 	}
 
-	return nil
+	if err := s.db.GetDB().Model(&database.Game{}).Where("season = ? AND week = ?", season, week).Count(&after).Error; err != nil {
+		return 0, 0, err
+	}
+
+	created := int(after - before)
+	return created, stored - created, nil
 }
 
 // fetchEvents retrieves events from ESPN API for a specific season and week.
+// Weeks with games in progress change with every score, so they bypass the cache. Requests
+// are conditional on the previous response of the week, and a 304 Not Modified answer
+// reuses its events.
 func (s *SyncService) fetchEvents(ctx context.Context, season, week int) ([]apiespn.Event, error) {
 	slog.Info("Fetching events from ESPN API", "season", season, "week", week)
 
+	live, err := s.weekInProgress(season, week, s.timeProvider.Now())
+	if err != nil {
+		slog.Warn("Failed to check for games in progress", "season", season, "week", week, "error", err)
+	}
+
 	// Check cache first
-	if cachedEvents, found := s.cache.Get(season, week); found {
-		slog.Debug("Using cached events", "season", season, "week", week, "events", len(cachedEvents))
-		return cachedEvents, nil
+	if !live {
+		if cachedEvents, found := s.cache.Get(season, week); found {
+			slog.Debug("Using cached events", "season", season, "week", week, "events", len(cachedEvents))
+			s.storeFeed(season, week, cachedEvents, nil)
+			return cachedEvents, nil
+		}
 	}
 
 	// Fetch from ESPN Site API
-	// Note: seasontype 2 represents regular season in NFL
+	// ESPN numbers weeks within each season type, so postseason rounds restart at 1
+	seasonType, espnWeek := espnWeekForPoolWeek(week)
 	params := &apiespn.GetScoreboardParams{
-		Week:       &week,
-		Seasontype: func() *int { i := 2; return &i }(), // seasontype 2 = regular season
+		Week:       &espnWeek,
+		Seasontype: &seasonType,
+	}
+
+	feed, fetched := s.feed(season, week)
+	var editors []apiespn.RequestEditorFn
+	if fetched {
+		editors = append(editors, feed.conditional)
 	}
 
-	response, err := s.espnClient.GetScoreboardWithResponse(ctx, params)
+	response, err := s.espnClient.GetScoreboardWithResponse(ctx, params, editors...)
 	if err != nil {
 		return nil, err
 	}
 
+	if response.StatusCode() == http.StatusNotModified && fetched {
+		slog.Info("ESPN events not modified", "season", season, "week", week, "events", len(feed.events))
+		s.storeFeed(season, week, feed.events, nil)
+		if err := s.cache.Set(season, week, feed.events, cacheTTL(s.config, feed.events)); err != nil {
+			slog.Warn("Failed to cache events", "error", err)
+		}
+		return feed.events, nil
+	}
+
 	if response.StatusCode() != 200 {
 		return nil, fmt.Errorf("ESPN API returned status %d: %s", response.StatusCode(), response.Status())
 	}
@@ -215,9 +386,10 @@ func (s *SyncService) fetchEvents(ctx context.Context, season, week int) ([]apie
 	}
 
 	slog.Info("Fetched events from ESPN API", "season", season, "week", week, "events", len(events))
+	s.storeFeed(season, week, events, response.HTTPResponse.Header)
 
 	// Cache the results
-	if err := s.cache.Set(season, week, events); err != nil {
+	if err := s.cache.Set(season, week, events, cacheTTL(s.config, events)); err != nil {
 		slog.Warn("Failed to cache events", "error", err)
 	}
 
@@ -225,7 +397,8 @@ func (s *SyncService) fetchEvents(ctx context.Context, season, week int) ([]apie
 }
 
 // transformAndStoreEvents transforms ESPN events to database models and stores them.
-func (s *SyncService) transformAndStoreEvents(events []apiespn.Event, season, week int) error {
+// It returns the number of games stored.
+func (s *SyncService) transformAndStoreEvents(events []apiespn.Event, season, week int) (int, error) {
 	slog.Info("transformAndStoreEvents called", "count", len(events), "season", season, "week", week)
 
 	// Deduplicate events by ID to handle duplicate events in the API response
@@ -280,18 +453,40 @@ func (s *SyncService) transformAndStoreEvents(events []apiespn.Event, season, we
 	}
 
 	slog.Info("Completed transforming and storing events", "total_events", len(events), "unique_events", len(seenEvents), "processed", processedCount)
-	return nil
+	return processedCount, nil
 }
 
 // getCurrentSeasonAndWeek returns the current NFL season and week.
-// This uses the configured season year and calculates the week based on the
-// configured Week 1 date, accounting for weeks ending on Monday.
+// The week is read from the week boundaries synced from the ESPN calendar. If the
+// calendar has not been synced, it is estimated from the configured Week 1 date.
 func (s *SyncService) getCurrentSeasonAndWeek() (int, int) {
 	currentTime := s.timeProvider.Now()
 
-	// Use configured season year
-	season := s.config.ESPN.SeasonYear
+	season := s.CurrentSeason()
+
+	week, err := s.db.GetWeekAt(season, currentTime)
+	if err == nil {
+		return season, week.WeekNumber
+	}
+	slog.Debug("No synced week found, estimating from Week 1 date", "season", season, "error", err)
+
+	return season, s.estimateWeek(currentTime)
+}
 
+// CurrentSeason returns the season being played or prepared, falling back to the
+// configured season year when seasons cannot be read.
+func (s *SyncService) CurrentSeason() int {
+	season, err := database.CurrentSeason(s.db.GetDB(), s.config.ESPN.SeasonYear)
+	if err != nil {
+		slog.Warn("Failed to load current season, using the configured season", "season", s.config.ESPN.SeasonYear, "error", err)
+		return s.config.ESPN.SeasonYear
+	}
+	return season
+}
+
+// estimateWeek calculates the week based on the configured Week 1 date,
+// accounting for weeks ending on Monday.
+func (s *SyncService) estimateWeek(currentTime time.Time) int {
 	// Calculate week based on days since Week 1 date
 	// NFL Week 1 starts with the first game (typically Thursday)
 	// Weeks run from the first game through the following Monday
@@ -299,28 +494,53 @@ func (s *SyncService) getCurrentSeasonAndWeek() (int, int) {
 
 	// If we're before Week 1 first game, we're in Week 0 (preseason)
 	if daysSinceWeek1 < 0 {
-		return season, 0
+		return 0
 	}
 
 	// Simple calculation: week = (days since first game) / 7 + 1
 	// This works because each week is exactly 7 days from the first game to the following Monday
 	week := (daysSinceWeek1 / 7) + 1
 
+	// The Super Bowl is played two weeks after the conference championships
+	if week > database.ConferenceWeek {
+		week = database.SuperBowlWeek
+	}
+
 	// Ensure week is within reasonable bounds
 	if week < 1 {
 		week = 1
 	}
-	if week > 18 { // NFL regular season is 18 weeks
-		week = 18
+	if week > s.lastWeek() {
+		week = s.lastWeek()
 	}
 
-	return season, week
+	return week
 }
 
-// SyncNow performs an immediate synchronization.
+// lastWeek returns the last pool week that should be synced for the configured playoff mode.
+func (s *SyncService) lastWeek() int {
+	if s.config.Pool.PlayoffMode == config.PlayoffModeNone {
+		return database.LastRegularSeasonWeek
+	}
+	return database.SuperBowlWeek
+}
+
+// espnWeekForPoolWeek converts a pool week number to the ESPN season type and week number.
+// Postseason rounds are numbered 1-5 by ESPN, with the Pro Bowl as round 4.
+func espnWeekForPoolWeek(week int) (int, int) {
+	switch {
+	case week == database.SuperBowlWeek:
+		return database.SeasonTypePostseason, 5
+	case week > database.LastRegularSeasonWeek:
+		return database.SeasonTypePostseason, week - database.LastRegularSeasonWeek
+	default:
+		return database.SeasonTypeRegular, week
+	}
+}
+
+// SyncNow performs an immediate synchronization of the current week.
 func (s *SyncService) SyncNow(ctx context.Context) error {
-	s.syncData(ctx)
-	return nil
+	return s.syncData(ctx)
 }
 
 // CheckAndUpdateSpreads checks games for the current and past week and updates spreads if needed.
@@ -344,7 +564,9 @@ func (s *SyncService) CheckAndUpdateSpreads(ctx context.Context) {
 
 		if allSpreadsZero {
 			slog.Info("Updating spreads for week", "season", currentSeason, "week", week)
-			if err := s.oddsService.UpdateGameSpreads(ctx, currentSeason, week); err != nil {
+			if err := s.oddsService.UpdateGameSpreads(ctx, currentSeason, week, oddssync.PriorityRoutine); errors.Is(err, oddssync.ErrBudgetReached) || errors.Is(err, oddssync.ErrManualSpreads) {
+				slog.Warn("Skipping spread update", "season", currentSeason, "week", week, "reason", err)
+			} else if err != nil {
 				slog.Error("Failed to update spreads", "season", currentSeason, "week", week, "error", err)
 			}
 		} else {
@@ -373,77 +595,26 @@ func (s *SyncService) allSpreadsZero(season, week int) (bool, error) {
 	return true, nil // All spreads are 0
 }
 
-// StartWeeklySpreadUpdates starts a background process that updates spreads every Monday at 11pm EST.
-func (s *SyncService) StartWeeklySpreadUpdates(ctx context.Context) {
-	slog.Info("Starting weekly spread updates (Monday 11pm EST)")
-
-	for {
-		select {
-		case <-ctx.Done():
-			slog.Info("Stopping weekly spread updates")
-			return
-		default:
-			// Calculate next Monday 11pm EST
-			nextUpdate := s.nextMonday11pmEST()
-			durationUntilNextUpdate := time.Until(nextUpdate)
-
-			slog.Info("Next spread update scheduled", "time", nextUpdate, "duration", durationUntilNextUpdate)
-
-			// Wait until next Monday 11pm EST
-			timer := time.NewTimer(durationUntilNextUpdate)
-			select {
-			case <-ctx.Done():
-				timer.Stop()
-				return
-			case <-timer.C:
-				// Update spreads for upcoming week
-				currentSeason, currentWeek := s.getCurrentSeasonAndWeek()
-				upcomingWeek := currentWeek + 1
-
-				// Only update if upcoming week is within the season (1-18)
-				if upcomingWeek >= 1 && upcomingWeek <= 18 {
-					slog.Info("Updating spreads for upcoming week", "season", currentSeason, "week", upcomingWeek)
-					if err := s.oddsService.UpdateGameSpreads(ctx, currentSeason, upcomingWeek); err != nil {
-						slog.Error("Failed to update spreads for upcoming week", "season", currentSeason, "week", upcomingWeek, "error", err)
-					}
-				} else {
-					slog.Info("Upcoming week is outside season range, skipping spread update", "week", upcomingWeek)
-				}
-			}
-		}
-	}
-}
-
-// nextMonday11pmEST calculates the next Monday at 11pm EST.
-func (s *SyncService) nextMonday11pmEST() time.Time {
-	now := s.timeProvider.Now()
+// UpdateUpcomingWeekSpreads updates spreads for the week after the current week.
+// This is the weekly fetch that sets the lines for the pool, so it ignores the Odds API budget floor.
+func (s *SyncService) UpdateUpcomingWeekSpreads(ctx context.Context) error {
+	currentSeason, currentWeek := s.getCurrentSeasonAndWeek()
+	upcomingWeek := currentWeek + 1
 
-	// Convert to EST (UTC-5)
-	estLocation, err := time.LoadLocation("America/New_York")
-	if err != nil {
-		slog.Warn("Failed to load EST timezone, using UTC", "error", err)
-		estLocation = time.UTC
+	// Only update if upcoming week is within the season
+	if upcomingWeek < 1 || upcomingWeek > s.lastWeek() {
+		slog.Info("Upcoming week is outside season range, skipping spread update", "week", upcomingWeek)
+		return nil
 	}
 
-	nowEST := now.In(estLocation)
-
-	// Find next Monday
-	daysUntilMonday := (1 - int(nowEST.Weekday()) + 7) % 7
-	if daysUntilMonday == 0 && nowEST.Hour() >= 23 {
-		// If it's already Monday after 11pm, schedule for next Monday
-		daysUntilMonday = 7
+	slog.Info("Updating spreads for upcoming week", "season", currentSeason, "week", upcomingWeek)
+	err := s.oddsService.UpdateGameSpreads(ctx, currentSeason, upcomingWeek, oddssync.PriorityEssential)
+	if errors.Is(err, oddssync.ErrManualSpreads) {
+		slog.Info("Skipping spread update", "season", currentSeason, "week", upcomingWeek, "reason", err)
+		return nil
 	}
-
-	nextMonday := nowEST.AddDate(0, 0, daysUntilMonday)
-	nextMonday11pm := time.Date(nextMonday.Year(), nextMonday.Month(), nextMonday.Day(), 23, 0, 0, 0, estLocation)
-
-	return nextMonday11pm
-}
-
-// GetSyncStatus returns the current status of the sync service.
-func (s *SyncService) GetSyncStatus() map[string]interface{} {
-	return map[string]interface{}{
-		"enabled":   s.syncEnabled,
-		"last_sync": time.Now(), // TODO: Track actual last sync time
+	if err != nil {
+		return fmt.Errorf("failed to update spreads for week %d: %w", upcomingWeek, err)
 	}
+	return nil
 }
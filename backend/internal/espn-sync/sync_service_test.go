@@ -6,6 +6,7 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -14,6 +15,7 @@ import (
 	apiespn "github.com/dhpollack/football-pool/internal/api-espn"
 	"github.com/dhpollack/football-pool/internal/config"
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/jobs"
 )
 
 const testAPIEspnCom = "https://test.api.espn.com"
@@ -52,7 +54,7 @@ func (m MockTimeProvider) Now() time.Time {
 	return time.Now()
 }
 
-func TestSyncService_StartDisabled(t *testing.T) {
+func TestSyncService_RegisterJobsDisabled(t *testing.T) {
 	db, err := database.New("sqlite", ":memory:")
 	if err != nil {
 		t.Fatalf("Failed to create database: %v", err)
@@ -65,17 +67,54 @@ func TestSyncService_StartDisabled(t *testing.T) {
 		t.Fatalf("NewSyncService() error = %v", err)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// No jobs are registered when sync is disabled
+	scheduler := jobs.NewScheduler(db, config)
+	if err := service.RegisterJobs(scheduler); err != nil {
+		t.Fatalf("RegisterJobs() error = %v", err)
+	}
+
+	registered, err := scheduler.Jobs()
+	if err != nil {
+		t.Fatalf("Jobs() error = %v", err)
+	}
+	if len(registered) != 0 {
+		t.Errorf("Expected no jobs, got %d", len(registered))
+	}
+}
 
-	// Start should return immediately when disabled
-	go service.Start(ctx, time.Hour)
+func TestSyncService_RegisterJobs(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
 
-	// Give it a moment to start
-	time.Sleep(100 * time.Millisecond)
+	config := testConfig(t)
+	config.ESPN.SyncEnabled = true
+
+	service, err := NewSyncService(db, config)
+	if err != nil {
+		t.Fatalf("NewSyncService() error = %v", err)
+	}
+
+	scheduler := jobs.NewScheduler(db, config)
+	if err := service.RegisterJobs(scheduler); err != nil {
+		t.Fatalf("RegisterJobs() error = %v", err)
+	}
 
-	// Service should not be running any sync operations
-	// This test mainly verifies that the service doesn't crash when disabled
+	registered, err := scheduler.Jobs()
+	if err != nil {
+		t.Fatalf("Jobs() error = %v", err)
+	}
+
+	expected := []string{JobESPNSync, JobBackfillWeeks, JobCheckSpreads, JobWeeklySpreads}
+	if len(registered) != len(expected) {
+		t.Fatalf("Expected %d jobs, got %d", len(expected), len(registered))
+	}
+	for i, job := range registered {
+		if job.Name != expected[i] {
+			t.Errorf("Job %d = %s, want %s", i, job.Name, expected[i])
+		}
+	}
 }
 
 func TestSyncService_SyncNow(t *testing.T) {
@@ -211,10 +250,9 @@ func TestSyncService_SyncNowAPIError(t *testing.T) {
 	service.espnClient = client
 
 	ctx := context.Background()
-	err = service.SyncNow(ctx)
-	// The sync service should handle API errors gracefully and return nil
-	if err != nil {
-		t.Errorf("SyncNow() unexpected error: %v", err)
+	// The failure is reported to the caller
+	if err := service.SyncNow(ctx); err == nil {
+		t.Error("SyncNow() expected an error")
 	}
 }
 
@@ -244,6 +282,16 @@ func TestSyncService_GetCurrentSeasonAndWeek(t *testing.T) {
 			now:          time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC), // October 15
 			expectedWeek: 6,
 		},
+		{
+			name:         "Wild Card",
+			now:          time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC), // January 13
+			expectedWeek: database.WildCardWeek,
+		},
+		{
+			name:         "Super Bowl",
+			now:          time.Date(2024, 2, 11, 0, 0, 0, 0, time.UTC), // February 11
+			expectedWeek: database.SuperBowlWeek,
+		},
 	}
 
 	for _, tt := range tests {
@@ -278,22 +326,117 @@ func TestSyncService_GetSyncStatus(t *testing.T) {
 		t.Fatalf("Failed to create database: %v", err)
 	}
 
+	// The first request fails, later requests return a single game
+	requests := 0
+	mockHTTPClient := &MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			requests++
+			if requests == 1 {
+				return &http.Response{
+					StatusCode: 503,
+					Body:       io.NopCloser(strings.NewReader("Service Unavailable")),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body: io.NopCloser(strings.NewReader(`{
+					"events": [{
+						"id": "event1",
+						"name": "Test Game",
+						"date": "2025-09-05T00:20Z",
+						"competitions": [{
+							"competitors": [{
+								"homeAway": "home",
+								"team": {"displayName": "Team A"}
+							}, {
+								"homeAway": "away",
+								"team": {"displayName": "Team B"}
+							}]
+						}]
+					}]
+				}`)),
+			}, nil
+		},
+	}
+
 	config := testConfig(t)
 	config.ESPN.SyncEnabled = true
 
-	service, err := NewSyncService(db, config)
+	client, err := apiespn.NewClientWithResponses(config.ESPN.BaseURL, apiespn.WithHTTPClient(mockHTTPClient))
 	if err != nil {
-		t.Fatalf("NewSyncService() error = %v", err)
+		t.Fatalf("Failed to create ESPN client: %v", err)
+	}
+
+	now := time.Date(2025, 9, 4, 12, 0, 0, 0, time.UTC)
+	service, err := NewSyncServiceWithTimeProvider(db, config, MockTimeProvider{
+		NowFunc: func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewSyncServiceWithTimeProvider() error = %v", err)
+	}
+	service.espnClient = client
+
+	if err := service.RegisterJobs(jobs.NewSchedulerWithTimeProvider(db, config, service.timeProvider)); err != nil {
+		t.Fatalf("RegisterJobs() error = %v", err)
 	}
 
-	status := service.GetSyncStatus()
+	ctx := context.Background()
+	if err := service.RefreshWeek(ctx, 2025, 1); err == nil {
+		t.Fatal("RefreshWeek() expected error")
+	}
 
-	if enabled, ok := status["enabled"].(bool); !ok || !enabled {
+	status, err := service.GetSyncStatus(2025)
+	if err != nil {
+		t.Fatalf("GetSyncStatus() error = %v", err)
+	}
+	if !status.Enabled {
 		t.Error("GetSyncStatus() enabled should be true")
 	}
+	if status.LastAttemptAt == nil || !status.LastAttemptAt.Equal(now) {
+		t.Errorf("GetSyncStatus() last attempt = %v, want %v", status.LastAttemptAt, now)
+	}
+	if status.LastSuccessAt != nil {
+		t.Errorf("GetSyncStatus() last success = %v, want nil", status.LastSuccessAt)
+	}
+	if status.LastError == "" {
+		t.Error("GetSyncStatus() last error should be set")
+	}
+	if status.NextRunAt == nil || !status.NextRunAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("GetSyncStatus() next run = %v, want %v", status.NextRunAt, now.Add(time.Hour))
+	}
 
-	if _, ok := status["last_sync"].(time.Time); !ok {
-		t.Error("GetSyncStatus() last_sync should be a time.Time")
+	// A successful sync creates the game and clears the error
+	now = now.Add(time.Minute)
+	if err := service.RefreshWeek(ctx, 2025, 1); err != nil {
+		t.Fatalf("RefreshWeek() error = %v", err)
+	}
+
+	week, err := service.GetWeekSyncStatus(2025, 1)
+	if err != nil {
+		t.Fatalf("GetWeekSyncStatus() error = %v", err)
+	}
+	if week.GamesCreated != 1 || week.GamesUpdated != 0 || week.LastError != "" || week.LastSuccessAt == nil || !week.LastSuccessAt.Equal(now) {
+		t.Errorf("Unexpected week sync status after first sync: %+v", week)
+	}
+
+	// Syncing again updates the existing game
+	if err := service.RefreshWeek(ctx, 2025, 1); err != nil {
+		t.Fatalf("RefreshWeek() error = %v", err)
+	}
+
+	status, err = service.GetSyncStatus(2025)
+	if err != nil {
+		t.Fatalf("GetSyncStatus() error = %v", err)
+	}
+	if len(status.Weeks) != 1 || status.Weeks[0].GamesCreated != 0 || status.Weeks[0].GamesUpdated != 1 {
+		t.Errorf("Unexpected week sync status after second sync: %+v", status.Weeks)
+	}
+	if status.LastError != "" || status.LastSuccessAt == nil {
+		t.Errorf("Expected latest sync to have succeeded, got error %q", status.LastError)
+	}
+	if requests != 3 {
+		t.Errorf("Expected refreshes to bypass the cache, got %d requests", requests)
 	}
 }
 
@@ -315,8 +458,9 @@ func TestSyncService_BackfillWeeks(t *testing.T) {
 	syncedWeeks := make(map[int]bool)
 	mockHTTPClient := &MockHTTPClient{
 		DoFunc: func(req *http.Request) (*http.Response, error) {
+			// Postseason rounds restart ESPN's week numbering, so only track the regular season
 			weekStr := req.URL.Query().Get("week")
-			if weekStr != "" {
+			if weekStr != "" && req.URL.Query().Get("seasontype") == "2" {
 				var week int
 				fmt.Sscanf(weekStr, "%d", &week)
 				syncedWeeks[week] = true
@@ -361,6 +505,281 @@ func TestSyncService_BackfillWeeks(t *testing.T) {
 	}
 }
 
+func TestSyncService_SyncPostseasonWeek(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	var requestedSeasonType, requestedWeek string
+	mockHTTPClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requestedSeasonType = req.URL.Query().Get("seasontype")
+			requestedWeek = req.URL.Query().Get("week")
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body: io.NopCloser(strings.NewReader(`{
+					"events": [{
+						"id": "playoff-` + requestedWeek + `",
+						"name": "Playoff Game",
+						"date": "2026-01-10T21:30Z",
+						"season": {"type": 3, "year": 2025},
+						"competitions": [{
+							"competitors": [{
+								"homeAway": "home",
+								"team": {"displayName": "Team A"}
+							}, {
+								"homeAway": "away",
+								"team": {"displayName": "Team B"}
+							}]
+						}]
+					}]
+				}`)),
+			}, nil
+		},
+	}
+
+	config := testConfig(t)
+	client, err := apiespn.NewClientWithResponses(config.ESPN.BaseURL, apiespn.WithHTTPClient(mockHTTPClient))
+	if err != nil {
+		t.Fatalf("Failed to create ESPN client: %v", err)
+	}
+
+	service, err := NewSyncService(db, config)
+	if err != nil {
+		t.Fatalf("NewSyncService() error = %v", err)
+	}
+	service.espnClient = client
+
+	tests := []struct {
+		week               int
+		expectedSeasonType string
+		expectedESPNWeek   string
+	}{
+		{week: database.WildCardWeek, expectedSeasonType: "3", expectedESPNWeek: "1"},
+		{week: database.ConferenceWeek, expectedSeasonType: "3", expectedESPNWeek: "3"},
+		{week: database.SuperBowlWeek, expectedSeasonType: "3", expectedESPNWeek: "5"},
+	}
+
+	for _, tt := range tests {
+		if err := service.SyncWeekData(context.Background(), 2025, tt.week); err != nil {
+			t.Fatalf("SyncWeekData(%d) error = %v", tt.week, err)
+		}
+
+		if requestedSeasonType != tt.expectedSeasonType || requestedWeek != tt.expectedESPNWeek {
+			t.Errorf("week %d requested seasontype=%s week=%s, want seasontype=%s week=%s",
+				tt.week, requestedSeasonType, requestedWeek, tt.expectedSeasonType, tt.expectedESPNWeek)
+		}
+
+		var game database.Game
+		if err := db.GetDB().Where("season = ? AND week = ?", 2025, tt.week).First(&game).Error; err != nil {
+			t.Fatalf("Failed to find game for week %d: %v", tt.week, err)
+		}
+		if game.SeasonType != database.SeasonTypePostseason {
+			t.Errorf("week %d game season type = %d, want %d", tt.week, game.SeasonType, database.SeasonTypePostseason)
+		}
+	}
+}
+
+func TestSyncService_NotifiesChangedResults(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	homeScore := "7"
+	mockHTTPClient := &MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body: io.NopCloser(strings.NewReader(`{
+					"events": [{
+						"id": "401772510",
+						"name": "Team B at Team A",
+						"date": "2025-09-07T17:00Z",
+						"season": {"type": 2, "year": 2025},
+						"competitions": [{
+							"competitors": [{
+								"homeAway": "home",
+								"score": "` + homeScore + `",
+								"team": {"displayName": "Team A"}
+							}, {
+								"homeAway": "away",
+								"score": "3",
+								"team": {"displayName": "Team B"}
+							}]
+						}]
+					}]
+				}`)),
+			}, nil
+		},
+	}
+
+	config := testConfig(t)
+	config.ESPN.CacheDir = t.TempDir()
+	client, err := apiespn.NewClientWithResponses(config.ESPN.BaseURL, apiespn.WithHTTPClient(mockHTTPClient))
+	if err != nil {
+		t.Fatalf("Failed to create ESPN client: %v", err)
+	}
+
+	service, err := NewSyncService(db, config)
+	if err != nil {
+		t.Fatalf("NewSyncService() error = %v", err)
+	}
+	service.espnClient = client
+
+	var notified []int
+	service.OnResults(func(_ context.Context, season, week int) {
+		notified = append(notified, week)
+	})
+
+	// The first sync stores the result, an identical sync leaves it alone and a new score changes it
+	for _, score := range []string{"7", "7", "14"} {
+		homeScore = score
+		if err := service.cache.ClearAll(); err != nil {
+			t.Fatalf("Failed to clear cache: %v", err)
+		}
+		if err := service.SyncWeekData(context.Background(), 2025, 1); err != nil {
+			t.Fatalf("SyncWeekData() error = %v", err)
+		}
+	}
+
+	if len(notified) != 2 || notified[0] != 1 || notified[1] != 1 {
+		t.Errorf("Expected two notifications for week 1, got %v", notified)
+	}
+}
+
+func TestSyncService_KeepsLiveScoresOnGames(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	status := `{"period": 2, "displayClock": "4:12", "type": {"state": "in", "completed": false}}`
+	mockHTTPClient := &MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body: io.NopCloser(strings.NewReader(`{
+					"events": [{
+						"id": "401772510",
+						"name": "Team B at Team A",
+						"date": "2025-09-07T17:00Z",
+						"season": {"type": 2, "year": 2025},
+						"status": ` + status + `,
+						"competitions": [{
+							"competitors": [{
+								"homeAway": "home",
+								"score": "10",
+								"team": {"displayName": "Team A"}
+							}, {
+								"homeAway": "away",
+								"score": "3",
+								"team": {"displayName": "Team B"}
+							}]
+						}]
+					}]
+				}`)),
+			}, nil
+		},
+	}
+
+	config := testConfig(t)
+	config.ESPN.CacheDir = t.TempDir()
+	client, err := apiespn.NewClientWithResponses(config.ESPN.BaseURL, apiespn.WithHTTPClient(mockHTTPClient))
+	if err != nil {
+		t.Fatalf("Failed to create ESPN client: %v", err)
+	}
+
+	service, err := NewSyncService(db, config)
+	if err != nil {
+		t.Fatalf("NewSyncService() error = %v", err)
+	}
+	service.espnClient = client
+
+	var results, liveScores int
+	service.OnResults(func(_ context.Context, _, _ int) { results++ })
+	service.OnLiveScores(func(_ context.Context, _, _ int) { liveScores++ })
+
+	if err := service.SyncWeekData(context.Background(), 2025, 1); err != nil {
+		t.Fatalf("SyncWeekData() error = %v", err)
+	}
+
+	// The score of a game in progress is kept on the game without a result
+	var game database.Game
+	if err := db.GetDB().First(&game).Error; err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if game.State != database.GameStateInProgress || game.HomeScore != 10 || game.AwayScore != 3 || game.Period != 2 || game.Clock != "4:12" {
+		t.Errorf("Unexpected live game: %+v", game)
+	}
+	var count int64
+	db.GetDB().Model(&database.Result{}).Count(&count)
+	if count != 0 || results != 0 || liveScores != 1 {
+		t.Errorf("Expected a live score notification and no result, got %d results stored, %d result notifications and %d live score notifications", count, results, liveScores)
+	}
+
+	// The result is stored once the game is over
+	status = `{"period": 4, "displayClock": "0:00", "type": {"state": "post", "completed": true}}`
+	if err := service.cache.ClearAll(); err != nil {
+		t.Fatalf("Failed to clear cache: %v", err)
+	}
+	if err := service.SyncWeekData(context.Background(), 2025, 1); err != nil {
+		t.Fatalf("SyncWeekData() error = %v", err)
+	}
+	db.GetDB().Model(&database.Result{}).Count(&count)
+	if count != 1 || results != 1 || liveScores != 2 {
+		t.Errorf("Expected the final result, got %d results stored, %d result notifications and %d live score notifications", count, results, liveScores)
+	}
+}
+
+func TestSyncService_BackfillWeeksWithoutPlayoffs(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	syncedWeeks := make(map[string]bool)
+	mockHTTPClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			syncedWeeks[req.URL.Query().Get("seasontype")+"-"+req.URL.Query().Get("week")] = true
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader("{}")),
+			}, nil
+		},
+	}
+
+	config := testConfig(t)
+	config.Pool.PlayoffMode = "none"
+
+	client, err := apiespn.NewClientWithResponses(config.ESPN.BaseURL, apiespn.WithHTTPClient(mockHTTPClient))
+	if err != nil {
+		t.Fatalf("Failed to create ESPN client: %v", err)
+	}
+
+	service, err := NewSyncService(db, config)
+	if err != nil {
+		t.Fatalf("NewSyncService() error = %v", err)
+	}
+	service.espnClient = client
+
+	service.BackfillWeeks(context.Background())
+
+	if len(syncedWeeks) != database.LastRegularSeasonWeek {
+		t.Errorf("Expected %d weeks to be synced, got %d", database.LastRegularSeasonWeek, len(syncedWeeks))
+	}
+	for key := range syncedWeeks {
+		if strings.HasPrefix(key, "3-") {
+			t.Errorf("Expected no postseason sync when playoffs are disabled, got %s", key)
+		}
+	}
+}
+
 func TestSyncService_SyncNowWithSampleData(t *testing.T) {
 	// Enable debug logging
 	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})
@@ -498,3 +917,64 @@ func TestSyncService_SyncNowWithSampleData(t *testing.T) {
 
 	t.Logf("Successfully stored %d games and %d results from sample data", len(games), len(results))
 }
+
+func TestSyncService_ReplaysFixtures(t *testing.T) {
+	sampleData, err := os.ReadFile("../../assets/test/scoreboard-sample.json")
+	if err != nil {
+		t.Fatalf("Failed to read sample data file: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(sampleData)
+	}))
+
+	cfg := testConfig(t)
+	cfg.ESPN.BaseURL = server.URL
+	cfg.Upstream.FixturesDir = t.TempDir()
+	mockTimeProvider := MockTimeProvider{
+		NowFunc: func() time.Time {
+			return time.Date(2025, 9, 5, 0, 0, 0, 0, time.UTC)
+		},
+	}
+
+	// syncWeek syncs the first week into a new database using the configured fixture mode
+	syncWeek := func(mode string) []database.Game {
+		db, err := database.New("sqlite", ":memory:")
+		if err != nil {
+			t.Fatalf("Failed to create database: %v", err)
+		}
+		cfg.Upstream.FixtureMode = mode
+		cfg.ESPN.CacheDir = t.TempDir()
+
+		service, err := NewSyncServiceWithTimeProvider(db, cfg, mockTimeProvider)
+		if err != nil {
+			t.Fatalf("NewSyncServiceWithTimeProvider() error = %v", err)
+		}
+		if err := service.SyncWeekData(context.Background(), 2025, 1); err != nil {
+			t.Fatalf("SyncWeekData() error = %v", err)
+		}
+
+		var games []database.Game
+		if err := db.GetDB().Order("espn_event_id").Find(&games).Error; err != nil {
+			t.Fatalf("Failed to query games: %v", err)
+		}
+		return games
+	}
+
+	recorded := syncWeek(config.FixtureModeRecord)
+	if len(recorded) == 0 {
+		t.Fatal("Expected games to be synced while recording")
+	}
+
+	// The replay serves the recorded scoreboard without the network
+	server.Close()
+	replayed := syncWeek(config.FixtureModeReplay)
+	if len(replayed) != len(recorded) {
+		t.Fatalf("Expected %d replayed games, got %d", len(recorded), len(replayed))
+	}
+	for i := range replayed {
+		if replayed[i].ESPNEventID != recorded[i].ESPNEventID || !replayed[i].StartTime.Equal(recorded[i].StartTime) {
+			t.Errorf("Replayed game %d = %+v, want %+v", i, replayed[i], recorded[i])
+		}
+	}
+}
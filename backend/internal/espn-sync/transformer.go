@@ -2,23 +2,35 @@
 package espnsync
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
 	"time"
 
 	apiespn "github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/clock"
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Transformer handles the transformation of ESPN API data to database models.
 type Transformer struct {
-	db *database.Database
+	db           *database.Database
+	timeProvider TimeProvider
 }
 
 // NewTransformer creates a new Transformer instance.
 func NewTransformer(db *database.Database) *Transformer {
-	return &Transformer{db: db}
+	return NewTransformerWithTimeProvider(db, clock.Real{})
+}
+
+// NewTransformerWithTimeProvider creates a new Transformer instance that records schedule
+// changes at the time of the given clock.
+func NewTransformerWithTimeProvider(db *database.Database, timeProvider TimeProvider) *Transformer {
+	return &Transformer{db: db, timeProvider: timeProvider}
 }
 
 // TransformEvent transforms an ESPN Event to database Game and Result models.
@@ -41,22 +53,35 @@ func (t *Transformer) TransformEvent(event apiespn.Event, season, week int) (*da
 		return nil, nil, err
 	}
 
+	// Register the teams so the game can reference them
+	homeTeamID, awayTeamID := t.registerTeams(competition)
+
 	// Create Game model
 	game := &database.Game{
-		Week:      week,
-		Season:    season,
-		HomeTeam:  homeTeam,
-		AwayTeam:  awayTeam,
-		Favorite:  nil,
-		Underdog:  nil,
-		Spread:    0.0, // ESPN API doesn't provide spread information
-		StartTime: startTime,
+		Week:       week,
+		Season:     season,
+		SeasonType: database.SeasonTypeForWeek(week),
+		HomeTeam:   homeTeam,
+		AwayTeam:   awayTeam,
+		Favorite:   nil,
+		Underdog:   nil,
+		Spread:     0.0, // ESPN API doesn't provide spread information
+		StartTime:  startTime,
+		HomeTeamID: homeTeamID,
+		AwayTeamID: awayTeamID,
+
+		ESPNEventID: stringValue(event.Id),
 	}
+	if competition.Venue != nil {
+		game.Venue = stringValue(competition.Venue.FullName)
+	}
+	t.extractLiveScore(competition, event, game)
 
-	// Create Result model if scores are available
+	// Create Result model if scores are available. The score of a game in progress is
+	// provisional, so it is only kept on the game
 	var result *database.Result
-	if competition.Competitors != nil && len(*competition.Competitors) == 2 {
-		result = t.extractResult(competition)
+	if competition.Competitors != nil && len(*competition.Competitors) == 2 && game.State != database.GameStateInProgress {
+		result = t.extractResult(game)
 	}
 
 	return game, result, nil
@@ -91,6 +116,48 @@ func (t *Transformer) extractTeams(competition apiespn.Competition) (string, str
 	return homeTeam, awayTeam, nil
 }
 
+// registerTeams creates or updates the competition's teams, returning the IDs of the
+// home and away teams. Teams without an ESPN ID are not registered and have no ID.
+func (t *Transformer) registerTeams(competition apiespn.Competition) (*uint, *uint) {
+	var homeTeamID, awayTeamID *uint
+	for _, competitor := range *competition.Competitors {
+		espnTeam := competitor.Team
+		if espnTeam == nil || espnTeam.Id == nil || espnTeam.DisplayName == nil {
+			continue
+		}
+
+		team := database.Team{
+			ESPNID:         *espnTeam.Id,
+			DisplayName:    *espnTeam.DisplayName,
+			Abbreviation:   stringValue(espnTeam.Abbreviation),
+			Location:       stringValue(espnTeam.Location),
+			Name:           stringValue(espnTeam.Name),
+			Color:          stringValue(espnTeam.Color),
+			AlternateColor: stringValue(espnTeam.AlternateColor),
+			LogoURL:        stringValue(espnTeam.Logo),
+		}
+		if err := t.db.UpsertTeam(&team); err != nil {
+			slog.Warn("Failed to register team", "espn_id", team.ESPNID, "team", team.DisplayName, "error", err)
+			continue
+		}
+
+		if competitor.HomeAway != nil && *competitor.HomeAway == "home" {
+			homeTeamID = &team.ID
+		} else {
+			awayTeamID = &team.ID
+		}
+	}
+	return homeTeamID, awayTeamID
+}
+
+// stringValue returns the value of an optional string, or the empty string.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // extractStartTime extracts the game start time from competition and event data.
 func (t *Transformer) extractStartTime(competition apiespn.Competition, event apiespn.Event) (time.Time, error) {
 	// Try competition date first
@@ -107,92 +174,203 @@ func (t *Transformer) extractStartTime(competition apiespn.Competition, event ap
 	return time.Time{}, fmt.Errorf("unable to determine the start date from competition or event")
 }
 
-// extractResult extracts game result from competition data.
-func (t *Transformer) extractResult(competition apiespn.Competition) *database.Result {
-	if competition.Competitors == nil || len(*competition.Competitors) != 2 {
+// extractLiveScore records the state ESPN reports for an event on its game, with the score,
+// quarter and game clock.
+func (t *Transformer) extractLiveScore(competition apiespn.Competition, event apiespn.Event, game *database.Game) {
+	game.State = eventState(event)
+	if event.Status != nil {
+		if event.Status.Period != nil {
+			game.Period = *event.Status.Period
+		}
+		game.Clock = stringValue(event.Status.DisplayClock)
+	}
+
+	if competition.Competitors == nil {
+		return
+	}
+	for _, competitor := range *competition.Competitors {
+		if competitor.Score == nil || *competitor.Score == "" {
+			continue
+		}
+		score, _ := strconv.Atoi(*competitor.Score)
+		if competitor.HomeAway != nil && *competitor.HomeAway == "home" {
+			game.HomeScore = score
+		} else {
+			game.AwayScore = score
+		}
+	}
+}
+
+// extractResult returns the result of a game at the score ESPN reports on it, or nil before
+// either team has scored. The scores are oriented by the game's favorite and the outcome is
+// graded against its spread, as the live standings grade the game while it is in progress.
+func (t *Transformer) extractResult(game *database.Game) *database.Result {
+	if game.HomeScore == 0 && game.AwayScore == 0 {
+		slog.Debug("No score available - returning nil result")
 		return nil
 	}
 
-	competitors := *competition.Competitors
-	var favoriteScore, underdogScore int
-	var outcome string
+	result := scoring.GameResult(*game)
+	slog.Debug("Result created", "favoriteScore", result.FavoriteScore, "underdogScore", result.UnderdogScore, "outcome", result.Outcome)
+	return &result
+}
 
-	// Debug: log competitor details
-	slog.Debug("Extracting result from competition", "competitors_count", len(competitors))
+// errGameDeleted is returned when a synced event belongs to a game deleted by an admin.
+var errGameDeleted = errors.New("game was deleted")
 
-	// Extract scores and determine outcome
-	for i, competitor := range competitors {
-		if competitor.Score == nil {
-			slog.Debug("Competitor has no score", "index", i, "homeAway", competitor.HomeAway)
-			continue
+// findExistingGame returns the stored game for a synced game, gorm.ErrRecordNotFound, or
+// errGameDeleted when an admin has deleted the game.
+func (t *Transformer) findExistingGame(game *database.Game) (*database.Game, error) {
+	if game.ESPNEventID != "" {
+		existing, err := t.db.GetGameByESPNEventID(game.ESPNEventID)
+		if err == nil && existing.DeletedAt.Valid {
+			return nil, errGameDeleted
 		}
-
-		score := 0
-		if *competitor.Score != "" {
-			score, _ = strconv.Atoi(*competitor.Score)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return existing, err
 		}
+	}
 
-		slog.Debug("Competitor score", "index", i, "homeAway", competitor.HomeAway, "score", score)
+	existing, err := t.db.FindGame(game.Season, game.Week, gameTeam(game.HomeTeamID, game.HomeTeam), gameTeam(game.AwayTeamID, game.AwayTeam))
+	if err != nil {
+		return nil, err
+	}
+	// A game of the same teams that was synced from another event is a different game
+	if existing.ESPNEventID != "" && game.ESPNEventID != "" && existing.ESPNEventID != game.ESPNEventID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return existing, nil
+}
 
-		// Determine if this competitor is the favorite or underdog
-		// This is based on our earlier assumption that home team = favorite
-		if competitor.HomeAway != nil && *competitor.HomeAway == "home" {
-			favoriteScore = score
-		} else {
-			underdogScore = score
-		}
+// recordScheduleChange records a change to a game's kickoff time, week or venue. Changes
+// to games that players have already picked are logged as alerts for the admins. A venue
+// is only compared once it has been recorded for the game.
+func (t *Transformer) recordScheduleChange(previous, game *database.Game) error {
+	venueChanged := previous.Venue != "" && previous.Venue != game.Venue
+	if previous.StartTime.Equal(game.StartTime) && previous.Week == game.Week && !venueChanged {
+		return nil
 	}
 
-	slog.Debug("Extracted scores", "favoriteScore", favoriteScore, "underdogScore", underdogScore)
+	var picks int64
+	if err := t.db.GetDB().Model(&database.Pick{}).Where("game_id = ?", game.ID).Count(&picks).Error; err != nil {
+		return err
+	}
 
-	// Determine outcome
-	if favoriteScore > 0 || underdogScore > 0 {
-		spread := float32(favoriteScore - underdogScore)
-		switch {
-		case spread > 0:
-			outcome = "favorite"
-		case spread < 0:
-			outcome = "underdog"
-		default:
-			outcome = "push"
-		}
+	change := database.ScheduleChange{
+		GameID:            game.ID,
+		Season:            game.Season,
+		PreviousWeek:      previous.Week,
+		Week:              game.Week,
+		PreviousStartTime: previous.StartTime,
+		StartTime:         game.StartTime,
+		PreviousVenue:     previous.Venue,
+		Venue:             game.Venue,
+		PicksAttached:     int(picks),
+		DetectedAt:        t.timeProvider.Now(),
+	}
+	if err := t.db.GetDB().Create(&change).Error; err != nil {
+		return err
 	}
 
-	if outcome == "" {
-		slog.Debug("No outcome determined - returning nil result")
-		return nil // No result available yet
+	if picks > 0 {
+		slog.Warn("Schedule changed for a game with picks", "game_id", game.ID, "event_id", game.ESPNEventID,
+			"home", game.HomeTeam, "away", game.AwayTeam, "previous_week", previous.Week, "week", game.Week,
+			"previous_start_time", previous.StartTime, "start_time", game.StartTime, "picks", picks)
+	} else {
+		slog.Info("Schedule changed", "game_id", game.ID, "event_id", game.ESPNEventID, "previous_week", previous.Week,
+			"week", game.Week, "previous_start_time", previous.StartTime, "start_time", game.StartTime)
 	}
+	return nil
+}
+
+// gameTeam returns the team a game references for matching it with FindGame.
+func gameTeam(id *uint, name string) *database.Team {
+	team := &database.Team{DisplayName: name}
+	if id != nil {
+		team.ID = *id
+	}
+	return team
+}
 
-	slog.Debug("Result created", "favoriteScore", favoriteScore, "underdogScore", underdogScore, "outcome", outcome)
-	return &database.Result{
-		FavoriteScore: favoriteScore,
-		UnderdogScore: underdogScore,
-		Outcome:       outcome,
+// syncedGameColumns are the columns of a game that the ESPN sync owns. The pool line is set
+// from the odds and is left alone when a synced game is stored again.
+var syncedGameColumns = []string{
+	"updated_at", "week", "season", "season_type", "favorite_team", "underdog_team", "start_time",
+	"home_team_id", "away_team_id", "venue", "state", "home_score", "away_score", "period", "clock",
+}
+
+// createGame stores a new game. A synced game is upserted on its ESPN event ID, so that a game
+// stored by a concurrent sync is updated rather than duplicated. A game deleted by an admin
+// stays deleted and errGameDeleted is returned.
+func (t *Transformer) createGame(game *database.Game) error {
+	if game.ESPNEventID == "" {
+		return t.db.GetDB().Create(game).Error
+	}
+	result := t.db.GetDB().Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "espn_event_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "espn_event_id <> ''"}}},
+		DoUpdates:   clause.AssignmentColumns(syncedGameColumns),
+		Where:       clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "games.deleted_at IS NULL"}}},
+	}).Create(game)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errGameDeleted
 	}
+	return nil
 }
 
-// StoreGameAndResult stores a game and its result in the database.
+// StoreGameAndResult stores a game and its result in the database. Games are keyed on their
+// ESPN event ID, so a game that is flexed or moved to another week updates its existing row
+// and records a schedule change. Games stored before their event ID was recorded are found
+// by their week and teams.
 func (t *Transformer) StoreGameAndResult(game *database.Game, result *database.Result) error {
-	// Check if game already exists
-	var existingGame database.Game
-	err := t.db.GetDB().Where("season = ? AND week = ? AND favorite_team = ? AND underdog_team = ?",
-		game.Season, game.Week, game.HomeTeam, game.AwayTeam).First(&existingGame).Error
+	existingGame, err := t.findExistingGame(game)
+	if errors.Is(err, errGameDeleted) {
+		slog.Info("Skipping event of a deleted game", "event_id", game.ESPNEventID, "home", game.HomeTeam, "away", game.AwayTeam)
+		return nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
 
-	slog.Debug("StoreGameAndResult: checking for existing game", "season", game.Season, "week", game.Week, "home", game.HomeTeam, "away", game.AwayTeam, "error", err, "existing_game_id", existingGame.ID)
+	slog.Debug("StoreGameAndResult: checking for existing game", "season", game.Season, "week", game.Week, "event_id", game.ESPNEventID, "home", game.HomeTeam, "away", game.AwayTeam, "found", existingGame != nil)
 
-	if err != nil {
+	if existingGame == nil {
 		// Game doesn't exist, create it
 		slog.Debug("StoreGameAndResult: creating new game")
-		if err := t.db.GetDB().Create(game).Error; err != nil {
+		if err := t.createGame(game); err != nil {
+			// The game was deleted after it was looked up
+			if errors.Is(err, errGameDeleted) {
+				slog.Info("Skipping event of a deleted game", "event_id", game.ESPNEventID, "home", game.HomeTeam, "away", game.AwayTeam)
+				return nil
+			}
 			return err
 		}
 	} else {
 		// Game exists, update it
 		slog.Debug("StoreGameAndResult: updating existing game", "existingID", existingGame.ID)
 		game.ID = existingGame.ID
+		game.CreatedAt = existingGame.CreatedAt
+
+		// ESPN does not provide lines, so a game without one keeps the pool line
+		// set from the odds, and a frozen pool line is never replaced
+		game.SpreadLockedAt = existingGame.SpreadLockedAt
+		if game.SpreadLockedAt != nil || (game.Favorite == nil && game.Spread == 0) {
+			game.Spread = existingGame.Spread
+			game.Favorite = existingGame.Favorite
+			game.Underdog = existingGame.Underdog
+			game.SpreadStrategy = existingGame.SpreadStrategy
+			game.SpreadBookmakers = existingGame.SpreadBookmakers
+		}
 		if err := t.db.GetDB().Save(game).Error; err != nil {
 			return err
 		}
+
+		if err := t.recordScheduleChange(existingGame, game); err != nil {
+			slog.Error("Failed to record schedule change", "game_id", game.ID, "error", err)
+		}
 	}
 
 	// Store result if available
@@ -200,6 +378,10 @@ func (t *Transformer) StoreGameAndResult(game *database.Game, result *database.R
 		return nil
 	}
 
+	// The line is only known once the game is stored, so the result is graded again on it
+	if graded := t.extractResult(game); graded != nil {
+		result.FavoriteScore, result.UnderdogScore, result.Outcome = graded.FavoriteScore, graded.UnderdogScore, graded.Outcome
+	}
 	result.GameID = game.ID
 
 	// Check if result already exists
@@ -1,11 +1,15 @@
 package espnsync
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	apiespn "github.com/dhpollack/football-pool/internal/api-espn"
+	"github.com/dhpollack/football-pool/internal/config"
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+	"github.com/dhpollack/football-pool/internal/seasons"
 )
 
 const favoriteRes = "favorite"
@@ -349,3 +353,301 @@ func TestTransformer_StoreGameAndResultUpdate(t *testing.T) {
 		t.Errorf("Stored result favoriteScore = %v, want 21", storedResult.FavoriteScore)
 	}
 }
+
+func TestTransformer_CreateGameUpsertsEvent(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	transformer := NewTransformer(db)
+
+	home := "Home"
+	kickoff := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+	stored := &database.Game{Week: 1, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Chargers", StartTime: kickoff, ESPNEventID: "401", Favorite: &home, Spread: 3}
+	if err := transformer.createGame(stored); err != nil {
+		t.Fatalf("createGame() error = %v", err)
+	}
+
+	// A second sync of the event updates the stored game and keeps its pool line
+	game := &database.Game{Week: 1, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Chargers", StartTime: kickoff.Add(3 * time.Hour), ESPNEventID: "401"}
+	if err := transformer.createGame(game); err != nil {
+		t.Fatalf("createGame() error = %v", err)
+	}
+
+	var games []database.Game
+	db.GetDB().Find(&games)
+	if len(games) != 1 || games[0].ID != stored.ID || game.ID != stored.ID || !games[0].StartTime.Equal(kickoff.Add(3*time.Hour)) || games[0].Spread != 3 || games[0].Favorite == nil {
+		t.Errorf("Expected the event to update the stored game, got %+v", games)
+	}
+
+	// Games entered by hand have no event ID and never conflict
+	for range 2 {
+		manual := &database.Game{Week: 1, Season: 2025, HomeTeam: "Bills", AwayTeam: "Jets", StartTime: kickoff}
+		if err := transformer.createGame(manual); err != nil {
+			t.Fatalf("createGame() error = %v", err)
+		}
+	}
+	var count int64
+	db.GetDB().Model(&database.Game{}).Count(&count)
+	if count != 3 {
+		t.Errorf("Expected 3 games, got %d", count)
+	}
+}
+
+func TestTransformer_DeletedGameStaysDeleted(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	transformer := NewTransformer(db)
+
+	kickoff := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+	stored := &database.Game{Week: 1, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Chargers", StartTime: kickoff, ESPNEventID: "401"}
+	if err := transformer.createGame(stored); err != nil {
+		t.Fatalf("createGame() error = %v", err)
+	}
+	if err := db.GetDB().Delete(stored).Error; err != nil {
+		t.Fatalf("Failed to delete game: %v", err)
+	}
+
+	// Syncing the event again neither revives the game nor stores its result
+	game := &database.Game{Week: 1, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Chargers", StartTime: kickoff, ESPNEventID: "401", State: database.GameStateFinal, HomeScore: 24, AwayScore: 17}
+	if err := transformer.StoreGameAndResult(game, &database.Result{FavoriteScore: 24, UnderdogScore: 17, Outcome: favoriteRes}); err != nil {
+		t.Fatalf("StoreGameAndResult() error = %v", err)
+	}
+	var games, results int64
+	db.GetDB().Model(&database.Game{}).Count(&games)
+	db.GetDB().Model(&database.Result{}).Count(&results)
+	if games != 0 || results != 0 {
+		t.Errorf("Expected the deleted game to stay deleted, got %d games and %d results", games, results)
+	}
+
+	// A concurrent sync that inserts the event does not revive it either
+	game = &database.Game{Week: 1, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Chargers", StartTime: kickoff.Add(time.Hour), ESPNEventID: "401"}
+	if err := transformer.createGame(game); !errors.Is(err, errGameDeleted) {
+		t.Errorf("createGame() error = %v, want %v", err, errGameDeleted)
+	}
+	var deleted database.Game
+	db.GetDB().Unscoped().First(&deleted, stored.ID)
+	if !deleted.DeletedAt.Valid || !deleted.StartTime.Equal(kickoff) {
+		t.Errorf("Expected the deleted game to be left alone, got %+v", deleted)
+	}
+}
+
+func TestTransformer_ResultGradedLikeLiveScores(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	transformer := NewTransformer(db)
+
+	// The Chiefs are favored by 7 and win by 3, so the Chargers cover
+	home := "Home"
+	kickoff := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+	stored := &database.Game{Week: 1, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Chargers", StartTime: kickoff, ESPNEventID: "401", Favorite: &home, Spread: 7}
+	if err := transformer.createGame(stored); err != nil {
+		t.Fatalf("createGame() error = %v", err)
+	}
+
+	sync := func(state string, completed bool) {
+		t.Helper()
+		event := apiespn.Event{
+			Id:     &[]string{"401"}[0],
+			Date:   espnDateTime(kickoff),
+			Status: &apiespn.Status{Type: &apiespn.StatusType{State: &state, Completed: &completed}},
+			Competitions: &[]apiespn.Competition{{
+				Date: espnDateTime(kickoff),
+				Competitors: &[]apiespn.Competitor{
+					{HomeAway: &[]string{"home"}[0], Team: &apiespn.Team{DisplayName: &[]string{"Chiefs"}[0]}, Score: &[]string{"20"}[0]},
+					{HomeAway: &[]string{"away"}[0], Team: &apiespn.Team{DisplayName: &[]string{"Chargers"}[0]}, Score: &[]string{"17"}[0]},
+				},
+			}},
+		}
+		game, result, err := transformer.TransformEvent(event, 2025, 1)
+		if err != nil {
+			t.Fatalf("TransformEvent() error = %v", err)
+		}
+		if err := transformer.StoreGameAndResult(game, result); err != nil {
+			t.Fatalf("StoreGameAndResult() error = %v", err)
+		}
+	}
+
+	sync(database.GameStateInProgress, false)
+	cfg := &config.Config{}
+	cfg.ESPN.SeasonYear = 2025
+	standings, err := seasons.NewService(db, cfg)
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	live, err := standings.LiveStandings(2025, 1)
+	if err != nil {
+		t.Fatalf("LiveStandings() error = %v", err)
+	}
+	if len(live.Games) != 1 || live.Games[0].Outcome != scoring.SideUnderdog {
+		t.Fatalf("Expected the underdog to cover while in progress, got %+v", live.Games)
+	}
+
+	// The final result agrees with the live standings at the same score
+	sync(database.GameStateFinal, true)
+	var result database.Result
+	if err := db.GetDB().Where("game_id = ?", stored.ID).First(&result).Error; err != nil {
+		t.Fatalf("Failed to find stored result: %v", err)
+	}
+	if result.FavoriteScore != 20 || result.UnderdogScore != 17 || result.Outcome != live.Games[0].Outcome {
+		t.Errorf("Expected the final result to match the live outcome %s, got %+v", live.Games[0].Outcome, result)
+	}
+}
+
+func TestTransformer_RegistersTeams(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	transformer := NewTransformer(db)
+
+	event := func(homeName string) apiespn.Event {
+		return apiespn.Event{
+			Id:   &[]string{"event1"}[0],
+			Date: espnDateTime(time.Date(2022, 9, 11, 17, 0, 0, 0, time.UTC)),
+			Competitions: &[]apiespn.Competition{
+				{
+					Competitors: &[]apiespn.Competitor{
+						{
+							HomeAway: &[]string{"home"}[0],
+							Team: &apiespn.Team{
+								Id:           &[]string{"28"}[0],
+								DisplayName:  &homeName,
+								Abbreviation: &[]string{"WSH"}[0],
+								Location:     &[]string{"Washington"}[0],
+								Color:        &[]string{"5a1414"}[0],
+							},
+						},
+						{
+							HomeAway: &[]string{"away"}[0],
+							Team: &apiespn.Team{
+								Id:           &[]string{"19"}[0],
+								DisplayName:  &[]string{"New York Giants"}[0],
+								Abbreviation: &[]string{"NYG"}[0],
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	game, _, err := transformer.TransformEvent(event("Washington Football Team"), 2022, 1)
+	if err != nil {
+		t.Fatalf("TransformEvent() error = %v", err)
+	}
+	if game.HomeTeamID == nil || game.AwayTeamID == nil {
+		t.Fatalf("TransformEvent() team IDs = %v, %v, want registered teams", game.HomeTeamID, game.AwayTeamID)
+	}
+	if game.ESPNEventID != "event1" {
+		t.Errorf("TransformEvent() ESPN event ID = %q, want %q", game.ESPNEventID, "event1")
+	}
+	if err := transformer.StoreGameAndResult(game, nil); err != nil {
+		t.Fatalf("StoreGameAndResult() error = %v", err)
+	}
+
+	// The team is renamed, but still refers to the stored game through its ID
+	renamed, _, err := transformer.TransformEvent(event("Washington Commanders"), 2022, 1)
+	if err != nil {
+		t.Fatalf("TransformEvent() error = %v", err)
+	}
+	if *renamed.HomeTeamID != *game.HomeTeamID {
+		t.Errorf("Renamed team ID = %d, want %d", *renamed.HomeTeamID, *game.HomeTeamID)
+	}
+	if err := transformer.StoreGameAndResult(renamed, nil); err != nil {
+		t.Fatalf("StoreGameAndResult() error = %v", err)
+	}
+
+	var games int64
+	db.GetDB().Model(&database.Game{}).Count(&games)
+	if games != 1 {
+		t.Errorf("Expected 1 game, got %d", games)
+	}
+
+	resolver := database.NewTeamResolver(db.GetDB())
+	for _, name := range []string{"Washington Football Team", "Washington Commanders", "WSH"} {
+		team, err := resolver.Resolve("theoddsapi", name)
+		if err != nil {
+			t.Errorf("Resolve(%q) error = %v", name, err)
+			continue
+		}
+		if team.ID != *game.HomeTeamID || team.Color != "5a1414" {
+			t.Errorf("Resolve(%q) = %+v, want team %d", name, team, *game.HomeTeamID)
+		}
+	}
+}
+
+func TestTransformer_StoreGameAndResultScheduleChange(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	transformer := NewTransformer(db)
+
+	kickoff := time.Date(2025, 12, 14, 18, 0, 0, 0, time.UTC)
+	// A game stored before event IDs were recorded is adopted by its event
+	legacy := &database.Game{Week: 15, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Chargers", StartTime: kickoff}
+	if err := db.GetDB().Create(legacy).Error; err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	game := &database.Game{Week: 15, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Chargers", StartTime: kickoff, ESPNEventID: "401", Venue: "GEHA Field"}
+	if err := transformer.StoreGameAndResult(game, nil); err != nil {
+		t.Fatalf("StoreGameAndResult() error = %v", err)
+	}
+	if game.ID != legacy.ID {
+		t.Fatalf("StoreGameAndResult() game ID = %d, want %d", game.ID, legacy.ID)
+	}
+
+	var changes int64
+	db.GetDB().Model(&database.ScheduleChange{}).Count(&changes)
+	if changes != 0 {
+		t.Fatalf("Expected no schedule changes, got %d", changes)
+	}
+
+	user := database.User{Name: "Player", Email: "player@example.com", Password: "password"}
+	if err := db.GetDB().Create(&user).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := db.GetDB().Create(&database.Pick{UserID: user.ID, GameID: game.ID, Picked: "Home", Rank: 1}).Error; err != nil {
+		t.Fatalf("Failed to create pick: %v", err)
+	}
+
+	// The game is postponed to the next week, where the sync finds it under the same event
+	moved := &database.Game{Week: 16, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Chargers", StartTime: kickoff.AddDate(0, 0, 8), ESPNEventID: "401", Venue: "GEHA Field"}
+	if err := transformer.StoreGameAndResult(moved, nil); err != nil {
+		t.Fatalf("StoreGameAndResult() error = %v", err)
+	}
+	if moved.ID != game.ID {
+		t.Errorf("StoreGameAndResult() game ID = %d, want %d", moved.ID, game.ID)
+	}
+
+	var games []database.Game
+	db.GetDB().Find(&games)
+	if len(games) != 1 || games[0].Week != 16 {
+		t.Fatalf("Expected the game to move to week 16, got %+v", games)
+	}
+
+	var change database.ScheduleChange
+	if err := db.GetDB().First(&change).Error; err != nil {
+		t.Fatalf("Failed to load schedule change: %v", err)
+	}
+	if change.GameID != game.ID || change.PreviousWeek != 15 || change.Week != 16 || !change.PreviousStartTime.Equal(kickoff) || change.PicksAttached != 1 || !change.IsAlert() {
+		t.Errorf("Unexpected schedule change: %+v", change)
+	}
+
+	// A venue change is recorded once the venue is known
+	relocated := *moved
+	relocated.Venue = "Arrowhead Stadium"
+	if err := transformer.StoreGameAndResult(&relocated, nil); err != nil {
+		t.Fatalf("StoreGameAndResult() error = %v", err)
+	}
+	db.GetDB().Model(&database.ScheduleChange{}).Count(&changes)
+	if changes != 2 {
+		t.Errorf("Expected 2 schedule changes, got %d", changes)
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/clock"
+)
+
+// GetClock handles returning the application clock.
+func GetClock(appClock *clock.Adjustable) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(clockResponse(appClock))
+	}
+}
+
+// SetClock handles moving the application clock to a time or advancing it by a duration,
+// so that E2E tests can play weeks out in minutes.
+func SetClock(appClock *clock.Adjustable) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var req api.ClockRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid request body"})
+			return
+		}
+		if (req.Now == nil) == (req.AdvanceSeconds == nil) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Exactly one of now and advance_seconds is required"})
+			return
+		}
+
+		if req.Now != nil {
+			appClock.Set(*req.Now)
+		} else {
+			appClock.Advance(time.Duration(*req.AdvanceSeconds) * time.Second)
+		}
+
+		_ = json.NewEncoder(w).Encode(clockResponse(appClock))
+	}
+}
+
+// ResetClock handles moving the application clock back to the system time.
+func ResetClock(appClock *clock.Adjustable) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		appClock.Reset()
+		_ = json.NewEncoder(w).Encode(clockResponse(appClock))
+	}
+}
+
+// clockResponse returns the state of the application clock.
+func clockResponse(appClock *clock.Adjustable) api.ClockResponse {
+	return api.ClockResponse{
+		Now:           appClock.Now(),
+		OffsetSeconds: int64(appClock.Offset() / time.Second),
+	}
+}
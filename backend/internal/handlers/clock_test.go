@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/clock"
+)
+
+func TestSetClock(t *testing.T) {
+	appClock := clock.NewAdjustable()
+	kickoff := time.Date(2025, time.September, 7, 17, 0, 0, 0, time.UTC)
+
+	setClock := func(body string) (int, api.ClockResponse) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		SetClock(appClock)(w, httptest.NewRequest(http.MethodPut, "/api/admin/e2e/clock", bytes.NewBufferString(body)))
+		var response api.ClockResponse
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return w.Code, response
+	}
+
+	status, response := setClock(`{"now": "2025-09-07T17:00:00Z"}`)
+	if status != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, status)
+	}
+	if response.Now.Before(kickoff) || response.Now.Sub(kickoff) > time.Second {
+		t.Errorf("Expected the clock at %v, got %v", kickoff, response.Now)
+	}
+
+	status, response = setClock(`{"advance_seconds": 86400}`)
+	if status != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, status)
+	}
+	if want := kickoff.Add(24 * time.Hour); response.Now.Before(want) || response.Now.Sub(want) > time.Second {
+		t.Errorf("Expected the clock at %v, got %v", want, response.Now)
+	}
+	if offset := time.Until(response.Now); (offset - time.Duration(response.OffsetSeconds)*time.Second).Abs() > time.Second {
+		t.Errorf("Expected an offset of %v, got %ds", offset, response.OffsetSeconds)
+	}
+
+	for _, body := range []string{`{}`, `{"now": "2025-09-07T17:00:00Z", "advance_seconds": 60}`, `not json`} {
+		if status, _ := setClock(body); status != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusBadRequest, body, status)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	ResetClock(appClock)(w, httptest.NewRequest(http.MethodDelete, "/api/admin/e2e/clock", nil))
+	if offset := appClock.Offset(); w.Code != http.StatusOK || offset != 0 {
+		t.Errorf("Expected the clock reset, got status %d and offset %v", w.Code, offset)
+	}
+}
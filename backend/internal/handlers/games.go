@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/config"
 	"github.com/dhpollack/football-pool/internal/database"
 	"gorm.io/gorm"
 )
@@ -37,6 +38,11 @@ func CreateGame(db *gorm.DB) http.HandlerFunc {
 			games[i] = game
 		}
 
+		resolver := database.NewTeamResolver(db)
+		for i := range games {
+			resolveGameTeams(resolver, &games[i])
+		}
+
 		if result := db.Create(&games); result.Error != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to create games"})
@@ -54,6 +60,52 @@ func CreateGame(db *gorm.DB) http.HandlerFunc {
 	}
 }
 
+// resolveGameTeams sets the team IDs of a game entered by an admin from its team names.
+func resolveGameTeams(resolver *database.TeamResolver, game *database.Game) {
+	game.HomeTeamID, game.AwayTeamID = nil, nil
+	if team, err := resolver.Resolve(config.OddsProviderManual, game.HomeTeam); err == nil {
+		game.HomeTeamID = &team.ID
+	}
+	if team, err := resolver.Resolve(config.OddsProviderManual, game.AwayTeam); err == nil {
+		game.AwayTeamID = &team.ID
+	}
+}
+
+// GetGameSpreads handles retrieval of a game's pool line and the history of lines fetched for it.
+func GetGameSpreads(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		id, err := strconv.ParseUint(extractPathParam(r, "id"), 10, 32)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid game ID"})
+			return
+		}
+
+		var game database.Game
+		if err := db.First(&game, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Game not found"})
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Database error"})
+			}
+			return
+		}
+
+		var snapshots []database.SpreadSnapshot
+		if err := db.Where("game_id = ?", game.ID).Order("fetched_at ASC, id ASC").Find(&snapshots).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch spreads"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(api.SpreadHistoryToResponse(game, snapshots))
+	}
+}
+
 // GetGames handles retrieval of game records with optional week and season filtering.
 func GetGames(db *gorm.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -245,8 +297,18 @@ func UpdateGame(db *gorm.DB) http.HandlerFunc {
 			return
 		}
 
-		// Update the game
-		if err := db.Model(&existingGame).Updates(updateData).Error; err != nil {
+		// Update the game, clearing the team references of names that do not resolve
+		resolveGameTeams(database.NewTeamResolver(db), &updateData)
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&existingGame).Updates(updateData).Error; err != nil {
+				return err
+			}
+			return tx.Model(&existingGame).Updates(map[string]interface{}{
+				"home_team_id": updateData.HomeTeamID,
+				"away_team_id": updateData.AwayTeamID,
+			}).Error
+		})
+		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to update game"})
 			return
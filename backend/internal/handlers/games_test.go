@@ -387,3 +387,57 @@ func TestDeleteGame(t *testing.T) {
 		}
 	})
 }
+
+func TestGetGameSpreads(t *testing.T) {
+	db, err := database.New("sqlite", "file::memory:")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/games/{id}/spreads", GetGameSpreads(gormDB))
+
+	home := "Home"
+	lockedAt := time.Date(2025, 9, 9, 16, 0, 0, 0, time.UTC)
+	game := database.Game{Week: 2, Season: 2025, HomeTeam: "Packers", AwayTeam: "Commanders", Spread: 3.5, Favorite: &home, SpreadLockedAt: &lockedAt}
+	gormDB.Create(&game)
+
+	// The line moved from the home team favored by 3.5 to the away team favored by 1
+	snapshots := []database.SpreadSnapshot{
+		{GameID: game.ID, Strategy: "preferred", Bookmakers: "draftkings", Spread: 3.5, Favorite: "Home", FetchedAt: time.Date(2025, 9, 8, 12, 0, 0, 0, time.UTC)},
+		{GameID: game.ID, Strategy: "preferred", Bookmakers: "draftkings", Spread: 1, Favorite: "Away", FetchedAt: time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)},
+	}
+	gormDB.Create(&snapshots)
+
+	t.Run("line history", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/api/games/%d/spreads", game.ID), nil)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+		}
+
+		var response api.SpreadHistoryResponse
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Spread != 3.5 || response.LockedAt == nil || len(response.Snapshots) != 2 {
+			t.Errorf("Unexpected response: %+v", response)
+		}
+		if response.Movement != 4.5 {
+			t.Errorf("Movement = %v, want 4.5", response.Movement)
+		}
+	})
+
+	t.Run("game not found", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/games/999/spreads", nil)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusNotFound {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/jobs"
+)
+
+// ListJobs handles listing the registered background jobs and their latest runs.
+func ListJobs(scheduler *jobs.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		infos, err := scheduler.Jobs()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch jobs"})
+			return
+		}
+
+		jobResponses := make([]api.JobResponse, len(infos))
+		for i, info := range infos {
+			jobResponses[i] = api.JobResponse{
+				Name:        info.Name,
+				Description: info.Description,
+				NextRunAt:   info.NextRun,
+				Running:     info.Running,
+			}
+			if info.Schedule != "" {
+				schedule := info.Schedule
+				jobResponses[i].Schedule = &schedule
+			}
+			if info.LastRun != nil {
+				lastRun := api.JobRunToResponse(*info.LastRun)
+				jobResponses[i].LastRun = &lastRun
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(api.JobListResponse{Jobs: jobResponses})
+	}
+}
+
+// ListJobRuns handles listing the most recent runs of a background job.
+func ListJobRuns(scheduler *jobs.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		limit := 20
+		if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+			var err error
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil || limit < 1 || limit > 100 {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid limit"})
+				return
+			}
+		}
+
+		runs, err := scheduler.Runs(extractPathParam(r, "name"), limit)
+		if err != nil {
+			if errors.Is(err, jobs.ErrJobNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Job not found"})
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch job runs"})
+			}
+			return
+		}
+
+		runResponses := make([]api.JobRunResponse, len(runs))
+		for i, run := range runs {
+			runResponses[i] = api.JobRunToResponse(run)
+		}
+
+		_ = json.NewEncoder(w).Encode(api.JobRunListResponse{Runs: runResponses})
+	}
+}
+
+// TriggerJob handles running a background job immediately.
+func TriggerJob(scheduler *jobs.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		run, err := scheduler.Trigger(extractPathParam(r, "name"))
+		if err != nil {
+			switch {
+			case errors.Is(err, jobs.ErrJobNotFound):
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Job not found"})
+			case errors.Is(err, jobs.ErrJobRunning):
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Job is already running"})
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to start job"})
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(api.JobRunToResponse(*run))
+	}
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/jobs"
+)
+
+// setupJobScheduler creates a scheduler with a single job that blocks until released.
+func setupJobScheduler(t *testing.T) (*jobs.Scheduler, chan struct{}) {
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.Jobs.LockTTL = time.Minute
+
+	release := make(chan struct{})
+	scheduler := jobs.NewScheduler(db, cfg)
+	if err := scheduler.Register(jobs.Job{
+		Name:        "espn-sync",
+		Description: "Sync games from ESPN",
+		Schedule:    "@every 1h",
+		Run: func(context.Context) error {
+			<-release
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+	return scheduler, release
+}
+
+func TestTriggerJob(t *testing.T) {
+	scheduler, release := setupJobScheduler(t)
+	defer close(release)
+
+	req := createRequestWithPathParams("POST", "/api/admin/jobs/espn-sync/run", nil, map[string]string{"name": "espn-sync"})
+	w := httptest.NewRecorder()
+	TriggerJob(scheduler)(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d", http.StatusAccepted, w.Code)
+	}
+
+	var response api.JobRunResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.JobName != "espn-sync" || response.TriggeredBy != api.Manual || response.Status != api.Running {
+		t.Errorf("Unexpected run: %+v", response)
+	}
+
+	// A second trigger conflicts with the running job
+	w = httptest.NewRecorder()
+	TriggerJob(scheduler)(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	// The job list shows the job running
+	w = httptest.NewRecorder()
+	ListJobs(scheduler)(w, httptest.NewRequest("GET", "/api/admin/jobs", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var list api.JobListResponse
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Jobs) != 1 || !list.Jobs[0].Running || list.Jobs[0].LastRun == nil || list.Jobs[0].NextRunAt == nil {
+		t.Errorf("Unexpected job list: %+v", list)
+	}
+}
+
+func TestTriggerJob_NotFound(t *testing.T) {
+	scheduler, release := setupJobScheduler(t)
+	defer close(release)
+
+	req := createRequestWithPathParams("POST", "/api/admin/jobs/missing/run", nil, map[string]string{"name": "missing"})
+	w := httptest.NewRecorder()
+	TriggerJob(scheduler)(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestListJobRuns(t *testing.T) {
+	scheduler, release := setupJobScheduler(t)
+	close(release)
+
+	if _, err := scheduler.Trigger("espn-sync"); err != nil {
+		t.Fatalf("Failed to trigger job: %v", err)
+	}
+
+	req := createRequestWithPathParams("GET", "/api/admin/jobs/espn-sync/runs?limit=5", nil, map[string]string{"name": "espn-sync"})
+	w := httptest.NewRecorder()
+	ListJobRuns(scheduler)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response api.JobRunListResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Runs) != 1 || response.Runs[0].JobName != "espn-sync" {
+		t.Errorf("Unexpected runs: %+v", response.Runs)
+	}
+
+	// Invalid limits and unknown jobs are rejected
+	req = createRequestWithPathParams("GET", "/api/admin/jobs/espn-sync/runs?limit=0", nil, map[string]string{"name": "espn-sync"})
+	w = httptest.NewRecorder()
+	ListJobRuns(scheduler)(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	req = createRequestWithPathParams("GET", "/api/admin/jobs/missing/runs", nil, map[string]string{"name": "missing"})
+	w = httptest.NewRecorder()
+	ListJobRuns(scheduler)(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
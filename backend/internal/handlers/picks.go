@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/dhpollack/football-pool/internal/api"
 	"github.com/dhpollack/football-pool/internal/auth"
+	"github.com/dhpollack/football-pool/internal/clock"
 	"github.com/dhpollack/football-pool/internal/database"
 	"gorm.io/gorm"
 )
@@ -52,8 +55,9 @@ func GetPicks(db *gorm.DB) http.HandlerFunc {
 	}
 }
 
-// SubmitPicks handles submission of user picks for games.
-func SubmitPicks(db *gorm.DB) http.HandlerFunc {
+// SubmitPicks handles submission of user picks for games, along with the tiebreaker
+// prediction of their week. Picks for a week are rejected once the week has locked.
+func SubmitPicks(db *gorm.DB, timeProvider clock.Clock) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		email := r.Context().Value(auth.EmailKey).(string)
@@ -79,13 +83,39 @@ func SubmitPicks(db *gorm.DB) http.HandlerFunc {
 			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: err.Error()})
 			return
 		}
+		now := timeProvider.Now()
 		for i := range picks {
 			picks[i].UserID = user.ID
+			picks[i].SubmittedAt = now
 		}
 
-		if result := db.Create(&picks); result.Error != nil {
+		locked, err := pickedWeekLocked(db, picks, now)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to check the week status"})
+			return
+		}
+		if locked {
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Picks are locked for this week"})
+			return
+		}
+
+		tiebreakers, ok, err := sheetTiebreakers(db, pickRequests, picks, now)
+		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to create picks: " + result.Error.Error()})
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch the picked games"})
+			return
+		}
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Conflicting tiebreaker predictions for the same week"})
+			return
+		}
+
+		if err := createSheet(db, picks, tiebreakers); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to create picks: " + err.Error()})
 			return
 		}
 
@@ -99,8 +129,114 @@ func SubmitPicks(db *gorm.DB) http.HandlerFunc {
 	}
 }
 
-// AdminSubmitPicks handles administrative submission of picks for any user.
-func AdminSubmitPicks(db *gorm.DB) http.HandlerFunc {
+// pickedWeekLocked reports whether any week of the picked games is locked.
+func pickedWeekLocked(db *gorm.DB, picks []database.Pick, now time.Time) (bool, error) {
+	gameIDs := make([]uint, len(picks))
+	for i, pick := range picks {
+		gameIDs[i] = pick.GameID
+	}
+
+	var weeks []struct {
+		Season int
+		Week   int
+	}
+	if err := db.Model(&database.Game{}).Distinct("season", "week").Where("id IN ?", gameIDs).Find(&weeks).Error; err != nil {
+		return false, err
+	}
+
+	for _, week := range weeks {
+		locked, err := weekLocked(db, week.Season, week.Week, now)
+		if err != nil || locked {
+			return locked, err
+		}
+	}
+	return false, nil
+}
+
+// weekLocked reports whether a week is locked, either because the week lifecycle has moved
+// it past open or because one of its games has kicked off.
+func weekLocked(db *gorm.DB, season, week int, now time.Time) (bool, error) {
+	lockedStatuses := []string{database.WeekStatusLocked, database.WeekStatusScoring, database.WeekStatusFinal}
+	var locked int64
+	if err := db.Model(&database.Week{}).
+		Where("season = ? AND week_number = ? AND status IN ?", season, week, lockedStatuses).
+		Count(&locked).Error; err != nil {
+		return false, err
+	}
+	var started int64
+	if err := db.Model(&database.Game{}).
+		Where("season = ? AND week = ? AND start_time <= ?", season, week, now).
+		Count(&started).Error; err != nil {
+		return false, err
+	}
+	return locked > 0 || started > 0, nil
+}
+
+// sheetTiebreakers returns the tiebreaker predictions submitted along with picks, one for
+// each player and week of the picked games. It reports false when the picks of a week
+// disagree on the prediction.
+func sheetTiebreakers(db *gorm.DB, reqs []api.PickRequest, picks []database.Pick, now time.Time) ([]database.Tiebreaker, bool, error) {
+	gameIDs := make([]uint, 0, len(picks))
+	for i, req := range reqs {
+		if req.PredictedTotal != nil {
+			gameIDs = append(gameIDs, picks[i].GameID)
+		}
+	}
+	if len(gameIDs) == 0 {
+		return nil, true, nil
+	}
+
+	var games []database.Game
+	if err := db.Where("id IN ?", gameIDs).Find(&games).Error; err != nil {
+		return nil, false, err
+	}
+	gamesMap := make(map[uint]database.Game, len(games))
+	for _, game := range games {
+		gamesMap[game.ID] = game
+	}
+
+	var tiebreakers []database.Tiebreaker
+	for i, req := range reqs {
+		game, ok := gamesMap[picks[i].GameID]
+		if req.PredictedTotal == nil || !ok {
+			continue
+		}
+		tiebreaker := database.Tiebreaker{UserID: picks[i].UserID, Season: game.Season, Week: game.Week, PredictedTotal: *req.PredictedTotal, SubmittedAt: now}
+		j := slices.IndexFunc(tiebreakers, func(other database.Tiebreaker) bool {
+			return other.UserID == tiebreaker.UserID && other.Season == tiebreaker.Season && other.Week == tiebreaker.Week
+		})
+		if j < 0 {
+			tiebreakers = append(tiebreakers, tiebreaker)
+		} else if tiebreakers[j].PredictedTotal != tiebreaker.PredictedTotal {
+			return nil, false, nil
+		}
+	}
+	return tiebreakers, true, nil
+}
+
+// createSheet creates picks and saves the tiebreaker predictions submitted along with them,
+// which replace the players' previous predictions.
+func createSheet(db *gorm.DB, picks []database.Pick, tiebreakers []database.Tiebreaker) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&picks).Error; err != nil {
+			return err
+		}
+		for _, submitted := range tiebreakers {
+			tiebreaker := database.Tiebreaker{UserID: submitted.UserID, Season: submitted.Season, Week: submitted.Week}
+			err := tx.Where(&tiebreaker).
+				Assign(database.Tiebreaker{PredictedTotal: submitted.PredictedTotal, SubmittedAt: submitted.SubmittedAt}).
+				FirstOrCreate(&tiebreaker).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AdminSubmitPicks handles administrative submission of picks for any user, along with the
+// tiebreaker prediction of their week.
+func AdminSubmitPicks(db *gorm.DB, timeProvider clock.Clock) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
@@ -118,8 +254,24 @@ func AdminSubmitPicks(db *gorm.DB) http.HandlerFunc {
 			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: err.Error()})
 			return
 		}
+		now := timeProvider.Now()
+		for i := range picks {
+			picks[i].SubmittedAt = now
+		}
+
+		tiebreakers, ok, err := sheetTiebreakers(db, pickRequests, picks, now)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch the picked games"})
+			return
+		}
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Conflicting tiebreaker predictions for the same week"})
+			return
+		}
 
-		if result := db.Create(&picks); result.Error != nil {
+		if err := createSheet(db, picks, tiebreakers); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to create picks"})
 			return
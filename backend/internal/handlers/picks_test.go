@@ -13,6 +13,7 @@ import (
 
 	"github.com/dhpollack/football-pool/internal/api"
 	"github.com/dhpollack/football-pool/internal/auth"
+	"github.com/dhpollack/football-pool/internal/clock"
 	"github.com/dhpollack/football-pool/internal/database"
 )
 
@@ -89,7 +90,7 @@ func TestSubmitPicks(t *testing.T) {
 	// Create a user and a game
 	user := database.User{Name: "testuser", Email: "test2@test.com", Password: "password", Role: "user"}
 	gormDB.Create(&user)
-	game := database.Game{Week: 1, Season: 2023, HomeTeam: "Packers", AwayTeam: "Bears", Favorite: &home, Underdog: &away, Spread: 3.5, StartTime: time.Now()}
+	game := database.Game{Week: 1, Season: 2023, HomeTeam: "Packers", AwayTeam: "Bears", Favorite: &home, Underdog: &away, Spread: 3.5, StartTime: time.Now().Add(time.Hour)}
 	gormDB.Create(&game)
 
 	// Create the picks to submit
@@ -108,7 +109,7 @@ func TestSubmitPicks(t *testing.T) {
 
 	// Create a ResponseRecorder
 	rr := httptest.NewRecorder()
-	handler := SubmitPicks(gormDB)
+	handler := SubmitPicks(gormDB, clock.Real{})
 
 	// Call the handler
 	handler.ServeHTTP(rr, req)
@@ -138,6 +139,136 @@ func TestSubmitPicks(t *testing.T) {
 	}
 }
 
+func TestSubmitPicksLockedWeek(t *testing.T) {
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+	home, away := homeAndAway()
+
+	gormDB.Create(&database.User{Name: "testuser", Email: "locked@test.com", Password: "password", Role: "user"})
+	sunday := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+	games := []database.Game{
+		{Week: 1, Season: 2025, HomeTeam: "Packers", AwayTeam: "Bears", Favorite: &home, Underdog: &away, Spread: 3.5, StartTime: sunday},
+		{Week: 1, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Raiders", Favorite: &home, Underdog: &away, Spread: 7, StartTime: sunday.Add(27 * time.Hour)},
+		{Week: 2, Season: 2025, HomeTeam: "Eagles", AwayTeam: "Giants", Favorite: &home, Underdog: &away, Spread: 6, StartTime: sunday.Add(7 * 24 * time.Hour)},
+	}
+	gormDB.Create(&games)
+	gormDB.Create(&database.Week{Season: 2025, WeekNumber: 2, WeekStartTime: sunday, WeekEndTime: sunday.Add(14 * 24 * time.Hour), Status: database.WeekStatusLocked})
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(sunday.Add(time.Hour))
+
+	tests := []struct {
+		name           string
+		gameID         uint
+		expectedStatus int
+	}{
+		// The Monday night game has not kicked off, but the week locked with the first game
+		{"week with a game kicked off", games[1].ID, http.StatusConflict},
+		{"week locked by the lifecycle", games[2].ID, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal([]api.PickRequest{{GameId: tt.gameID, Picked: "favorite", Rank: 1}})
+			req := httptest.NewRequest("POST", "/picks", bytes.NewBuffer(body))
+			req = req.WithContext(context.WithValue(req.Context(), auth.EmailKey, "locked@test.com"))
+
+			rr := httptest.NewRecorder()
+			SubmitPicks(gormDB, appClock)(rr, req)
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+
+	var picks int64
+	gormDB.Model(&database.Pick{}).Count(&picks)
+	if picks != 0 {
+		t.Errorf("Expected no picks to be saved, got %d", picks)
+	}
+
+	// Before kickoff the week is still open
+	appClock.Set(sunday.Add(-time.Hour))
+	body, _ := json.Marshal([]api.PickRequest{{GameId: games[1].ID, Picked: "favorite", Rank: 1}})
+	req := httptest.NewRequest("POST", "/picks", bytes.NewBuffer(body))
+	req = req.WithContext(context.WithValue(req.Context(), auth.EmailKey, "locked@test.com"))
+	rr := httptest.NewRecorder()
+	SubmitPicks(gormDB, appClock)(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Errorf("Expected status %d before kickoff, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+}
+
+func TestSubmitPicksWithTiebreaker(t *testing.T) {
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+	home, away := homeAndAway()
+
+	user := database.User{Name: "testuser", Email: "sheet@test.com", Password: "password", Role: "user"}
+	gormDB.Create(&user)
+	sunday := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+	games := []database.Game{
+		{Week: 1, Season: 2025, HomeTeam: "Packers", AwayTeam: "Bears", Favorite: &home, Underdog: &away, Spread: 3.5, StartTime: sunday},
+		{Week: 1, Season: 2025, HomeTeam: "Chiefs", AwayTeam: "Raiders", Favorite: &home, Underdog: &away, Spread: 7, StartTime: sunday.Add(27 * time.Hour)},
+	}
+	gormDB.Create(&games)
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(sunday.Add(-time.Hour))
+	predicted, other := 45, 38
+
+	submit := func(requests []api.PickRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(requests)
+		req := httptest.NewRequest("POST", "/picks", bytes.NewBuffer(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.EmailKey, "sheet@test.com"))
+		rr := httptest.NewRecorder()
+		SubmitPicks(gormDB, appClock)(rr, req)
+		return rr
+	}
+
+	// Picks of a week that disagree on the prediction are rejected
+	rr := submit([]api.PickRequest{
+		{GameId: games[0].ID, Picked: "favorite", Rank: 1, PredictedTotal: &predicted},
+		{GameId: games[1].ID, Picked: "underdog", Rank: 2, PredictedTotal: &other},
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for conflicting predictions, got %d: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = submit([]api.PickRequest{
+		{GameId: games[0].ID, Picked: "favorite", Rank: 1},
+		{GameId: games[1].ID, Picked: "underdog", Rank: 2, PredictedTotal: &predicted},
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	// The prediction and the picks are timed by the application clock, which keeps running
+	var tiebreaker database.Tiebreaker
+	if err := gormDB.Where("user_id = ? AND season = ? AND week = ?", user.ID, 2025, 1).First(&tiebreaker).Error; err != nil {
+		t.Fatalf("Expected the prediction to be saved with the sheet: %v", err)
+	}
+	if tiebreaker.PredictedTotal != predicted || !tiebreaker.SubmittedAt.Truncate(time.Minute).Equal(sunday.Add(-time.Hour)) {
+		t.Errorf("Unexpected tiebreaker: %+v", tiebreaker)
+	}
+	var picks []database.Pick
+	gormDB.Where("user_id = ?", user.ID).Find(&picks)
+	if len(picks) != 2 {
+		t.Fatalf("Expected 2 picks, got %d", len(picks))
+	}
+	for _, pick := range picks {
+		if !pick.SubmittedAt.Truncate(time.Minute).Equal(sunday.Add(-time.Hour)) {
+			t.Errorf("Expected pick %d to be submitted at %v, got %v", pick.GameID, sunday.Add(-time.Hour), pick.SubmittedAt)
+		}
+	}
+}
+
 func TestGetPicksErrors(t *testing.T) {
 	// Set up test database
 	db, err := database.New("sqlite", "file::memory:")
@@ -221,7 +352,7 @@ func TestSubmitPicksErrors(t *testing.T) {
 			req = req.WithContext(ctx)
 
 			rr := httptest.NewRecorder()
-			handler := SubmitPicks(gormDB)
+			handler := SubmitPicks(gormDB, clock.Real{})
 
 			handler.ServeHTTP(rr, req)
 
@@ -401,7 +532,7 @@ func TestAdminSubmitPicks(t *testing.T) {
 
 	// Create a ResponseRecorder
 	rr := httptest.NewRecorder()
-	handler := AdminSubmitPicks(gormDB)
+	handler := AdminSubmitPicks(gormDB, clock.Real{})
 
 	// Call the handler
 	handler.ServeHTTP(rr, req)
@@ -735,7 +866,7 @@ func TestSubmitPicksEdgeCases(t *testing.T) {
 			req = req.WithContext(ctx)
 
 			rr := httptest.NewRecorder()
-			handler := SubmitPicks(gormDB)
+			handler := SubmitPicks(gormDB, clock.Real{})
 
 			handler.ServeHTTP(rr, req)
 
@@ -806,7 +937,7 @@ func TestAdminSubmitPicksEdgeCases(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			handler := AdminSubmitPicks(gormDB)
+			handler := AdminSubmitPicks(gormDB, clock.Real{})
 
 			handler.ServeHTTP(rr, req)
 
@@ -3,22 +3,25 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dhpollack/football-pool/internal/api"
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+	"github.com/dhpollack/football-pool/internal/seasons"
 	"gorm.io/gorm"
 )
 
-const (
-	outcomeFavorite = "favorite"
-	outcomeUnderdog = "underdog"
-	outcomePush     = "push"
-)
-
-// GetWeeklyResults handles retrieval of game results for a specific week and season.
-func GetWeeklyResults(db *gorm.DB) http.HandlerFunc {
+// GetWeeklyResults handles retrieval of every player's score for a specific week and season,
+// ranked with ties broken by the pool's tiebreakers and flagged when the player used the
+// quick pick.
+func GetWeeklyResults(db *gorm.DB, standings *seasons.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		weekStr := r.URL.Query().Get("week")
 		seasonStr := r.URL.Query().Get("season")
@@ -40,71 +43,120 @@ func GetWeeklyResults(db *gorm.DB) http.HandlerFunc {
 			return
 		}
 
-		var games []database.Game
-		if result := db.Where("week = ? AND season = ?", week, season).Find(&games); result.Error != nil {
+		weeklyStandings, err := standings.WeeklyStandings(season, week)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		gamesRemaining, err := seasons.WeekGamesRemaining(db, season, week)
+		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		var gameIDs []uint
-		for _, game := range games {
-			gameIDs = append(gameIDs, game.ID)
+		weeklyResults := make([]api.WeeklyResult, len(weeklyStandings))
+		for i, standing := range weeklyStandings {
+			weeklyResults[i] = api.WeeklyScoreToResponse(standing.WeeklyScore, gamesRemaining)
+			weeklyResults[i].PredictedTotal = standing.PredictedTotal
 		}
 
-		var results []database.Result
-		if result := db.Where("game_id IN ?", gameIDs).Find(&results); result.Error != nil {
+		if err := json.NewEncoder(w).Encode(weeklyResults); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+// liveKeepAlive is how often an idle live results stream sends a comment, so that proxies
+// keep the connection open.
+const liveKeepAlive = 30 * time.Second
+
+// GetLiveWeeklyResults handles retrieval of every player's provisional score for a week as if
+// the games in progress ended at their current score. Clients that accept server-sent events
+// receive the standings again whenever live scores or results change.
+func GetLiveWeeklyResults(standings *seasons.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		week, err := strconv.Atoi(r.URL.Query().Get("week"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		season, err := strconv.Atoi(r.URL.Query().Get("season"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
-		resultsMap := make(map[uint]database.Result)
-		for _, result := range results {
-			resultsMap[result.GameID] = result
+		if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			streamLiveWeeklyResults(w, r, standings, season, week)
+			return
 		}
 
-		var picks []database.Pick
-		if result := db.Where("game_id IN ?", gameIDs).Find(&picks); result.Error != nil {
+		live, err := standings.LiveStandings(season, week)
+		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(api.LiveWeekToResponse(*live)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
 
-		playerScores := make(map[uint]float32)
-		for _, pick := range picks {
-			result, ok := resultsMap[pick.GameID]
-			if !ok {
-				continue
-			}
+// streamLiveWeeklyResults sends the live standings of a week as a standings event, and again
+// after every change until the client disconnects.
+func streamLiveWeeklyResults(w http.ResponseWriter, r *http.Request, standings *seasons.Service, season, week int) {
+	controller := http.NewResponseController(w)
+	// The stream outlives the server's write timeout
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 
-			if (pick.Picked == outcomeFavorite && result.Outcome == outcomeFavorite) || (pick.Picked == outcomeUnderdog && result.Outcome == outcomeUnderdog) {
-				playerScores[pick.UserID] += float32(pick.Rank)
-			} else if result.Outcome == outcomePush {
-				playerScores[pick.UserID] += float32(pick.Rank) / 2
-			}
-		}
+	changes, unsubscribe := standings.SubscribeLive(season, week)
+	defer unsubscribe()
 
-		type WeeklyResult struct {
-			PlayerID   uint    `json:"player_id"`
-			PlayerName string  `json:"player_name"`
-			Score      float32 `json:"score"`
-		}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	keepAlive := time.NewTicker(liveKeepAlive)
+	defer keepAlive.Stop()
 
-		var weeklyResults []WeeklyResult
-		for userID, score := range playerScores {
-			var user database.User
-			if result := db.First(&user, userID); result.Error != nil {
-				continue
+	for changed := true; ; {
+		if changed {
+			live, err := standings.LiveStandings(season, week)
+			if err != nil {
+				slog.Error("Failed to compute live standings", "season", season, "week", week, "error", err)
+				return
 			}
-			weeklyResults = append(weeklyResults, WeeklyResult{PlayerID: userID, PlayerName: user.Name, Score: score})
+			data, err := json.Marshal(api.LiveWeekToResponse(*live))
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: standings\ndata: %s\n\n", data); err != nil {
+				return
+			}
+		} else if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+			return
+		}
+		if err := controller.Flush(); err != nil {
+			return
 		}
 
-		if err := json.NewEncoder(w).Encode(weeklyResults); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+		select {
+		case <-r.Context().Done():
+			return
+		case <-changes:
+			changed = true
+		case <-keepAlive.C:
+			changed = false
 		}
 	}
 }
 
-// SubmitResult handles submission of game results (admin only).
-func SubmitResult(db *gorm.DB) http.HandlerFunc {
+// SubmitResult handles submission of game results (admin only). The standings of the game's
+// week are updated once the result is stored.
+func SubmitResult(db *gorm.DB, standings *seasons.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var result database.Result
 		if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
@@ -118,19 +170,13 @@ func SubmitResult(db *gorm.DB) http.HandlerFunc {
 			return
 		}
 
-		switch {
-		case float32(result.FavoriteScore)-game.Spread > float32(result.UnderdogScore):
-			result.Outcome = outcomeFavorite
-		case float32(result.UnderdogScore) > float32(result.FavoriteScore)-game.Spread:
-			result.Outcome = outcomeUnderdog
-		default:
-			result.Outcome = outcomePush
-		}
+		result.Outcome = scoring.Outcome(game.Spread, result.FavoriteScore, result.UnderdogScore)
 
 		if dbResult := db.Create(&result); dbResult.Error != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		standings.ResultsChanged(r.Context(), game.Season, game.Week)
 
 		response := api.ResultToResponse(result)
 
@@ -139,8 +185,19 @@ func SubmitResult(db *gorm.DB) http.HandlerFunc {
 	}
 }
 
-// GetSeasonResults handles retrieval of season-wide results and standings.
-func GetSeasonResults(db *gorm.DB) http.HandlerFunc {
+// GetSeasonResults handles retrieval of the ranked season standings of every player.
+// Postseason games count toward them when the playoff mode is confidence.
+func GetSeasonResults(db *gorm.DB, playoffMode string) http.HandlerFunc {
+	return seasonResults(db, database.PoolSeason, playoffMode)
+}
+
+// GetPlayoffResults handles retrieval of the separate playoff pool standings.
+func GetPlayoffResults(db *gorm.DB, playoffMode string) http.HandlerFunc {
+	return seasonResults(db, database.PoolPlayoffs, playoffMode)
+}
+
+// seasonResults returns a handler that lists the ranked standings of a pool.
+func seasonResults(db *gorm.DB, pool, playoffMode string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		seasonStr := r.URL.Query().Get("season")
 
@@ -155,61 +212,21 @@ func GetSeasonResults(db *gorm.DB) http.HandlerFunc {
 			return
 		}
 
-		var games []database.Game
-		if result := db.Where("season = ?", season).Find(&games); result.Error != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-
-		var gameIDs []uint
-		for _, game := range games {
-			gameIDs = append(gameIDs, game.ID)
-		}
-
-		var results []database.Result
-		if result := db.Where("game_id IN ?", gameIDs).Find(&results); result.Error != nil {
+		standings, err := seasons.SeasonStandings(db, season, pool)
+		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		resultsMap := make(map[uint]database.Result)
-		for _, result := range results {
-			resultsMap[result.GameID] = result
-		}
-
-		var picks []database.Pick
-		if result := db.Where("game_id IN ?", gameIDs).Find(&picks); result.Error != nil {
+		gamesRemaining, err := seasons.PoolGamesRemaining(db, season, seasons.PoolSeasonTypes(pool, playoffMode))
+		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		playerScores := make(map[uint]float32)
-		for _, pick := range picks {
-			result, ok := resultsMap[pick.GameID]
-			if !ok {
-				continue
-			}
-
-			if (pick.Picked == outcomeFavorite && result.Outcome == outcomeFavorite) || (pick.Picked == outcomeUnderdog && result.Outcome == outcomeUnderdog) {
-				playerScores[pick.UserID] += float32(pick.Rank)
-			} else if result.Outcome == outcomePush {
-				playerScores[pick.UserID] += float32(pick.Rank) / 2
-			}
-		}
-
-		type SeasonResult struct {
-			PlayerID   uint    `json:"player_id"`
-			PlayerName string  `json:"player_name"`
-			Score      float32 `json:"score"`
-		}
-
-		var seasonResults []SeasonResult
-		for userID, score := range playerScores {
-			var user database.User
-			if result := db.First(&user, userID); result.Error != nil {
-				continue
-			}
-			seasonResults = append(seasonResults, SeasonResult{PlayerID: userID, PlayerName: user.Name, Score: score})
+		seasonResults := make([]api.SeasonResult, len(standings))
+		for i, standing := range standings {
+			seasonResults[i] = api.SeasonStandingToResponse(standing, gamesRemaining)
 		}
 
 		if err := json.NewEncoder(w).Encode(seasonResults); err != nil {
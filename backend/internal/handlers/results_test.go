@@ -1,17 +1,37 @@
 package handlers
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/dhpollack/football-pool/internal/api"
 	"github.com/dhpollack/football-pool/internal/auth"
+	"github.com/dhpollack/football-pool/internal/config"
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+	"github.com/dhpollack/football-pool/internal/seasons"
 )
 
+// newStandingsService creates a season service that materializes standings under classic
+// scoring and the given playoff mode.
+func newStandingsService(t *testing.T, db *database.Database, playoffMode string) *seasons.Service {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Pool.PlayoffMode = playoffMode
+	service, err := seasons.NewService(db, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create season service: %v", err)
+	}
+	return service
+}
+
 func TestSubmitResult(t *testing.T) {
 	// Set up test database
 	db, err := database.New("sqlite", "file::memory:?cache=shared")
@@ -24,8 +44,9 @@ func TestSubmitResult(t *testing.T) {
 	// Create an admin user and a game
 	admin := database.User{Email: "admin@test.com", Password: "password", Role: "admin"}
 	gormDB.Create(&admin)
-	game := database.Game{Week: 1, Season: 2023, HomeTeam: "Lions", AwayTeam: "Chiefs", Spread: 3.5, Favorite: &home, Underdog: &away}
+	game := database.Game{Week: 1, Season: 2022, HomeTeam: "Lions", AwayTeam: "Chiefs", Spread: 3.5, Favorite: &home, Underdog: &away}
 	gormDB.Create(&game)
+	gormDB.Create(&database.Pick{UserID: admin.ID, GameID: game.ID, Picked: "favorite", Rank: 8})
 
 	// Create the result to submit
 	result := database.Result{GameID: game.ID, FavoriteScore: 21, UnderdogScore: 17}
@@ -41,7 +62,7 @@ func TestSubmitResult(t *testing.T) {
 
 	// Create a ResponseRecorder
 	rr := httptest.NewRecorder()
-	handler := SubmitResult(gormDB)
+	handler := SubmitResult(gormDB, newStandingsService(t, db, config.PlayoffModeNone))
 
 	// Call the handler
 	handler.ServeHTTP(rr, req)
@@ -59,11 +80,20 @@ func TestSubmitResult(t *testing.T) {
 		t.Errorf("handler returned unexpected body: got %v want %v",
 			dbResult.Outcome, "favorite")
 	}
+
+	// Check that the week's standings were updated
+	var score database.WeeklyScore
+	if err := gormDB.Where("season = ? AND week = ? AND user_id = ?", 2022, 1, admin.ID).First(&score).Error; err != nil {
+		t.Fatalf("Expected a weekly score: %v", err)
+	}
+	if score.Points != 8 || score.Rank != 1 || score.SeasonPoints != 8 {
+		t.Errorf("Unexpected weekly score: %+v", score)
+	}
 }
 
 func TestGetWeeklyResults(t *testing.T) {
 	// Set up test database
-	db, err := database.New("sqlite", "file::memory:?cache=shared")
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
 	if err != nil {
 		t.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -75,6 +105,9 @@ func TestGetWeeklyResults(t *testing.T) {
 	gormDB.Create(&user1)
 	user2 := database.User{Name: "User 2", Email: "user2@test.com", Password: "password"}
 	gormDB.Create(&user2)
+	// User 3 makes no picks
+	user3 := database.User{Name: "User 3", Email: "user5@test.com", Password: "password"}
+	gormDB.Create(&user3)
 
 	game1 := database.Game{Week: 1, Season: 2023, HomeTeam: "Lions", AwayTeam: "Chiefs", Spread: 3.5, Favorite: &home, Underdog: &away}
 	gormDB.Create(&game1)
@@ -83,7 +116,7 @@ func TestGetWeeklyResults(t *testing.T) {
 
 	pick1 := database.Pick{UserID: user1.ID, GameID: game1.ID, Picked: "favorite", Rank: 16}
 	gormDB.Create(&pick1)
-	pick2 := database.Pick{UserID: user1.ID, GameID: game2.ID, Picked: "underdog", Rank: 1}
+	pick2 := database.Pick{UserID: user1.ID, GameID: game2.ID, Picked: "underdog", Rank: 1, QuickPick: true}
 	gormDB.Create(&pick2)
 	pick3 := database.Pick{UserID: user2.ID, GameID: game1.ID, Picked: "underdog", Rank: 10}
 	gormDB.Create(&pick3)
@@ -95,6 +128,14 @@ func TestGetWeeklyResults(t *testing.T) {
 	result2 := database.Result{GameID: game2.ID, FavoriteScore: 34, UnderdogScore: 10, Outcome: "favorite"}
 	gormDB.Create(&result2)
 
+	// User 2 predicts the tiebreaker game
+	gormDB.Create(&database.Tiebreaker{UserID: user2.ID, Season: 2023, Week: 1, PredictedTotal: 45})
+
+	standings := newStandingsService(t, db, config.PlayoffModeNone)
+	if err := standings.Recompute(2023); err != nil {
+		t.Fatalf("Failed to recompute standings: %v", err)
+	}
+
 	// Create a request with the week and season as query parameters
 	req, err := http.NewRequest("GET", "/results/weekly?week=1&season=2023", nil)
 	if err != nil {
@@ -103,7 +144,7 @@ func TestGetWeeklyResults(t *testing.T) {
 
 	// Create a ResponseRecorder
 	rr := httptest.NewRecorder()
-	handler := GetWeeklyResults(gormDB)
+	handler := GetWeeklyResults(gormDB, standings)
 
 	// Call the handler
 	handler.ServeHTTP(rr, req)
@@ -115,42 +156,117 @@ func TestGetWeeklyResults(t *testing.T) {
 	}
 
 	// Check the response body
-	type WeeklyResult struct {
-		PlayerID   uint    `json:"player_id"`
-		PlayerName string  `json:"player_name"`
-		Score      float32 `json:"score"`
+	var weeklyResults []api.WeeklyResult
+	if err := json.NewDecoder(rr.Body).Decode(&weeklyResults); err != nil {
+		t.Fatal(err)
 	}
 
-	var weeklyResults []WeeklyResult
-	if err := json.NewDecoder(rr.Body).Decode(&weeklyResults); err != nil {
+	predictedTotal := 45
+	expected := []api.WeeklyResult{
+		{PlayerId: user1.ID, PlayerName: "User 1", Score: 16, Rank: 1, SeasonRank: 1, Correct: 1, Incorrect: 1, MaxPoints: 16, QuickPick: true},
+		{PlayerId: user2.ID, PlayerName: "User 2", Score: 5, Rank: 2, SeasonRank: 2, Correct: 1, Incorrect: 1, MaxPoints: 5, PredictedTotal: &predictedTotal},
+		{PlayerId: user3.ID, PlayerName: "User 3", Score: 0, Rank: 3, SeasonRank: 3},
+	}
+	if len(weeklyResults) != len(expected) {
+		t.Fatalf("handler returned unexpected number of results: got %v want %v", len(weeklyResults), len(expected))
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(weeklyResults[i], expected[i]) {
+			t.Errorf("handler returned unexpected result %d: got %+v want %+v", i, weeklyResults[i], expected[i])
+		}
+	}
+}
+
+func TestGetLiveWeeklyResults(t *testing.T) {
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+	home, away := homeAndAway()
+
+	user := database.User{Name: "User 1", Email: "user1@test.com", Password: "password"}
+	gormDB.Create(&user)
+	// The favorite leads by 4 but does not cover yet
+	game := database.Game{Week: 1, Season: 2023, HomeTeam: "Lions", AwayTeam: "Chiefs", Spread: 6.5, Favorite: &home, Underdog: &away, State: database.GameStateInProgress, HomeScore: 14, AwayScore: 10, Period: 2, Clock: "1:52"}
+	gormDB.Create(&game)
+	gormDB.Create(&database.Pick{UserID: user.ID, GameID: game.ID, Picked: scoring.SideUnderdog, Rank: 8})
+
+	standings := newStandingsService(t, db, config.PlayoffModeNone)
+	handler := GetLiveWeeklyResults(standings)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/results/week/live?week=1&season=2023", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	var live api.LiveWeekResponse
+	if err := json.NewDecoder(rr.Body).Decode(&live); err != nil {
 		t.Fatal(err)
 	}
+	if len(live.Games) != 1 || live.Games[0].State != api.GameInProgress || *live.Games[0].Outcome != scoring.SideUnderdog {
+		t.Errorf("Unexpected live games: %+v", live.Games)
+	}
+	expected := api.LivePlayerResult{PlayerId: user.ID, PlayerName: "User 1", Rank: 1, Score: 8, PointsAtRisk: 8, MaxPoints: 8, Correct: 1}
+	if len(live.Players) != 1 || live.Players[0] != expected {
+		t.Errorf("Unexpected live players: got %+v want %+v", live.Players, expected)
+	}
 
-	if len(weeklyResults) != 2 {
-		t.Errorf("handler returned unexpected number of results: got %v want %v",
-			len(weeklyResults), 2)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/results/week/live?week=1", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d without a season, got %d", http.StatusBadRequest, rr.Code)
 	}
 
-	// Check the scores for each user
-	for _, result := range weeklyResults {
-		switch result.PlayerName {
-		case "User 1":
-			if result.Score != 16 {
-				t.Errorf("handler returned wrong score for User 1: got %v want %v",
-					result.Score, 16)
-			}
-		case "User 2":
-			if result.Score != 5 {
-				t.Errorf("handler returned wrong score for User 2: got %v want %v",
-					result.Score, 5)
+	// Streams send the standings again when the live scores change
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"?week=1&season=2023", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %q", contentType)
+	}
+
+	events := bufio.NewScanner(resp.Body)
+	nextEvent := func() api.LiveWeekResponse {
+		t.Helper()
+		var event api.LiveWeekResponse
+		for events.Scan() {
+			if data, ok := strings.CutPrefix(events.Text(), "data: "); ok {
+				if err := json.Unmarshal([]byte(data), &event); err != nil {
+					t.Fatal(err)
+				}
+				return event
 			}
 		}
+		t.Fatalf("Stream ended: %v", events.Err())
+		return event
+	}
+
+	if event := nextEvent(); event.Players[0].Score != 8 {
+		t.Errorf("Unexpected first event: %+v", event.Players)
+	}
+	// The favorite pulls ahead and covers
+	gormDB.Model(&game).Updates(database.Game{HomeScore: 21, Period: 3})
+	standings.LiveScoresChanged(ctx, 2023, 1)
+	if event := nextEvent(); event.Players[0].Score != 0 || event.Players[0].MaxPoints != 8 || event.Games[0].HomeScore != 21 {
+		t.Errorf("Unexpected event after the score changed: %+v", event)
 	}
 }
 
 func TestGetSeasonResults(t *testing.T) {
 	// Set up test database
-	db, err := database.New("sqlite", "file::memory:?cache=shared")
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
 	if err != nil {
 		t.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -167,6 +283,8 @@ func TestGetSeasonResults(t *testing.T) {
 	gormDB.Create(&game1)
 	game2 := database.Game{Week: 2, Season: 2024, HomeTeam: "Eagles", AwayTeam: "Patriots", Spread: 7.5, Favorite: &home, Underdog: &away}
 	gormDB.Create(&game2)
+	game3 := database.Game{Week: 3, Season: 2024, HomeTeam: "Bears", AwayTeam: "Vikings", Spread: 1.5, Favorite: &home, Underdog: &away}
+	gormDB.Create(&game3)
 
 	pick1 := database.Pick{UserID: user1.ID, GameID: game1.ID, Picked: "favorite", Rank: 16}
 	gormDB.Create(&pick1)
@@ -176,12 +294,18 @@ func TestGetSeasonResults(t *testing.T) {
 	gormDB.Create(&pick3)
 	pick4 := database.Pick{UserID: user2.ID, GameID: game2.ID, Picked: "favorite", Rank: 5}
 	gormDB.Create(&pick4)
+	// The third week is still to be played
+	gormDB.Create(&database.Pick{UserID: user2.ID, GameID: game3.ID, Picked: "favorite", Rank: 7})
 
 	result1 := database.Result{GameID: game1.ID, FavoriteScore: 21, UnderdogScore: 17, Outcome: "favorite"}
 	gormDB.Create(&result1)
 	result2 := database.Result{GameID: game2.ID, FavoriteScore: 34, UnderdogScore: 10, Outcome: "favorite"}
 	gormDB.Create(&result2)
 
+	if err := newStandingsService(t, db, config.PlayoffModeNone).Recompute(2024); err != nil {
+		t.Fatalf("Failed to recompute standings: %v", err)
+	}
+
 	// Create a request with the season as query parameters
 	req, err := http.NewRequest("GET", "/results/season?season=2024", nil)
 	if err != nil {
@@ -190,7 +314,7 @@ func TestGetSeasonResults(t *testing.T) {
 
 	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
 	rr := httptest.NewRecorder()
-	handler := GetSeasonResults(gormDB)
+	handler := GetSeasonResults(gormDB, config.PlayoffModeNone)
 
 	// Our handlers satisfy http.Handler, so we can call their ServeHTTP method
 	// directly and pass in our Request and ResponseRecorder.
@@ -203,35 +327,21 @@ func TestGetSeasonResults(t *testing.T) {
 	}
 
 	// Check the response body is what we expect.
-	type SeasonResult struct {
-		PlayerID   uint    `json:"player_id"`
-		PlayerName string  `json:"player_name"`
-		Score      float32 `json:"score"`
-	}
-
-	var seasonResults []SeasonResult
+	var seasonResults []api.SeasonResult
 	if err := json.NewDecoder(rr.Body).Decode(&seasonResults); err != nil {
 		t.Fatal(err)
 	}
 
-	if len(seasonResults) != 2 {
-		t.Errorf("handler returned unexpected number of results: got %v want %v",
-			len(seasonResults), 2)
+	expected := []api.SeasonResult{
+		{PlayerId: user1.ID, PlayerName: "User 1", Score: 16, Rank: 1, Correct: 1, Incorrect: 1, GamesRemaining: 1, MaxPoints: 16},
+		{PlayerId: user2.ID, PlayerName: "User 2", Score: 5, Rank: 2, Correct: 1, Incorrect: 1, GamesRemaining: 1, MaxPoints: 12},
 	}
-
-	// Check the scores for each user
-	for _, result := range seasonResults {
-		switch result.PlayerName {
-		case "User 1":
-			if result.Score != 16 {
-				t.Errorf("handler returned wrong score for User 1: got %v want %v",
-					result.Score, 16)
-			}
-		case "User 2":
-			if result.Score != 5 {
-				t.Errorf("handler returned wrong score for User 2: got %v want %v",
-					result.Score, 5)
-			}
+	if len(seasonResults) != len(expected) {
+		t.Fatalf("handler returned unexpected number of results: got %v want %v", len(seasonResults), len(expected))
+	}
+	for i := range expected {
+		if seasonResults[i] != expected[i] {
+			t.Errorf("handler returned unexpected result %d: got %+v want %+v", i, seasonResults[i], expected[i])
 		}
 	}
 }
@@ -279,7 +389,7 @@ func TestGetWeeklyResultsErrors(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			handler := GetWeeklyResults(gormDB)
+			handler := GetWeeklyResults(gormDB, newStandingsService(t, db, config.PlayoffModeNone))
 
 			handler.ServeHTTP(rr, req)
 
@@ -329,7 +439,7 @@ func TestSubmitResultErrors(t *testing.T) {
 			req = req.WithContext(ctx)
 
 			rr := httptest.NewRecorder()
-			handler := SubmitResult(gormDB)
+			handler := SubmitResult(gormDB, newStandingsService(t, db, config.PlayoffModeNone))
 
 			handler.ServeHTTP(rr, req)
 
@@ -374,7 +484,7 @@ func TestGetSeasonResultsErrors(t *testing.T) {
 			}
 
 			rr := httptest.NewRecorder()
-			handler := GetSeasonResults(gormDB)
+			handler := GetSeasonResults(gormDB, config.PlayoffModeNone)
 
 			handler.ServeHTTP(rr, req)
 
@@ -385,3 +495,90 @@ func TestGetSeasonResultsErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPlayoffResults(t *testing.T) {
+	// Set up test database
+	db, err := database.New("sqlite", "file::memory:")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+	home, away := homeAndAway()
+
+	user := database.User{Name: "Playoff User", Email: "playoffs@test.com", Password: "password"}
+	gormDB.Create(&user)
+
+	regular := database.Game{Week: 18, Season: 2025, HomeTeam: "Lions", AwayTeam: "Packers", Favorite: &home, Underdog: &away}
+	gormDB.Create(&regular)
+	wildCard := database.Game{Week: database.WildCardWeek, Season: 2025, SeasonType: database.SeasonTypePostseason, HomeTeam: "Eagles", AwayTeam: "Rams", Favorite: &home, Underdog: &away}
+	gormDB.Create(&wildCard)
+
+	gormDB.Create(&database.Pick{UserID: user.ID, GameID: regular.ID, Picked: "favorite", Rank: 10})
+	gormDB.Create(&database.Pick{UserID: user.ID, GameID: wildCard.ID, Picked: "favorite", Rank: 4})
+	gormDB.Create(&database.Result{GameID: regular.ID, FavoriteScore: 30, UnderdogScore: 20, Outcome: "favorite"})
+	gormDB.Create(&database.Result{GameID: wildCard.ID, FavoriteScore: 28, UnderdogScore: 21, Outcome: "favorite"})
+
+	type SeasonResult struct {
+		PlayerID   uint    `json:"player_id"`
+		PlayerName string  `json:"player_name"`
+		Score      float32 `json:"score"`
+	}
+
+	tests := []struct {
+		name          string
+		playoffMode   string
+		handler       http.HandlerFunc
+		expectedScore float32
+	}{
+		{
+			name:          "Season results exclude playoffs",
+			playoffMode:   config.PlayoffModeSeparate,
+			handler:       GetSeasonResults(gormDB, config.PlayoffModeSeparate),
+			expectedScore: 10,
+		},
+		{
+			name:          "Season results include playoffs",
+			playoffMode:   config.PlayoffModeConfidence,
+			handler:       GetSeasonResults(gormDB, config.PlayoffModeConfidence),
+			expectedScore: 14,
+		},
+		{
+			name:          "Playoff results",
+			playoffMode:   config.PlayoffModeSeparate,
+			handler:       GetPlayoffResults(gormDB, config.PlayoffModeSeparate),
+			expectedScore: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newStandingsService(t, db, tt.playoffMode).Recompute(2025); err != nil {
+				t.Fatalf("Failed to recompute standings: %v", err)
+			}
+
+			req, err := http.NewRequest("GET", "/results/season?season=2025", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != http.StatusOK {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+			}
+
+			var seasonResults []SeasonResult
+			if err := json.NewDecoder(rr.Body).Decode(&seasonResults); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(seasonResults) != 1 {
+				t.Fatalf("handler returned unexpected number of results: got %v want %v", len(seasonResults), 1)
+			}
+			if seasonResults[0].Score != tt.expectedScore {
+				t.Errorf("handler returned wrong score: got %v want %v", seasonResults[0].Score, tt.expectedScore)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// ListScheduleChanges handles listing the schedule changes detected by the ESPN sync, most
+// recent first. The alerts filter limits the list to unacknowledged changes to games with picks.
+func ListScheduleChanges(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		query := db.Preload("Game").Order("detected_at DESC, id DESC")
+		if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+			season, err := strconv.Atoi(seasonStr)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid season"})
+				return
+			}
+			query = query.Where("season = ?", season)
+		}
+		if alertsStr := r.URL.Query().Get("alerts"); alertsStr != "" {
+			alerts, err := strconv.ParseBool(alertsStr)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid alerts filter"})
+				return
+			}
+			if alerts {
+				query = query.Where("picks_attached > 0 AND acknowledged_at IS NULL")
+			}
+		}
+
+		var changes []database.ScheduleChange
+		if err := query.Find(&changes).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch schedule changes"})
+			return
+		}
+
+		response := api.ScheduleChangeListResponse{Changes: make([]api.ScheduleChangeResponse, len(changes))}
+		for i, change := range changes {
+			response.Changes[i] = api.ScheduleChangeToResponse(change)
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// AcknowledgeScheduleChange handles acknowledging a schedule change, which clears its alert.
+// Acknowledging a change again keeps the time it was first acknowledged.
+func AcknowledgeScheduleChange(db *gorm.DB, timeProvider clock.Clock) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		id, err := strconv.ParseUint(extractPathParam(r, "id"), 10, 32)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid schedule change ID"})
+			return
+		}
+
+		var change database.ScheduleChange
+		if err := db.Preload("Game").First(&change, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Schedule change not found"})
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch schedule change"})
+			}
+			return
+		}
+
+		if change.AcknowledgedAt == nil {
+			now := timeProvider.Now()
+			if err := db.Model(&change).Update("acknowledged_at", now).Error; err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to acknowledge schedule change"})
+				return
+			}
+			change.AcknowledgedAt = &now
+		}
+
+		_ = json.NewEncoder(w).Encode(api.ScheduleChangeToResponse(change))
+	}
+}
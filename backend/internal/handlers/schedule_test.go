@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// setupScheduleChangesTest creates a database with the schedule changes of a game.
+func setupScheduleChangesTest(t *testing.T) (*gorm.DB, []database.ScheduleChange) {
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+
+	kickoff := time.Date(2025, 12, 14, 18, 0, 0, 0, time.UTC)
+	game := database.Game{Week: 16, Season: 2025, HomeTeam: "Kansas City Chiefs", AwayTeam: "Los Angeles Chargers", StartTime: kickoff.AddDate(0, 0, 8)}
+	if err := gormDB.Create(&game).Error; err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	changes := []database.ScheduleChange{
+		{GameID: game.ID, Season: 2025, PreviousWeek: 15, Week: 15, PreviousStartTime: kickoff, StartTime: kickoff.Add(3 * time.Hour), DetectedAt: kickoff.AddDate(0, 0, -7)},
+		{GameID: game.ID, Season: 2025, PreviousWeek: 15, Week: 16, PreviousStartTime: kickoff.Add(3 * time.Hour), StartTime: game.StartTime, PicksAttached: 4, DetectedAt: kickoff},
+	}
+	if err := gormDB.Create(&changes).Error; err != nil {
+		t.Fatalf("Failed to create schedule changes: %v", err)
+	}
+	return gormDB, changes
+}
+
+func TestListScheduleChanges(t *testing.T) {
+	gormDB, changes := setupScheduleChangesTest(t)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedIDs    []uint
+	}{
+		{"all changes", "", http.StatusOK, []uint{changes[1].ID, changes[0].ID}},
+		{"alerts", "?alerts=true", http.StatusOK, []uint{changes[1].ID}},
+		{"other season", "?season=2024", http.StatusOK, nil},
+		{"invalid season", "?season=last", http.StatusBadRequest, nil},
+		{"invalid alerts", "?alerts=maybe", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ListScheduleChanges(gormDB)(w, httptest.NewRequest("GET", "/api/admin/schedule-changes"+tt.query, nil))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var response api.ScheduleChangeListResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(response.Changes) != len(tt.expectedIDs) {
+				t.Fatalf("Expected %d changes, got %d", len(tt.expectedIDs), len(response.Changes))
+			}
+			for i, id := range tt.expectedIDs {
+				if response.Changes[i].Id != id {
+					t.Errorf("Change %d has ID %d, want %d", i, response.Changes[i].Id, id)
+				}
+			}
+		})
+	}
+}
+
+func TestAcknowledgeScheduleChange(t *testing.T) {
+	gormDB, changes := setupScheduleChangesTest(t)
+	alert := changes[1]
+
+	req := createRequestWithPathParams("POST", "/api/admin/schedule-changes/2/acknowledge", nil, map[string]string{"id": "2"})
+	w := httptest.NewRecorder()
+	AcknowledgeScheduleChange(gormDB, clock.Real{})(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response api.ScheduleChangeResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Id != alert.ID || response.Alert || response.AcknowledgedAt == nil || response.HomeTeam != "Kansas City Chiefs" {
+		t.Errorf("Unexpected response: %+v", response)
+	}
+
+	var count int64
+	gormDB.Model(&database.ScheduleChange{}).Where("picks_attached > 0 AND acknowledged_at IS NULL").Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no open alerts, got %d", count)
+	}
+
+	req = createRequestWithPathParams("POST", "/api/admin/schedule-changes/99/acknowledge", nil, map[string]string{"id": "99"})
+	w = httptest.NewRecorder()
+	AcknowledgeScheduleChange(gormDB, clock.Real{})(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/seasons"
+	"gorm.io/gorm"
+)
+
+// ListSeasons handles listing all seasons, newest first, with the current season.
+// configuredSeason is the current season until seasons are recorded.
+func ListSeasons(db *gorm.DB, configuredSeason int) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var records []database.Season
+		if result := db.Order("year DESC").Find(&records); result.Error != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch seasons"})
+			return
+		}
+
+		current, err := database.CurrentSeason(db, configuredSeason)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch current season"})
+			return
+		}
+
+		response := api.SeasonListResponse{
+			Seasons:       make([]api.SeasonResponse, len(records)),
+			CurrentSeason: current,
+		}
+		for i, season := range records {
+			response.Seasons[i] = api.SeasonToResponse(season)
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// GetSeasonStandings handles retrieval of the standings of a pool in a season. Complete
+// seasons return their archived final standings; other seasons return their materialized
+// standings under the configured playoff mode.
+func GetSeasonStandings(db *gorm.DB, playoffMode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		season, ok := pathSeason(w, r, db)
+		if !ok {
+			return
+		}
+
+		pool := r.URL.Query().Get("pool")
+		if pool == "" {
+			pool = database.PoolSeason
+		}
+		if pool != database.PoolSeason && pool != database.PoolPlayoffs {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid pool"})
+			return
+		}
+
+		response := api.SeasonStandingsResponse{
+			Season:    season.Year,
+			Pool:      api.SeasonStandingsResponsePool(pool),
+			Archived:  season.State == database.SeasonStateComplete,
+			Standings: []api.StandingResponse{},
+		}
+
+		if response.Archived {
+			var archived []database.ArchivedStanding
+			if result := db.Where("season = ? AND pool = ?", season.Year, pool).Order("rank, player_name").Find(&archived); result.Error != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch standings"})
+				return
+			}
+			for _, standing := range archived {
+				response.Standings = append(response.Standings, api.ArchivedStandingToResponse(standing))
+			}
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		if seasons.PoolSeasonTypes(pool, playoffMode) == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Playoffs are not a separate pool"})
+			return
+		}
+
+		standings, err := seasons.SeasonStandings(db, season.Year, pool)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch standings"})
+			return
+		}
+		for _, standing := range standings {
+			response.Standings = append(response.Standings, api.StandingResponse{
+				PlayerId:   standing.UserID,
+				PlayerName: standing.PlayerName,
+				Score:      standing.Points,
+				Rank:       standing.Rank,
+			})
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// GetSeasonSurvivorResults handles retrieval of the survivor results of a season. Complete
+// seasons return their archived results.
+func GetSeasonSurvivorResults(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		season, ok := pathSeason(w, r, db)
+		if !ok {
+			return
+		}
+
+		response := api.SeasonSurvivorResponse{
+			Season:   season.Year,
+			Archived: season.State == database.SeasonStateComplete,
+			Results:  []api.SurvivorResultResponse{},
+		}
+
+		if response.Archived {
+			var archived []database.ArchivedSurvivorResult
+			if result := db.Where("season = ?", season.Year).Order("user_id, week").Find(&archived); result.Error != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch survivor results"})
+				return
+			}
+			for _, result := range archived {
+				response.Results = append(response.Results, api.ArchivedSurvivorResultToResponse(result))
+			}
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		results, err := seasons.SurvivorResults(db, season.Year)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to compute survivor results"})
+			return
+		}
+		for _, result := range results {
+			response.Results = append(response.Results, api.SurvivorResultResponse{
+				PlayerId:   result.UserID,
+				PlayerName: result.PlayerName,
+				Week:       result.Week,
+				Team:       result.Team,
+				Outcome:    api.SurvivorResultResponseOutcome(result.Outcome),
+			})
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// pathSeason loads the season record named by the year path parameter, writing an error
+// response and returning false when it is invalid or unknown.
+func pathSeason(w http.ResponseWriter, r *http.Request, db *gorm.DB) (database.Season, bool) {
+	var season database.Season
+
+	year, err := strconv.Atoi(extractPathParam(r, "year"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid season"})
+		return season, false
+	}
+
+	if err := db.Where("year = ?", year).First(&season).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Season not found"})
+			return season, false
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch season"})
+		return season, false
+	}
+	return season, true
+}
+
+// querySeason returns the season query parameter, or the current season when it is absent.
+func querySeason(r *http.Request, db *gorm.DB, configuredSeason int) (int, error) {
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		return strconv.Atoi(seasonStr)
+	}
+	return database.CurrentSeason(db, configuredSeason)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+)
+
+// setupSeasonsTest creates a complete 2024 season with archived results and an active 2025
+// season with a scored game.
+func setupSeasonsTest(t *testing.T) *database.Database {
+	t.Helper()
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+
+	user := database.User{Name: "Alice", Email: "alice@test.com", Password: "password", Role: "user"}
+	gormDB.Create(&user)
+
+	gormDB.Create(&[]database.Season{
+		{Year: 2024, State: database.SeasonStateComplete},
+		{Year: 2025, State: database.SeasonStateActive},
+	})
+	gormDB.Create(&database.ArchivedStanding{Season: 2024, Pool: database.PoolSeason, UserID: user.ID, PlayerName: "Alice", Score: 42.5, Rank: 1})
+	gormDB.Create(&database.ArchivedSurvivorResult{Season: 2024, UserID: user.ID, Week: 1, PlayerName: "Alice", Team: "Lions", Outcome: "win"})
+
+	game := database.Game{Week: 1, Season: 2025, SeasonType: database.SeasonTypeRegular, HomeTeam: "Detroit Lions", AwayTeam: "Green Bay Packers"}
+	gormDB.Create(&game)
+	gormDB.Create(&database.Pick{UserID: user.ID, GameID: game.ID, Picked: scoring.SideFavorite, Rank: 3})
+	gormDB.Create(&database.Result{GameID: game.ID, FavoriteScore: 28, UnderdogScore: 17, Outcome: scoring.SideFavorite})
+	gormDB.Create(&database.SurvivorPick{UserID: user.ID, Season: 2025, Week: 1, Team: "Packers"})
+
+	if err := newStandingsService(t, db, config.PlayoffModeSeparate).Recompute(2025); err != nil {
+		t.Fatalf("Failed to recompute standings: %v", err)
+	}
+
+	return db
+}
+
+func TestListSeasons(t *testing.T) {
+	db := setupSeasonsTest(t)
+
+	w := httptest.NewRecorder()
+	ListSeasons(db.GetDB(), 2023)(w, httptest.NewRequest("GET", "/api/seasons", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response api.SeasonListResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.CurrentSeason != 2025 || len(response.Seasons) != 2 || response.Seasons[0].Year != 2025 || response.Seasons[1].State != api.SeasonComplete {
+		t.Errorf("Unexpected seasons: %+v", response)
+	}
+}
+
+func TestGetSeasonStandings(t *testing.T) {
+	db := setupSeasonsTest(t)
+
+	tests := []struct {
+		name           string
+		year           string
+		query          string
+		playoffMode    string
+		expectedStatus int
+		archived       bool
+		score          float32
+	}{
+		{name: "archived season", year: "2024", playoffMode: config.PlayoffModeSeparate, expectedStatus: http.StatusOK, archived: true, score: 42.5},
+		{name: "materialized season", year: "2025", playoffMode: config.PlayoffModeSeparate, expectedStatus: http.StatusOK, score: 3},
+		{name: "separate playoff pool", year: "2025", query: "?pool=playoffs", playoffMode: config.PlayoffModeSeparate, expectedStatus: http.StatusOK},
+		{name: "no playoff pool", year: "2025", query: "?pool=playoffs", playoffMode: config.PlayoffModeConfidence, expectedStatus: http.StatusNotFound},
+		{name: "invalid pool", year: "2025", query: "?pool=weekly", playoffMode: config.PlayoffModeSeparate, expectedStatus: http.StatusBadRequest},
+		{name: "invalid season", year: "last", playoffMode: config.PlayoffModeSeparate, expectedStatus: http.StatusBadRequest},
+		{name: "unknown season", year: "2020", playoffMode: config.PlayoffModeSeparate, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := createRequestWithPathParams("GET", "/api/seasons/"+tt.year+"/standings"+tt.query, nil, map[string]string{"year": tt.year})
+			w := httptest.NewRecorder()
+			GetSeasonStandings(db.GetDB(), tt.playoffMode)(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var response api.SeasonStandingsResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Archived != tt.archived {
+				t.Errorf("Expected archived %v, got %v", tt.archived, response.Archived)
+			}
+			// Every player is listed, even without points in the pool
+			if len(response.Standings) != 1 || response.Standings[0].Score != tt.score || response.Standings[0].Rank != 1 {
+				t.Errorf("Unexpected standings: %+v", response.Standings)
+			}
+		})
+	}
+}
+
+func TestGetSeasonSurvivorResults(t *testing.T) {
+	db := setupSeasonsTest(t)
+
+	tests := []struct {
+		year     string
+		archived bool
+		team     string
+		outcome  api.SurvivorResultResponseOutcome
+	}{
+		{year: "2024", archived: true, team: "Lions", outcome: api.SurvivorWin},
+		{year: "2025", team: "Packers", outcome: api.SurvivorLoss},
+	}
+
+	for _, tt := range tests {
+		req := createRequestWithPathParams("GET", "/api/seasons/"+tt.year+"/survivor", nil, map[string]string{"year": tt.year})
+		w := httptest.NewRecorder()
+		GetSeasonSurvivorResults(db.GetDB())(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var response api.SeasonSurvivorResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Archived != tt.archived || len(response.Results) != 1 || response.Results[0].Team != tt.team || response.Results[0].Outcome != tt.outcome {
+			t.Errorf("Unexpected survivor results of %s: %+v", tt.year, response)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/odds-sync"
+	"gorm.io/gorm"
+)
+
+// maxSpreadsUpload limits the size of an uploaded spreads file.
+const maxSpreadsUpload = 1 << 20
+
+// matchedSpread is a spread entry matched to one of the week's games.
+type matchedSpread struct {
+	game     *database.Game
+	favorite string
+	spread   float32
+}
+
+// BulkUpdateWeekSpreads handles setting the pool lines of a week's games from a list of
+// entries or an uploaded CSV file. Matched entries are applied in a single transaction and
+// lock the pool line, so the odds provider no longer updates it. Entries that match no game
+// are reported back instead of failing the request.
+func BulkUpdateWeekSpreads(db *gorm.DB, timeProvider clock.Clock) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		season, week, ok := extractSeasonAndWeek(w, r)
+		if !ok {
+			return
+		}
+
+		lines, err := readSpreadLines(r)
+		if err != nil {
+			message := err.Error()
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid spreads", Message: &message})
+			return
+		}
+
+		var games []database.Game
+		if err := db.Where("season = ? AND week = ?", season, week).Order("start_time").Find(&games).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch games"})
+			return
+		}
+
+		matched, unmatched := matchSpreadLines(lines, games, database.NewTeamResolver(db))
+
+		now := timeProvider.Now()
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, match := range matched {
+				game := match.game
+				underdog := "Away"
+				if match.favorite == "Away" {
+					underdog = "Home"
+				}
+				game.Spread = match.spread
+				game.Favorite = &match.favorite
+				game.Underdog = &underdog
+				game.SpreadStrategy = config.OddsProviderManual
+				game.SpreadBookmakers = ""
+				if game.SpreadLockedAt == nil {
+					game.SpreadLockedAt = &now
+				}
+				if err := tx.Save(game).Error; err != nil {
+					return err
+				}
+
+				snapshot := database.SpreadSnapshot{
+					GameID:    game.ID,
+					Strategy:  config.OddsProviderManual,
+					Spread:    match.spread,
+					Favorite:  match.favorite,
+					FetchedAt: now,
+				}
+				if err := tx.Create(&snapshot).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to update spreads"})
+			return
+		}
+
+		response := api.BulkSpreadsResponse{
+			Updated:   make([]api.GameResponse, len(matched)),
+			Unmatched: unmatched,
+		}
+		for i, match := range matched {
+			response.Updated[i] = api.GameToResponse(*match.game)
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// readSpreadLines reads the spread entries from a JSON body, a CSV body or an uploaded CSV file.
+func readSpreadLines(r *http.Request) ([]oddssync.SpreadLine, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "application/json"
+	}
+
+	switch mediaType {
+	case "text/csv":
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxSpreadsUpload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body: %w", err)
+		}
+		return oddssync.ParseSpreadLinesCSV(data)
+	case "multipart/form-data":
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("missing spreads file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+
+		data, err := io.ReadAll(io.LimitReader(file, maxSpreadsUpload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spreads file: %w", err)
+		}
+		return oddssync.ParseSpreadLinesCSV(data)
+	default:
+		var request api.BulkSpreadsRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		lines := make([]oddssync.SpreadLine, len(request.Entries))
+		for i, entry := range request.Entries {
+			lines[i] = oddssync.SpreadLine{
+				HomeTeam: entry.HomeTeam,
+				AwayTeam: entry.AwayTeam,
+				Spread:   entry.Spread,
+			}
+			if entry.Favorite != nil {
+				lines[i].Favorite = *entry.Favorite
+			}
+		}
+		return lines, nil
+	}
+}
+
+// resolveEntryTeam resolves a team named by a spread entry. Names that do not resolve
+// to a single registered team are matched against the games by name.
+func resolveEntryTeam(resolver *database.TeamResolver, name string) *database.Team {
+	team, err := resolver.Resolve(config.OddsProviderManual, name)
+	if err != nil {
+		return &database.Team{DisplayName: name}
+	}
+	return team
+}
+
+// matchSpreadLines matches spread entries to games by their team names, in either order.
+// Team names are resolved to the registered teams through their aliases. Entries that are
+// invalid, ambiguous, match no game or repeat a game are returned as unmatched.
+func matchSpreadLines(lines []oddssync.SpreadLine, games []database.Game, resolver *database.TeamResolver) ([]matchedSpread, []api.UnmatchedSpreadEntryResponse) {
+	matched := []matchedSpread{}
+	unmatched := []api.UnmatchedSpreadEntryResponse{}
+	entered := make(map[uint]bool)
+
+	for i, line := range lines {
+		reject := func(reason string) {
+			unmatched = append(unmatched, api.UnmatchedSpreadEntryResponse{
+				Row:      i + 1,
+				HomeTeam: line.HomeTeam,
+				AwayTeam: line.AwayTeam,
+				Reason:   reason,
+			})
+		}
+
+		if line.Spread < 0 {
+			reject("spread must not be negative")
+			continue
+		}
+
+		first := resolveEntryTeam(resolver, line.HomeTeam)
+		second := resolveEntryTeam(resolver, line.AwayTeam)
+
+		var candidates []*database.Game
+		for j := range games {
+			game := &games[j]
+			if (database.TeamMatches(first, game.HomeTeamID, game.HomeTeam) && database.TeamMatches(second, game.AwayTeamID, game.AwayTeam)) ||
+				(database.TeamMatches(first, game.AwayTeamID, game.AwayTeam) && database.TeamMatches(second, game.HomeTeamID, game.HomeTeam)) {
+				candidates = append(candidates, game)
+			}
+		}
+		switch {
+		case len(candidates) == 0:
+			reject("no game found for the teams")
+			continue
+		case len(candidates) > 1:
+			reject("the teams match more than one game")
+			continue
+		}
+		game := candidates[0]
+
+		favorite, err := spreadFavorite(line, game, resolver)
+		if err != nil {
+			reject(err.Error())
+			continue
+		}
+		if entered[game.ID] {
+			reject("the game already has an entry")
+			continue
+		}
+		entered[game.ID] = true
+
+		matched = append(matched, matchedSpread{game: game, favorite: favorite, spread: line.Spread})
+	}
+	return matched, unmatched
+}
+
+// spreadFavorite resolves the favorite of an entry to "Home" or "Away" for its game. A
+// favorite of "Home" or "Away" refers to the entry's teams, which may be listed in the
+// opposite order to the game's. Even spreads default to the home team as favorite.
+func spreadFavorite(line oddssync.SpreadLine, game *database.Game, resolver *database.TeamResolver) (string, error) {
+	name := line.Favorite
+	switch {
+	case strings.EqualFold(name, "Home"):
+		name = line.HomeTeam
+	case strings.EqualFold(name, "Away"):
+		name = line.AwayTeam
+	case name == "" && line.Spread == 0:
+		return "Home", nil
+	}
+
+	favorite := resolveEntryTeam(resolver, name)
+	home := database.TeamMatches(favorite, game.HomeTeamID, game.HomeTeam)
+	away := database.TeamMatches(favorite, game.AwayTeamID, game.AwayTeam)
+	switch {
+	case home && !away:
+		return "Home", nil
+	case away && !home:
+		return "Away", nil
+	default:
+		return "", fmt.Errorf("favorite %q is neither team", line.Favorite)
+	}
+}
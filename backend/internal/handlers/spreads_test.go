@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// setupSpreadsTest creates a database with the games of a week.
+func setupSpreadsTest(t *testing.T) *gorm.DB {
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+
+	kickoff := time.Date(2025, 9, 14, 17, 0, 0, 0, time.UTC)
+	games := []database.Game{
+		{Week: 2, Season: 2025, HomeTeam: "Green Bay Packers", AwayTeam: "Washington Commanders", StartTime: kickoff},
+		{Week: 2, Season: 2025, HomeTeam: "New York Giants", AwayTeam: "Dallas Cowboys", StartTime: kickoff},
+		{Week: 2, Season: 2025, HomeTeam: "New York Jets", AwayTeam: "Buffalo Bills", StartTime: kickoff},
+	}
+	if err := gormDB.Create(&games).Error; err != nil {
+		t.Fatalf("Failed to create games: %v", err)
+	}
+	return gormDB
+}
+
+func bulkSpreadsRequest(body *bytes.Buffer, contentType string) *http.Request {
+	req := createRequestWithPathParams("PUT", "/api/admin/weeks/2025/2/spreads", body, map[string]string{"season": "2025", "week": "2"})
+	req.Header.Set("Content-Type", contentType)
+	return req
+}
+
+func TestBulkUpdateWeekSpreads(t *testing.T) {
+	gormDB := setupSpreadsTest(t)
+
+	favorite := func(s string) *string { return &s }
+	request := api.BulkSpreadsRequest{Entries: []api.BulkSpreadEntry{
+		{HomeTeam: "Packers", AwayTeam: "Washington", Favorite: favorite("Home"), Spread: 3.5},
+		// Abbreviations are not recognized
+		{HomeTeam: "Dallas", AwayTeam: "N.Y. Giants", Favorite: favorite("Cowboys"), Spread: 6.5},
+		// The teams are listed in the opposite order to the game
+		{HomeTeam: "Dallas", AwayTeam: "Giants", Favorite: favorite("Cowboys"), Spread: 6},
+		{HomeTeam: "Packers", AwayTeam: "Commanders", Favorite: favorite("Home"), Spread: 4},
+		{HomeTeam: "Chicago Bears", AwayTeam: "Detroit Lions", Favorite: favorite("Away"), Spread: 1},
+		{HomeTeam: "Jets", AwayTeam: "Bills", Favorite: favorite("Dolphins"), Spread: 2.5},
+	}}
+	body, _ := json.Marshal(request)
+
+	w := httptest.NewRecorder()
+	BulkUpdateWeekSpreads(gormDB, clock.Real{})(w, bulkSpreadsRequest(bytes.NewBuffer(body), "application/json"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response api.BulkSpreadsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Updated) != 2 {
+		t.Fatalf("Expected 2 updated games, got %d: %+v", len(response.Updated), response.Updated)
+	}
+
+	var rows []int
+	for _, entry := range response.Unmatched {
+		rows = append(rows, entry.Row)
+	}
+	if len(rows) != 4 || rows[0] != 2 || rows[1] != 4 || rows[2] != 5 || rows[3] != 6 {
+		t.Errorf("Unexpected unmatched rows: %+v", response.Unmatched)
+	}
+
+	var game database.Game
+	if err := gormDB.Where("favorite_team = ?", "Green Bay Packers").First(&game).Error; err != nil {
+		t.Fatalf("Failed to load game: %v", err)
+	}
+	if game.Spread != 3.5 || game.Favorite == nil || *game.Favorite != "Home" || game.SpreadStrategy != config.OddsProviderManual || game.SpreadLockedAt == nil {
+		t.Errorf("Unexpected pool line: spread %v, favorite %v, strategy %q, locked %v", game.Spread, game.Favorite, game.SpreadStrategy, game.SpreadLockedAt)
+	}
+
+	var swapped database.Game
+	if err := gormDB.Where("favorite_team = ?", "New York Giants").First(&swapped).Error; err != nil {
+		t.Fatalf("Failed to load game: %v", err)
+	}
+	if swapped.Spread != 6 || swapped.Favorite == nil || *swapped.Favorite != "Away" {
+		t.Errorf("Unexpected pool line: spread %v, favorite %v", swapped.Spread, swapped.Favorite)
+	}
+
+	var snapshots int64
+	gormDB.Model(&database.SpreadSnapshot{}).Where("game_id = ?", game.ID).Count(&snapshots)
+	if snapshots != 1 {
+		t.Errorf("Expected 1 spread snapshot, got %d", snapshots)
+	}
+}
+
+func TestBulkUpdateWeekSpreads_CSV(t *testing.T) {
+	csv := "home_team,away_team,favorite,spread\n" +
+		"Green Bay Packers,Washington Commanders,Washington Commanders,1.5\n" +
+		"Cowboys,Giants,Away,6\n"
+
+	multipartBody := &bytes.Buffer{}
+	writer := multipart.NewWriter(multipartBody)
+	part, err := writer.CreateFormFile("file", "week-2.csv")
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	_, _ = part.Write([]byte(csv))
+	_ = writer.Close()
+
+	tests := []struct {
+		name        string
+		body        *bytes.Buffer
+		contentType string
+	}{
+		{"csv body", bytes.NewBufferString(csv), "text/csv"},
+		{"uploaded file", multipartBody, writer.FormDataContentType()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDB := setupSpreadsTest(t)
+
+			w := httptest.NewRecorder()
+			BulkUpdateWeekSpreads(gormDB, clock.Real{})(w, bulkSpreadsRequest(tt.body, tt.contentType))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+
+			var response api.BulkSpreadsResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(response.Updated) != 2 || len(response.Unmatched) != 0 {
+				t.Fatalf("Unexpected response: %+v", response)
+			}
+
+			// The Giants are the away team of the entry but the home team of the game
+			var game database.Game
+			if err := gormDB.Where("favorite_team = ?", "New York Giants").First(&game).Error; err != nil {
+				t.Fatalf("Failed to load game: %v", err)
+			}
+			if game.Spread != 6 || game.Favorite == nil || *game.Favorite != "Home" {
+				t.Errorf("Unexpected pool line: spread %v, favorite %v", game.Spread, game.Favorite)
+			}
+		})
+	}
+}
+
+func TestBulkUpdateWeekSpreads_InvalidRequest(t *testing.T) {
+	gormDB := setupSpreadsTest(t)
+
+	w := httptest.NewRecorder()
+	BulkUpdateWeekSpreads(gormDB, clock.Real{})(w, bulkSpreadsRequest(bytes.NewBufferString("home_team,spread\nPackers,3\n"), "text/csv"))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -11,8 +11,9 @@ import (
 	"gorm.io/gorm"
 )
 
-// GetSurvivorPicks handles retrieval of survivor pool picks for the current user.
-func GetSurvivorPicks(db *gorm.DB) http.HandlerFunc {
+// GetSurvivorPicks handles retrieval of survivor pool picks for the current user in a season,
+// the current season by default.
+func GetSurvivorPicks(db *gorm.DB, configuredSeason int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		email := r.Context().Value(auth.EmailKey).(string)
 
@@ -22,8 +23,14 @@ func GetSurvivorPicks(db *gorm.DB) http.HandlerFunc {
 			return
 		}
 
+		season, err := querySeason(r, db, configuredSeason)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		var survivorPicks []database.SurvivorPick
-		if result := db.Where("user_id = ?", user.ID).Find(&survivorPicks); result.Error != nil {
+		if result := db.Where("user_id = ? AND season = ?", user.ID, season).Find(&survivorPicks); result.Error != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -39,8 +46,9 @@ func GetSurvivorPicks(db *gorm.DB) http.HandlerFunc {
 	}
 }
 
-// SubmitSurvivorPick handles submission of survivor pool picks.
-func SubmitSurvivorPick(db *gorm.DB) http.HandlerFunc {
+// SubmitSurvivorPick handles submission of survivor pool picks. Picks without a season are
+// made for the current season.
+func SubmitSurvivorPick(db *gorm.DB, configuredSeason int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		email := r.Context().Value(auth.EmailKey).(string)
 
@@ -57,6 +65,14 @@ func SubmitSurvivorPick(db *gorm.DB) http.HandlerFunc {
 		}
 
 		pick.UserID = user.ID
+		if pick.Season == 0 {
+			season, err := database.CurrentSeason(db, configuredSeason)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			pick.Season = season
+		}
 
 		if result := db.Create(&pick); result.Error != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -25,7 +25,7 @@ func TestGetSurvivorPicks(t *testing.T) {
 	gormDB.Create(&user)
 
 	// Create a survivor pick for the user
-	pick := database.SurvivorPick{UserID: user.ID, Week: 1, Team: "Lions"}
+	pick := database.SurvivorPick{UserID: user.ID, Season: 2025, Week: 1, Team: "Lions"}
 	gormDB.Create(&pick)
 
 	// Create a request with the user's email in the context
@@ -38,7 +38,7 @@ func TestGetSurvivorPicks(t *testing.T) {
 
 	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
 	rr := httptest.NewRecorder()
-	handler := GetSurvivorPicks(gormDB)
+	handler := GetSurvivorPicks(gormDB, 2025)
 
 	// Our handlers satisfy http.Handler, so we can call their ServeHTTP method
 	// directly and pass in our Request and ResponseRecorder.
@@ -93,7 +93,7 @@ func TestSubmitSurvivorPick(t *testing.T) {
 
 	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
 	rr := httptest.NewRecorder()
-	handler := SubmitSurvivorPick(gormDB)
+	handler := SubmitSurvivorPick(gormDB, 2025)
 
 	// Our handlers satisfy http.Handler, so we can call their ServeHTTP method
 	// directly and pass in our Request and ResponseRecorder.
@@ -112,6 +112,12 @@ func TestSubmitSurvivorPick(t *testing.T) {
 		t.Errorf("handler returned unexpected body: got %v want %v",
 			dbPick.Team, "Packers")
 	}
+
+	// Picks without a season are made for the current season
+	if dbPick.Season != 2025 {
+		t.Errorf("handler returned unexpected season: got %v want %v",
+			dbPick.Season, 2025)
+	}
 }
 
 func TestGetSurvivorPicksErrors(t *testing.T) {
@@ -144,7 +150,7 @@ func TestGetSurvivorPicksErrors(t *testing.T) {
 			req = req.WithContext(ctx)
 
 			rr := httptest.NewRecorder()
-			handler := GetSurvivorPicks(gormDB)
+			handler := GetSurvivorPicks(gormDB, 2025)
 
 			handler.ServeHTTP(rr, req)
 
@@ -197,7 +203,7 @@ func TestSubmitSurvivorPickErrors(t *testing.T) {
 			req = req.WithContext(ctx)
 
 			rr := httptest.NewRecorder()
-			handler := SubmitSurvivorPick(gormDB)
+			handler := SubmitSurvivorPick(gormDB, 2025)
 
 			handler.ServeHTTP(rr, req)
 
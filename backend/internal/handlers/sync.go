@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/database"
+	espnsync "github.com/dhpollack/football-pool/internal/espn-sync"
+	"github.com/dhpollack/football-pool/internal/odds-sync"
+	"gorm.io/gorm"
+)
+
+// GetSyncStatus handles reporting the ESPN sync status of a season, the current season by default.
+func GetSyncStatus(syncService *espnsync.SyncService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if !syncAvailable(w, syncService) {
+			return
+		}
+
+		season := syncService.CurrentSeason()
+		if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+			var err error
+			season, err = strconv.Atoi(seasonStr)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid season"})
+				return
+			}
+		}
+
+		status, err := syncService.GetSyncStatus(season)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch sync status"})
+			return
+		}
+
+		response := api.SyncStatusResponse{
+			Enabled:       status.Enabled,
+			LastAttemptAt: status.LastAttemptAt,
+			LastSuccessAt: status.LastSuccessAt,
+			NextRunAt:     status.NextRunAt,
+			Weeks:         make([]api.WeekSyncStatusResponse, len(status.Weeks)),
+			Upstreams:     make([]api.UpstreamStatsResponse, len(status.Upstreams)),
+
+			ScheduleAlerts: status.ScheduleAlerts,
+			Live:           status.Live,
+		}
+		if status.LastError != "" {
+			response.LastError = &status.LastError
+		}
+		for i, week := range status.Weeks {
+			response.Weeks[i] = api.WeekSyncStatusToResponse(week)
+		}
+		for i, stats := range status.Upstreams {
+			response.Upstreams[i] = api.UpstreamStatsToResponse(stats)
+		}
+		if status.OddsQuota != nil {
+			quota := api.OddsQuotaToResponse(*status.OddsQuota)
+			response.OddsQuota = &quota
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// SyncWeek handles syncing a week from ESPN on demand.
+func SyncWeek(syncService *espnsync.SyncService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if !syncAvailable(w, syncService) {
+			return
+		}
+
+		season, week, ok := extractSeasonAndWeek(w, r)
+		if !ok {
+			return
+		}
+
+		if err := syncService.RefreshWeek(r.Context(), season, week); err != nil {
+			message := err.Error()
+			w.WriteHeader(http.StatusBadGateway)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to sync week", Message: &message})
+			return
+		}
+
+		status, err := syncService.GetWeekSyncStatus(season, week)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch sync status"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(api.WeekSyncStatusToResponse(*status))
+	}
+}
+
+// RefreshWeekSpreads handles fetching the latest spreads for a week and returns the week's games.
+func RefreshWeekSpreads(db *gorm.DB, syncService *espnsync.SyncService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if !syncAvailable(w, syncService) {
+			return
+		}
+
+		season, week, ok := extractSeasonAndWeek(w, r)
+		if !ok {
+			return
+		}
+
+		if err := syncService.RefreshSpreads(r.Context(), season, week); err != nil {
+			message := err.Error()
+			if errors.Is(err, oddssync.ErrManualSpreads) {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Spreads are entered manually", Message: &message})
+				return
+			}
+			w.WriteHeader(http.StatusBadGateway)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to refresh spreads", Message: &message})
+			return
+		}
+
+		var games []database.Game
+		if err := db.Where("season = ? AND week = ?", season, week).Order("start_time").Find(&games).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch games"})
+			return
+		}
+
+		response := make([]api.GameResponse, len(games))
+		for i, game := range games {
+			response[i] = api.GameToResponse(game)
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// ListUnmatchedSpreads handles listing the odds events of a week that matched none of its
+// games in the latest spreads update.
+func ListUnmatchedSpreads(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		season, week, ok := extractSeasonAndWeek(w, r)
+		if !ok {
+			return
+		}
+
+		var unmatched []database.UnmatchedSpread
+		if err := db.Where("season = ? AND week = ?", season, week).Order("id").Find(&unmatched).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch unmatched spreads"})
+			return
+		}
+
+		response := api.UnmatchedSpreadListResponse{Unmatched: make([]api.UnmatchedSpreadResponse, len(unmatched))}
+		for i, entry := range unmatched {
+			response.Unmatched[i] = api.UnmatchedSpreadToResponse(entry)
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// GetSyncCache handles inspecting the ESPN response cache and its hit and miss counters.
+// Entries are reported as expired by the application clock, which the cache expires them by.
+func GetSyncCache(syncService *espnsync.SyncService, timeProvider clock.Clock) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if !syncAvailable(w, syncService) {
+			return
+		}
+
+		entries, err := syncService.CacheEntries()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to read cache"})
+			return
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].Season != entries[j].Season {
+				return entries[i].Season < entries[j].Season
+			}
+			return entries[i].Week < entries[j].Week
+		})
+
+		_ = json.NewEncoder(w).Encode(api.SyncCacheToResponse(syncService.CacheStats(), entries, timeProvider.Now()))
+	}
+}
+
+// ClearSyncCache handles removing every cached ESPN response, or only the expired ones.
+func ClearSyncCache(syncService *espnsync.SyncService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if !syncAvailable(w, syncService) {
+			return
+		}
+
+		expired := false
+		if expiredStr := r.URL.Query().Get("expired"); expiredStr != "" {
+			var err error
+			expired, err = strconv.ParseBool(expiredStr)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid expired filter"})
+				return
+			}
+		}
+
+		clearCache := syncService.ClearCache
+		if expired {
+			clearCache = syncService.ClearExpiredCache
+		}
+		if err := clearCache(); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to clear cache"})
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DeleteSyncCacheEntry handles removing the cached ESPN response of a week.
+func DeleteSyncCacheEntry(syncService *espnsync.SyncService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if !syncAvailable(w, syncService) {
+			return
+		}
+
+		season, week, ok := extractSeasonAndWeek(w, r)
+		if !ok {
+			return
+		}
+
+		if err := syncService.DeleteCacheEntry(season, week); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to remove cache entry"})
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// syncAvailable writes an error response if the sync service could not be initialized.
+func syncAvailable(w http.ResponseWriter, syncService *espnsync.SyncService) bool {
+	if syncService == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "ESPN sync service is not available"})
+		return false
+	}
+	return true
+}
+
+// extractSeasonAndWeek reads the season and week path parameters, writing an error response if they are invalid.
+func extractSeasonAndWeek(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	season, err := strconv.Atoi(extractPathParam(r, "season"))
+	if err != nil || season <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid season"})
+		return 0, 0, false
+	}
+
+	week, err := strconv.Atoi(extractPathParam(r, "week"))
+	if err != nil || week < 1 || week > database.SuperBowlWeek {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid week"})
+		return 0, 0, false
+	}
+
+	return season, week, true
+}
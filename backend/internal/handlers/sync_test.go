@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	espnsync "github.com/dhpollack/football-pool/internal/espn-sync"
+)
+
+// setupSyncService creates a sync service backed by a fake ESPN server serving the sample scoreboard.
+func setupSyncService(t *testing.T) (*database.Database, *espnsync.SyncService) {
+	sampleData, err := os.ReadFile("../../assets/test/scoreboard-sample.json")
+	if err != nil {
+		t.Fatalf("Failed to read sample data: %v", err)
+	}
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(sampleData)
+	}))
+	t.Cleanup(upstream.Close)
+
+	db, err := database.New("sqlite", "file::memory:")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.ESPN.BaseURL = upstream.URL
+	cfg.ESPN.CacheDir = t.TempDir()
+	cfg.ESPN.CacheExpiry = time.Hour
+	cfg.ESPN.SyncEnabled = true
+	cfg.ESPN.SeasonYear = 2025
+
+	syncService, err := espnsync.NewSyncService(db, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create sync service: %v", err)
+	}
+	return db, syncService
+}
+
+func TestSyncWeek(t *testing.T) {
+	_, syncService := setupSyncService(t)
+
+	req := createRequestWithPathParams("POST", "/api/admin/sync/weeks/2025/10", nil, map[string]string{"season": "2025", "week": "10"})
+	w := httptest.NewRecorder()
+	SyncWeek(syncService)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response api.WeekSyncStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Season != 2025 || response.Week != 10 || response.GamesCreated == 0 || response.LastSuccessAt == nil || response.LastError != nil {
+		t.Errorf("Unexpected sync status: %+v", response)
+	}
+
+	// The season status includes the synced week
+	w = httptest.NewRecorder()
+	GetSyncStatus(syncService)(w, httptest.NewRequest("GET", "/api/admin/sync/status", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var status api.SyncStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !status.Enabled || len(status.Weeks) != 1 || status.Weeks[0].Week != 10 || status.LastSuccessAt == nil {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+	if len(status.Upstreams) != 2 || status.Upstreams[0].Name != "espn" || status.Upstreams[0].Requests != 1 || status.Upstreams[0].State != api.UpstreamStatsResponseStateClosed {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+
+	// Another season has not been synced
+	w = httptest.NewRecorder()
+	GetSyncStatus(syncService)(w, httptest.NewRequest("GET", "/api/admin/sync/status?season=2024", nil))
+	status = api.SyncStatusResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(status.Weeks) != 0 {
+		t.Errorf("Expected no weeks for 2024, got %+v", status.Weeks)
+	}
+}
+
+func TestSyncWeek_InvalidParams(t *testing.T) {
+	_, syncService := setupSyncService(t)
+
+	tests := []struct {
+		name   string
+		season string
+		week   string
+	}{
+		{name: "invalid season", season: "abc", week: "1"},
+		{name: "invalid week", season: "2025", week: "abc"},
+		{name: "week out of range", season: "2025", week: "23"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := createRequestWithPathParams("POST", "/api/admin/sync/weeks/"+tt.season+"/"+tt.week, nil, map[string]string{"season": tt.season, "week": tt.week})
+			w := httptest.NewRecorder()
+			SyncWeek(syncService)(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestClearSyncCache(t *testing.T) {
+	_, syncService := setupSyncService(t)
+
+	w := httptest.NewRecorder()
+	ClearSyncCache(syncService)(w, httptest.NewRequest("DELETE", "/api/admin/sync/cache", nil))
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+}
+
+func TestSyncCache(t *testing.T) {
+	_, syncService := setupSyncService(t)
+
+	req := createRequestWithPathParams("POST", "/api/admin/sync/weeks/2025/10", nil, map[string]string{"season": "2025", "week": "10"})
+	w := httptest.NewRecorder()
+	SyncWeek(syncService)(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	GetSyncCache(syncService, clock.Real{})(w, httptest.NewRequest("GET", "/api/admin/sync/cache", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response api.SyncCacheResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Backend != config.CacheBackendFile || response.Misses != 1 || response.Sets != 1 || response.Hits != 0 {
+		t.Errorf("Unexpected cache stats: %+v", response)
+	}
+	if len(response.Entries) != 1 || response.Entries[0].Season != 2025 || response.Entries[0].Week != 10 || response.Entries[0].Events == 0 || response.Entries[0].Expired {
+		t.Fatalf("Unexpected cache entries: %+v", response.Entries)
+	}
+
+	w = httptest.NewRecorder()
+	ClearSyncCache(syncService)(w, httptest.NewRequest("DELETE", "/api/admin/sync/cache?expired=maybe", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Clearing the expired entries keeps the week
+	w = httptest.NewRecorder()
+	ClearSyncCache(syncService)(w, httptest.NewRequest("DELETE", "/api/admin/sync/cache?expired=true", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if entries, _ := syncService.CacheEntries(); len(entries) != 1 {
+		t.Errorf("Expected 1 cache entry, got %d", len(entries))
+	}
+
+	req = createRequestWithPathParams("DELETE", "/api/admin/sync/cache/2025/10", nil, map[string]string{"season": "2025", "week": "10"})
+	w = httptest.NewRecorder()
+	DeleteSyncCacheEntry(syncService)(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if entries, _ := syncService.CacheEntries(); len(entries) != 0 {
+		t.Errorf("Expected no cache entries, got %d", len(entries))
+	}
+}
+
+func TestSyncHandlers_Unavailable(t *testing.T) {
+	handlers := map[string]http.HandlerFunc{
+		"status":  GetSyncStatus(nil),
+		"week":    SyncWeek(nil),
+		"spreads": RefreshWeekSpreads(nil, nil),
+		"cache":   ClearSyncCache(nil),
+		"inspect": GetSyncCache(nil, clock.Real{}),
+		"entry":   DeleteSyncCacheEntry(nil),
+	}
+
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest("POST", "/api/admin/sync", nil))
+
+			if w.Code != http.StatusServiceUnavailable {
+				t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+			}
+		})
+	}
+}
+
+func TestListUnmatchedSpreads(t *testing.T) {
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+
+	fetchedAt := time.Date(2025, 9, 8, 12, 0, 0, 0, time.UTC)
+	unmatched := []database.UnmatchedSpread{
+		{Season: 2025, Week: 2, Provider: config.OddsProviderTheOddsAPI, EventID: "abc", HomeTeam: "Chicago Bears", AwayTeam: "Detroit Lions", Reason: "no game found for the teams", FetchedAt: fetchedAt},
+		{Season: 2025, Week: 3, Provider: config.OddsProviderTheOddsAPI, HomeTeam: "Dallas Cowboys", AwayTeam: "New York Giants", Reason: "no game found for the teams", FetchedAt: fetchedAt},
+	}
+	if err := gormDB.Create(&unmatched).Error; err != nil {
+		t.Fatalf("Failed to create unmatched spreads: %v", err)
+	}
+
+	req := createRequestWithPathParams("GET", "/api/admin/sync/weeks/2025/2/spreads/unmatched", nil, map[string]string{"season": "2025", "week": "2"})
+	w := httptest.NewRecorder()
+	ListUnmatchedSpreads(gormDB)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response api.UnmatchedSpreadListResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Unmatched) != 1 || response.Unmatched[0].EventId == nil || *response.Unmatched[0].EventId != "abc" {
+		t.Errorf("Unexpected unmatched spreads: %+v", response.Unmatched)
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// teamAliasProviders are the data sources a team alias can be recorded for.
+var teamAliasProviders = map[string]bool{
+	database.TeamSourceESPN:       true,
+	config.OddsProviderTheOddsAPI: true,
+	config.OddsProviderFile:       true,
+	config.OddsProviderManual:     true,
+}
+
+// ListTeams handles listing the registered teams with the names each data source uses for them.
+func ListTeams(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var teams []database.Team
+		if err := db.Preload("Aliases").Order("display_name").Find(&teams).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch teams"})
+			return
+		}
+
+		teamResponses := make([]api.TeamResponse, len(teams))
+		for i, team := range teams {
+			teamResponses[i] = api.TeamToResponse(team)
+		}
+		_ = json.NewEncoder(w).Encode(api.TeamListResponse{Teams: teamResponses})
+	}
+}
+
+// AddTeamAlias handles recording a name a data source uses for a team, so that its games
+// and lines resolve to the team. A name the source already uses for another team is moved.
+func AddTeamAlias(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		id, err := strconv.ParseUint(extractPathParam(r, "id"), 10, 32)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid team ID"})
+			return
+		}
+
+		var request api.TeamAliasRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid request body"})
+			return
+		}
+		if !teamAliasProviders[request.Provider] {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Unknown provider"})
+			return
+		}
+
+		var team database.Team
+		if err := db.First(&team, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Team not found"})
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch team"})
+			}
+			return
+		}
+
+		if err := database.AddTeamAlias(db, team.ID, request.Provider, request.Name); err != nil {
+			if errors.Is(err, database.ErrEmptyTeamAlias) {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Alias name is required"})
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to add alias"})
+			}
+			return
+		}
+
+		if err := db.Preload("Aliases").First(&team, team.ID).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch team"})
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(api.TeamToResponse(team))
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// setupTeamsTest creates a database with registered teams.
+func setupTeamsTest(t *testing.T) (*gorm.DB, []database.Team) {
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	teams := []database.Team{
+		{ESPNID: "9", Abbreviation: "GB", DisplayName: "Green Bay Packers", Location: "Green Bay", Name: "Packers", Color: "204e32"},
+		{ESPNID: "28", Abbreviation: "WSH", DisplayName: "Washington Commanders", Location: "Washington", Name: "Commanders"},
+	}
+	for i := range teams {
+		if err := db.UpsertTeam(&teams[i]); err != nil {
+			t.Fatalf("Failed to create team: %v", err)
+		}
+	}
+	return db.GetDB(), teams
+}
+
+func TestListTeams(t *testing.T) {
+	gormDB, _ := setupTeamsTest(t)
+
+	w := httptest.NewRecorder()
+	ListTeams(gormDB)(w, httptest.NewRequest("GET", "/api/teams", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response api.TeamListResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Teams) != 2 {
+		t.Fatalf("Expected 2 teams, got %d", len(response.Teams))
+	}
+	packers := response.Teams[0]
+	if packers.DisplayName != "Green Bay Packers" || packers.Color == nil || *packers.Color != "204e32" || packers.LogoUrl != nil {
+		t.Errorf("Unexpected team: %+v", packers)
+	}
+	if len(packers.Aliases) != 1 || packers.Aliases[0].Provider != database.TeamSourceESPN || packers.Aliases[0].Name != "green bay packers" {
+		t.Errorf("Unexpected aliases: %+v", packers.Aliases)
+	}
+}
+
+func TestAddTeamAlias(t *testing.T) {
+	gormDB, teams := setupTeamsTest(t)
+	washington := teams[1]
+
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		expectedStatus int
+	}{
+		{"adds alias", "2", `{"provider": "theoddsapi", "name": "Washington Football Team"}`, http.StatusCreated},
+		{"invalid id", "abc", `{"provider": "manual", "name": "WFT"}`, http.StatusBadRequest},
+		{"invalid body", "2", `{`, http.StatusBadRequest},
+		{"unknown provider", "2", `{"provider": "sportsbook", "name": "WFT"}`, http.StatusBadRequest},
+		{"empty name", "2", `{"provider": "manual", "name": " "}`, http.StatusBadRequest},
+		{"team not found", "99", `{"provider": "manual", "name": "WFT"}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := createRequestWithPathParams("POST", "/api/admin/teams/"+tt.id+"/aliases", bytes.NewBufferString(tt.body), map[string]string{"id": tt.id})
+			w := httptest.NewRecorder()
+			AddTeamAlias(gormDB)(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	team, err := database.NewTeamResolver(gormDB).Resolve("theoddsapi", "Washington Football Team")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if team.ID != washington.ID {
+		t.Errorf("Resolve() = %s, want %s", team.DisplayName, washington.DisplayName)
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/auth"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/seasons"
+	"gorm.io/gorm"
+)
+
+// GetTiebreaker handles retrieval of a week's tiebreaker game and the current user's
+// prediction for it, in the current season by default.
+func GetTiebreaker(db *gorm.DB, configuredSeason int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		email := r.Context().Value(auth.EmailKey).(string)
+		var user database.User
+		if err := db.Where("email = ?", email).First(&user).Error; err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "User not found"})
+			return
+		}
+
+		season, err := querySeason(r, db, configuredSeason)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid season"})
+			return
+		}
+		week, err := strconv.Atoi(r.URL.Query().Get("week"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid week"})
+			return
+		}
+
+		game, ok := tiebreakerGame(w, db, season, week)
+		if !ok {
+			return
+		}
+
+		var tiebreaker database.Tiebreaker
+		err = db.Where("user_id = ? AND season = ? AND week = ?", user.ID, season, week).First(&tiebreaker).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch tiebreaker"})
+			return
+		}
+
+		response := api.TiebreakerToResponse(game, nil)
+		if err == nil {
+			response = api.TiebreakerToResponse(game, &tiebreaker)
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// SubmitTiebreaker handles submission of the current user's predicted total points of a
+// week's tiebreaker game. A new prediction replaces the previous one until the week locks,
+// like the pick sheet it belongs to.
+// Predictions without a season are made for the current season.
+func SubmitTiebreaker(db *gorm.DB, configuredSeason int, timeProvider clock.Clock) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		email := r.Context().Value(auth.EmailKey).(string)
+		var user database.User
+		if err := db.Where("email = ?", email).First(&user).Error; err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "User not found"})
+			return
+		}
+
+		var req api.TiebreakerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Invalid request body"})
+			return
+		}
+		if req.Week == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Week is required"})
+			return
+		}
+		if req.PredictedTotal < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Predicted total cannot be negative"})
+			return
+		}
+
+		season := 0
+		if req.Season != nil {
+			season = *req.Season
+		} else {
+			current, err := database.CurrentSeason(db, configuredSeason)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to determine the current season"})
+				return
+			}
+			season = current
+		}
+
+		game, ok := tiebreakerGame(w, db, season, req.Week)
+		if !ok {
+			return
+		}
+		now := timeProvider.Now()
+		locked, err := weekLocked(db, season, req.Week, now)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to check the week status"})
+			return
+		}
+		if locked {
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Tiebreakers are locked for this week"})
+			return
+		}
+
+		tiebreaker := database.Tiebreaker{UserID: user.ID, Season: season, Week: req.Week}
+		err = db.Where(&tiebreaker).Assign(database.Tiebreaker{PredictedTotal: req.PredictedTotal, SubmittedAt: now}).FirstOrCreate(&tiebreaker).Error
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to save tiebreaker"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(api.TiebreakerToResponse(game, &tiebreaker))
+	}
+}
+
+// tiebreakerGame loads the tiebreaker game of a week, writing the error response when it
+// cannot.
+func tiebreakerGame(w http.ResponseWriter, db *gorm.DB, season, week int) (database.Game, bool) {
+	game, err := seasons.TiebreakerGame(db, season, week)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "The week has no games"})
+		return game, false
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Failed to fetch the tiebreaker game"})
+		return game, false
+	}
+	return game, true
+}
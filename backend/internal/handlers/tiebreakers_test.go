@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/auth"
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// setupTiebreakerTest creates a user and a week of games whose Monday night game kicks off
+// last, and returns the Monday night game.
+func setupTiebreakerTest(t *testing.T) (*gorm.DB, database.Game) {
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+
+	if err := gormDB.Create(&database.User{Name: "Alice", Email: "alice@test.com", Password: "password"}).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	sunday := time.Date(2025, 9, 14, 17, 0, 0, 0, time.UTC)
+	games := []database.Game{
+		{Week: 2, Season: 2025, HomeTeam: "Kansas City Chiefs", AwayTeam: "Philadelphia Eagles", StartTime: sunday},
+		{Week: 2, Season: 2025, HomeTeam: "Houston Texans", AwayTeam: "Tampa Bay Buccaneers", StartTime: sunday.Add(31 * time.Hour)},
+		{Week: 2, Season: 2025, HomeTeam: "Buffalo Bills", AwayTeam: "New York Jets", StartTime: sunday.Add(3 * time.Hour)},
+	}
+	if err := gormDB.Create(&games).Error; err != nil {
+		t.Fatalf("Failed to create games: %v", err)
+	}
+	return gormDB, games[1]
+}
+
+// tiebreakerRequest creates a request made by Alice.
+func tiebreakerRequest(method, target string, body any) *http.Request {
+	var payload bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&payload).Encode(body)
+	}
+	req := httptest.NewRequest(method, target, &payload)
+	return req.WithContext(context.WithValue(req.Context(), auth.EmailKey, "alice@test.com"))
+}
+
+func TestSubmitTiebreaker(t *testing.T) {
+	gormDB, monday := setupTiebreakerTest(t)
+	season := 2025
+	// The week's first game kicks off on Sunday, 31 hours before the tiebreaker game
+	kickoff := monday.StartTime.Add(-31 * time.Hour)
+	appClock := clock.NewAdjustable()
+	appClock.Set(kickoff.Add(-time.Hour))
+
+	tests := []struct {
+		name           string
+		body           api.TiebreakerRequest
+		expectedStatus int
+	}{
+		{"prediction", api.TiebreakerRequest{Season: &season, Week: 2, PredictedTotal: 44}, http.StatusOK},
+		{"new prediction", api.TiebreakerRequest{Season: &season, Week: 2, PredictedTotal: 51}, http.StatusOK},
+		{"negative total", api.TiebreakerRequest{Season: &season, Week: 2, PredictedTotal: -3}, http.StatusBadRequest},
+		{"missing week", api.TiebreakerRequest{Season: &season, PredictedTotal: 40}, http.StatusBadRequest},
+		{"week without games", api.TiebreakerRequest{Season: &season, Week: 3, PredictedTotal: 40}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			SubmitTiebreaker(gormDB, 2025, appClock)(w, tiebreakerRequest("POST", "/api/picks/tiebreaker/submit", tt.body))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var response api.TiebreakerResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.GameId != monday.ID || response.PredictedTotal == nil || *response.PredictedTotal != tt.body.PredictedTotal {
+				t.Errorf("Unexpected response: %+v", response)
+			}
+		})
+	}
+
+	// A new prediction replaces the previous one
+	var tiebreakers []database.Tiebreaker
+	gormDB.Find(&tiebreakers)
+	if len(tiebreakers) != 1 || tiebreakers[0].PredictedTotal != 51 || !tiebreakers[0].SubmittedAt.Before(kickoff) || tiebreakers[0].SubmittedAt.IsZero() {
+		t.Errorf("Unexpected tiebreakers: %+v", tiebreakers)
+	}
+
+	// Predictions lock with the pick sheet, once the first game of the week kicks off
+	appClock.Set(kickoff)
+	w := httptest.NewRecorder()
+	SubmitTiebreaker(gormDB, 2025, appClock)(w, tiebreakerRequest("POST", "/api/picks/tiebreaker/submit", api.TiebreakerRequest{Week: 2, PredictedTotal: 60}))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+
+	// They also lock when the week lifecycle locks the week before kickoff
+	appClock.Set(kickoff.Add(-time.Hour))
+	gormDB.Create(&database.Week{Season: 2025, WeekNumber: 2, Status: database.WeekStatusLocked})
+	w = httptest.NewRecorder()
+	SubmitTiebreaker(gormDB, 2025, appClock)(w, tiebreakerRequest("POST", "/api/picks/tiebreaker/submit", api.TiebreakerRequest{Week: 2, PredictedTotal: 60}))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+}
+
+func TestGetTiebreaker(t *testing.T) {
+	gormDB, monday := setupTiebreakerTest(t)
+
+	w := httptest.NewRecorder()
+	GetTiebreaker(gormDB, 2025)(w, tiebreakerRequest("GET", "/api/picks/tiebreaker?week=2", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response api.TiebreakerResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.GameId != monday.ID || response.Season != 2025 || response.PredictedTotal != nil {
+		t.Errorf("Unexpected response without a prediction: %+v", response)
+	}
+
+	gormDB.Create(&database.Tiebreaker{UserID: 1, Season: 2025, Week: 2, PredictedTotal: 38})
+	w = httptest.NewRecorder()
+	GetTiebreaker(gormDB, 2025)(w, tiebreakerRequest("GET", "/api/picks/tiebreaker?season=2025&week=2", nil))
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.PredictedTotal == nil || *response.PredictedTotal != 38 {
+		t.Errorf("Unexpected response with a prediction: %+v", response)
+	}
+
+	w = httptest.NewRecorder()
+	GetTiebreaker(gormDB, 2025)(w, tiebreakerRequest("GET", "/api/picks/tiebreaker", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d without a week, got %d", http.StatusBadRequest, w.Code)
+	}
+}
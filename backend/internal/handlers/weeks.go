@@ -121,6 +121,9 @@ func UpdateWeek(db *gorm.DB) http.HandlerFunc {
 		// Update week fields
 		week.WeekNumber = weekRequest.WeekNumber
 		week.Season = weekRequest.Season
+		if weekRequest.SeasonType != nil {
+			week.SeasonType = *weekRequest.SeasonType
+		}
 		week.WeekStartTime = weekRequest.WeekStartTime
 		week.WeekEndTime = weekRequest.WeekEndTime
 		week.IsActive = weekRequest.IsActive
@@ -0,0 +1,238 @@
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes when a job should next run.
+type Schedule interface {
+	// Next returns the first activation time strictly after t.
+	Next(t time.Time) time.Time
+}
+
+// ScheduleFunc adapts a function to the Schedule interface.
+type ScheduleFunc func(t time.Time) time.Time
+
+// Next calls f(t).
+func (f ScheduleFunc) Next(t time.Time) time.Time {
+	return f(t)
+}
+
+// everySchedule runs a job at a fixed interval.
+type everySchedule struct {
+	interval time.Duration
+}
+
+// Next returns the first multiple of the interval after t. Activations are aligned to the
+// interval rather than to when the scheduler started, so every instance agrees on them.
+func (e everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(e.interval).Add(e.interval)
+}
+
+// previousActivation returns the last activation of a schedule at or before t, or the zero
+// time if there is none within five years.
+func previousActivation(schedule Schedule, t time.Time) time.Time {
+	// Widen the window back from t until it contains an activation
+	window := time.Minute
+	activation := schedule.Next(t.Add(-window))
+	for activation.After(t) {
+		window *= 2
+		if window > 5*365*24*time.Hour {
+			return time.Time{}
+		}
+		activation = schedule.Next(t.Add(-window))
+	}
+	if activation.IsZero() {
+		return activation
+	}
+
+	for next := schedule.Next(activation); !next.IsZero() && !next.After(t); next = schedule.Next(activation) {
+		activation = next
+	}
+	return activation
+}
+
+// cronSchedule runs a job at the times matched by a five field cron expression.
+// Each field is a bit set of the values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny record whether the day fields were "*", which changes how
+	// they combine: if both are restricted a day matching either field is used.
+	domAny, dowAny bool
+	location       *time.Location
+}
+
+// Next returns the first minute after t matched by the expression.
+func (c cronSchedule) Next(t time.Time) time.Time {
+	t = t.In(c.location).Truncate(time.Minute).Add(time.Minute)
+
+	// Give up after five years; only impossible dates such as February 30th get there
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.location)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of month and day of week fields match t.
+func (c cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// descriptors are shorthands for common cron expressions.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a schedule specification. Supported forms are
+// "@every <duration>", the descriptors "@hourly", "@daily", "@weekly", "@monthly"
+// and "@yearly", and five field cron expressions (minute, hour, day of month, month,
+// day of week) supporting "*", ranges, lists and steps. Cron expressions are
+// evaluated in UTC unless prefixed with "CRON_TZ=<location>".
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	location := time.UTC
+
+	if strings.HasPrefix(spec, "CRON_TZ=") {
+		fields := strings.SplitN(spec, " ", 2)
+		loc, err := time.LoadLocation(strings.TrimPrefix(fields[0], "CRON_TZ="))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule time zone: %w", err)
+		}
+		location = loc
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("schedule %q has no expression", spec)
+		}
+		spec = strings.TrimSpace(fields[1])
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule interval: %w", err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("schedule interval must be positive: %s", interval)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+
+	if expression, ok := descriptors[spec]; ok {
+		spec = expression
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q must have 5 fields, got %d", spec, len(fields))
+	}
+
+	schedule := cronSchedule{
+		domAny:   fields[2] == "*",
+		dowAny:   fields[4] == "*",
+		location: location,
+	}
+
+	var err error
+	if schedule.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if schedule.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if schedule.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day of month field: %w", err)
+	}
+	if schedule.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if schedule.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid day of week field: %w", err)
+	}
+
+	// Both 0 and 7 mean Sunday
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+
+	return schedule, nil
+}
+
+// parseField parses a comma separated list of values, ranges and steps into a bit set.
+func parseField(field string, minValue, maxValue int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+
+		start, end := minValue, maxValue
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+			if end, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			start = value
+			end = value
+			// A step on a single value runs from that value to the maximum
+			if step > 1 {
+				end = maxValue
+			}
+		}
+
+		if start < minValue || end > maxValue || start > end {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, minValue, maxValue)
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
@@ -0,0 +1,154 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		spec     string
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "every interval",
+			spec:     "@every 90m",
+			from:     time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 1, 13, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "every interval aligned",
+			spec:     "@every 15m",
+			from:     time.Date(2025, 9, 1, 12, 7, 30, 0, time.UTC),
+			expected: time.Date(2025, 9, 1, 12, 15, 0, 0, time.UTC),
+		},
+		{
+			name:     "hourly",
+			spec:     "@hourly",
+			from:     time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily",
+			spec:     "@daily",
+			from:     time.Date(2025, 9, 1, 12, 30, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "step minutes",
+			spec:     "*/15 * * * *",
+			from:     time.Date(2025, 9, 1, 12, 16, 30, 0, time.UTC),
+			expected: time.Date(2025, 9, 1, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "list and range",
+			spec:     "0 9,17 * * 1-5",
+			from:     time.Date(2025, 9, 5, 18, 0, 0, 0, time.UTC), // Friday evening
+			expected: time.Date(2025, 9, 8, 9, 0, 0, 0, time.UTC),  // Monday morning
+		},
+		{
+			name:     "sunday as seven",
+			spec:     "0 13 * * 7",
+			from:     time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 7, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month or day of week",
+			spec:     "0 0 1 * 3",
+			from:     time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "time zone",
+			spec:     "CRON_TZ=America/New_York 0 23 * * 1",
+			from:     time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC), // Monday 8pm in New York
+			expected: time.Date(2025, 9, 1, 23, 0, 0, 0, newYork),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) error = %v", tt.spec, err)
+			}
+			if next := schedule.Next(tt.from); !next.Equal(tt.expected) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, next, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPreviousActivation(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		at       time.Time
+		expected time.Time
+	}{
+		{
+			name:     "every interval",
+			spec:     "@every 6h",
+			at:       time.Date(2025, 9, 1, 13, 30, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "on an activation",
+			spec:     "@hourly",
+			at:       time.Date(2025, 9, 1, 13, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 9, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "over a weekend",
+			spec:     "0 9,17 * * 1-5",
+			at:       time.Date(2025, 9, 8, 8, 0, 0, 0, time.UTC),  // Monday morning
+			expected: time.Date(2025, 9, 5, 17, 0, 0, 0, time.UTC), // Friday evening
+		},
+		{
+			name: "impossible date",
+			spec: "0 0 30 2 *",
+			at:   time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) error = %v", tt.spec, err)
+			}
+			if previous := previousActivation(schedule, tt.at); !previous.Equal(tt.expected) {
+				t.Errorf("previousActivation(%v) = %v, want %v", tt.at, previous, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every never",
+		"@every -1m",
+		"CRON_TZ=Nowhere/Special 0 0 * * *",
+	}
+
+	for _, spec := range specs {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q) expected error", spec)
+		}
+	}
+}
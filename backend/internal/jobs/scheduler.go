@@ -0,0 +1,491 @@
+// Package jobs runs named background jobs on schedules and records their run history.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm/clause"
+)
+
+// pollInterval is how often the scheduler checks for jobs that are due.
+const pollInterval = time.Second
+
+var (
+	// ErrJobNotFound is returned when a job name is not registered.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobRunning is returned when a job is already running on this or another instance.
+	ErrJobRunning = errors.New("job is already running")
+
+	// errAlreadyRan is returned when a scheduled activation has already run.
+	errAlreadyRan = errors.New("job already ran for this schedule")
+)
+
+// TimeProvider is the application clock. It is injected so that tests and E2E runs can
+// control the time.
+type TimeProvider = clock.Clock
+
+// Job describes a named unit of background work.
+type Job struct {
+	// Name uniquely identifies the job.
+	Name string
+	// Description is shown to admins.
+	Description string
+	// Schedule is a specification accepted by ParseSchedule. Jobs without a
+	// schedule only run at startup or when triggered.
+	Schedule string
+	// Cadence computes the activations in place of Schedule, for jobs whose interval
+	// depends on the application's state. Schedule then only describes it to admins.
+	Cadence Schedule
+	// RunOnStart runs the job when the scheduler starts. Startup runs happen one
+	// at a time in registration order. A job with a schedule skips its startup run
+	// when its last activation has already run.
+	RunOnStart bool
+	// Run performs the work. Returning an error fails the attempt and retries it.
+	Run func(ctx context.Context) error
+}
+
+// JobInfo describes a registered job and its latest run.
+type JobInfo struct {
+	Name        string
+	Description string
+	Schedule    string
+	NextRun     *time.Time
+	Running     bool
+	LastRun     *database.JobRun
+}
+
+// registeredJob is a job along with its parsed schedule and next activation.
+type registeredJob struct {
+	Job
+	schedule Schedule
+	next     time.Time
+}
+
+// Scheduler runs registered jobs on their schedules. Each run is recorded in the
+// database and guarded by a database lock so that only one instance runs a job at a time.
+// Locks expire by the system clock rather than the application clock, so that moving the
+// application clock does not hand a running job over to another instance.
+type Scheduler struct {
+	db           *database.Database
+	config       *config.Config
+	timeProvider TimeProvider
+	owner        string
+
+	mu      sync.Mutex
+	jobs    map[string]*registeredJob
+	names   []string
+	running map[string]bool
+	baseCtx context.Context
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler instance.
+func NewScheduler(db *database.Database, config *config.Config) *Scheduler {
+	return NewSchedulerWithTimeProvider(db, config, clock.Real{})
+}
+
+// NewSchedulerWithTimeProvider creates a new Scheduler instance with a custom time provider.
+// This is primarily for testing purposes.
+func NewSchedulerWithTimeProvider(db *database.Database, config *config.Config, timeProvider TimeProvider) *Scheduler {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &Scheduler{
+		db:           db,
+		config:       config,
+		timeProvider: timeProvider,
+		owner:        hostname + "-" + strconv.Itoa(os.Getpid()) + "-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+		jobs:         make(map[string]*registeredJob),
+		running:      make(map[string]bool),
+		baseCtx:      context.Background(),
+	}
+}
+
+// Register adds a job to the scheduler.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" {
+		return fmt.Errorf("job name is required")
+	}
+	if job.Run == nil {
+		return fmt.Errorf("job %s has no run function", job.Name)
+	}
+
+	registered := &registeredJob{Job: job}
+	switch {
+	case job.Cadence != nil:
+		registered.schedule = job.Cadence
+	case job.Schedule != "":
+		schedule, err := ParseSchedule(job.Schedule)
+		if err != nil {
+			return fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		registered.schedule = schedule
+	}
+	if registered.schedule != nil {
+		registered.next = registered.schedule.Next(s.timeProvider.Now())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.jobs[job.Name]; exists {
+		return fmt.Errorf("job %s is already registered", job.Name)
+	}
+	s.jobs[job.Name] = registered
+	s.names = append(s.names, job.Name)
+	return nil
+}
+
+// Start runs startup jobs and then scheduled jobs until the context is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	if !s.config.Jobs.Enabled {
+		slog.Info("Job scheduler is disabled")
+		return
+	}
+
+	s.mu.Lock()
+	s.baseCtx = ctx
+	var startup []*registeredJob
+	for _, name := range s.names {
+		if s.jobs[name].RunOnStart {
+			startup = append(startup, s.jobs[name])
+		}
+	}
+	s.mu.Unlock()
+
+	slog.Info("Starting job scheduler", "jobs", len(s.names))
+
+	go s.runStartupJobs(ctx, startup)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Stopping job scheduler")
+			s.wg.Wait()
+			return
+		case <-ticker.C:
+			s.runDue(ctx)
+		}
+	}
+}
+
+// runStartupJobs runs each startup job in turn, waiting for one to finish before the next.
+func (s *Scheduler) runStartupJobs(ctx context.Context, startup []*registeredJob) {
+	for _, job := range startup {
+		// A scheduled job's startup run stands in for its last activation, which another
+		// instance or this one before a restart may already have run
+		var activation time.Time
+		if job.schedule != nil {
+			activation = previousActivation(job.schedule, s.timeProvider.Now())
+		}
+		run, err := s.begin(job, database.JobTriggerStartup, activation)
+		if err != nil {
+			slog.Info("Skipping startup run", "job", job.Name, "reason", err)
+			continue
+		}
+		s.wg.Add(1)
+		s.execute(ctx, job, run)
+	}
+}
+
+// runDue starts every scheduled job whose next activation has passed.
+func (s *Scheduler) runDue(ctx context.Context) {
+	now := s.timeProvider.Now()
+
+	s.mu.Lock()
+	var due []*registeredJob
+	var scheduled []time.Time
+	for _, name := range s.names {
+		job := s.jobs[name]
+		if job.schedule == nil || job.next.IsZero() || job.next.After(now) {
+			continue
+		}
+		due = append(due, job)
+		scheduled = append(scheduled, job.next)
+		job.next = job.schedule.Next(now)
+	}
+	s.mu.Unlock()
+
+	for i, job := range due {
+		if _, err := s.launch(ctx, job, database.JobTriggerSchedule, scheduled[i]); err != nil {
+			slog.Debug("Skipping scheduled run", "job", job.Name, "reason", err)
+		}
+	}
+}
+
+// Trigger starts a job immediately and returns the new run.
+func (s *Scheduler) Trigger(name string) (*database.JobRun, error) {
+	s.mu.Lock()
+	job, ok := s.jobs[name]
+	ctx := s.baseCtx
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+
+	return s.launch(ctx, job, database.JobTriggerManual, time.Time{})
+}
+
+// Jobs returns the registered jobs in registration order.
+func (s *Scheduler) Jobs() ([]JobInfo, error) {
+	s.mu.Lock()
+	infos := make([]JobInfo, 0, len(s.names))
+	for _, name := range s.names {
+		job := s.jobs[name]
+		info := JobInfo{
+			Name:        job.Name,
+			Description: job.Description,
+			Schedule:    job.Schedule,
+			Running:     s.running[name],
+		}
+		if !job.next.IsZero() {
+			next := job.next
+			info.NextRun = &next
+		}
+		infos = append(infos, info)
+	}
+	s.mu.Unlock()
+
+	for i := range infos {
+		var run database.JobRun
+		result := s.db.GetDB().Where("job_name = ?", infos[i].Name).Order("started_at DESC").Limit(1).Find(&run)
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to load last run of job %s: %w", infos[i].Name, result.Error)
+		}
+		if result.RowsAffected > 0 {
+			infos[i].LastRun = &run
+		}
+	}
+	return infos, nil
+}
+
+// NextRun returns the next scheduled activation of a job.
+// It returns false if the job is not registered or has no schedule.
+func (s *Scheduler) NextRun(name string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[name]
+	if !ok || job.next.IsZero() {
+		return time.Time{}, false
+	}
+	return job.next, true
+}
+
+// Runs returns the most recent runs of a job, newest first.
+func (s *Scheduler) Runs(name string, limit int) ([]database.JobRun, error) {
+	s.mu.Lock()
+	_, ok := s.jobs[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+
+	var runs []database.JobRun
+	if err := s.db.GetDB().Where("job_name = ?", name).Order("started_at DESC").Limit(limit).Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to load runs of job %s: %w", name, err)
+	}
+	return runs, nil
+}
+
+// launch begins a run and executes it in the background. A copy of the run as
+// it was recorded is returned.
+func (s *Scheduler) launch(ctx context.Context, job *registeredJob, trigger string, scheduledAt time.Time) (*database.JobRun, error) {
+	run, err := s.begin(job, trigger, scheduledAt)
+	if err != nil {
+		return nil, err
+	}
+
+	started := *run
+	s.wg.Add(1)
+	go s.execute(ctx, job, run)
+	return &started, nil
+}
+
+// begin marks a job as running, takes its lock and records a new run.
+func (s *Scheduler) begin(job *registeredJob, trigger string, scheduledAt time.Time) (*database.JobRun, error) {
+	s.mu.Lock()
+	if s.running[job.Name] {
+		s.mu.Unlock()
+		return nil, ErrJobRunning
+	}
+	s.running[job.Name] = true
+	s.mu.Unlock()
+
+	run, err := s.record(job, trigger, scheduledAt)
+	if err != nil {
+		s.mu.Lock()
+		delete(s.running, job.Name)
+		s.mu.Unlock()
+		return nil, err
+	}
+	return run, nil
+}
+
+// record takes the job lock and records a new run.
+func (s *Scheduler) record(job *registeredJob, trigger string, scheduledAt time.Time) (*database.JobRun, error) {
+	acquired, err := s.acquireLock(job.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrJobRunning
+	}
+
+	// Another instance may already have run this activation and released the lock
+	if !scheduledAt.IsZero() {
+		var count int64
+		err := s.db.GetDB().Model(&database.JobRun{}).
+			Where("job_name = ? AND triggered_by IN ? AND started_at >= ?", job.Name, []string{database.JobTriggerSchedule, database.JobTriggerStartup}, scheduledAt).
+			Count(&count).Error
+		if err != nil || count > 0 {
+			s.releaseLock(job.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check previous runs of job %s: %w", job.Name, err)
+			}
+			return nil, errAlreadyRan
+		}
+	}
+
+	run := &database.JobRun{
+		JobName:     job.Name,
+		TriggeredBy: trigger,
+		Status:      database.JobRunStatusRunning,
+		StartedAt:   s.timeProvider.Now(),
+	}
+	if err := s.db.GetDB().Create(run).Error; err != nil {
+		s.releaseLock(job.Name)
+		return nil, fmt.Errorf("failed to record run of job %s: %w", job.Name, err)
+	}
+	return run, nil
+}
+
+// execute runs a job with retries and records the outcome.
+func (s *Scheduler) execute(ctx context.Context, job *registeredJob, run *database.JobRun) {
+	defer s.wg.Done()
+	defer func() {
+		s.releaseLock(job.Name)
+		s.mu.Lock()
+		delete(s.running, job.Name)
+		s.mu.Unlock()
+	}()
+
+	stopHeartbeat := s.heartbeat(job.Name)
+	defer stopHeartbeat()
+
+	slog.Info("Running job", "job", job.Name, "trigger", run.TriggeredBy)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		run.Attempts = attempt
+		err = runAttempt(ctx, job)
+		if err == nil || attempt > s.config.Jobs.MaxRetries || ctx.Err() != nil {
+			break
+		}
+
+		backoff := s.config.Jobs.RetryBackoff << (attempt - 1)
+		slog.Warn("Job attempt failed, retrying", "job", job.Name, "attempt", attempt, "backoff", backoff.String(), "error", err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	finished := s.timeProvider.Now()
+	run.FinishedAt = &finished
+	if err != nil {
+		run.Status = database.JobRunStatusFailed
+		run.Error = err.Error()
+		slog.Error("Job failed", "job", job.Name, "attempts", run.Attempts, "error", err)
+	} else {
+		run.Status = database.JobRunStatusSucceeded
+		slog.Info("Job succeeded", "job", job.Name, "attempts", run.Attempts, "duration", finished.Sub(run.StartedAt).String())
+	}
+
+	if err := s.db.GetDB().Save(run).Error; err != nil {
+		slog.Error("Failed to record job run", "job", job.Name, "error", err)
+	}
+}
+
+// runAttempt runs a job once, turning a panic into an error.
+func runAttempt(ctx context.Context, job *registeredJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+// acquireLock takes the database lock for a job, clearing it first if it has expired.
+func (s *Scheduler) acquireLock(name string) (bool, error) {
+	now := time.Now()
+	db := s.db.GetDB()
+
+	if err := db.Where("name = ? AND expires_at < ?", name, now).Delete(&database.JobLock{}).Error; err != nil {
+		return false, fmt.Errorf("failed to clear expired lock for job %s: %w", name, err)
+	}
+
+	lock := database.JobLock{Name: name, Owner: s.owner, ExpiresAt: now.Add(s.config.Jobs.LockTTL)}
+	// Nothing is inserted when another instance holds the lock
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&lock)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to acquire lock for job %s: %w", name, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// releaseLock releases the database lock for a job held by this instance.
+func (s *Scheduler) releaseLock(name string) {
+	if err := s.db.GetDB().Where("name = ? AND owner = ?", name, s.owner).Delete(&database.JobLock{}).Error; err != nil {
+		slog.Error("Failed to release job lock", "job", name, "error", err)
+	}
+}
+
+// heartbeat extends the lock for a job while it runs. The returned function stops it.
+func (s *Scheduler) heartbeat(name string) func() {
+	interval := s.config.Jobs.LockTTL / 3
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				expires := time.Now().Add(s.config.Jobs.LockTTL)
+				err := s.db.GetDB().Model(&database.JobLock{}).
+					Where("name = ? AND owner = ?", name, s.owner).
+					Update("expires_at", expires).Error
+				if err != nil {
+					slog.Warn("Failed to extend job lock", "job", name, "error", err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
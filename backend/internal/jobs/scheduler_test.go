@@ -0,0 +1,376 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// setupScheduler creates a scheduler backed by a database private to the test.
+func setupScheduler(t *testing.T) (*database.Database, *Scheduler, *clock.Adjustable) {
+	// Runs execute in goroutines, so every connection must see the same in-memory database
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.Jobs.Enabled = true
+	cfg.Jobs.LockTTL = time.Minute
+	cfg.Jobs.MaxRetries = 2
+	cfg.Jobs.RetryBackoff = time.Millisecond
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC))
+	return db, NewSchedulerWithTimeProvider(db, cfg, appClock), appClock
+}
+
+func TestScheduler_Register(t *testing.T) {
+	_, scheduler, _ := setupScheduler(t)
+	run := func(context.Context) error { return nil }
+
+	if err := scheduler.Register(Job{Name: "sync", Schedule: "@every 1h", Run: run}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := scheduler.Register(Job{Name: "sync", Run: run}); err == nil {
+		t.Error("Register() expected error for duplicate job")
+	}
+	if err := scheduler.Register(Job{Name: "invalid", Schedule: "not a schedule", Run: run}); err == nil {
+		t.Error("Register() expected error for invalid schedule")
+	}
+	if err := scheduler.Register(Job{Name: "empty"}); err == nil {
+		t.Error("Register() expected error for missing run function")
+	}
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	db, scheduler, _ := setupScheduler(t)
+
+	var calls atomic.Int32
+	if err := scheduler.Register(Job{
+		Name: "sync",
+		Run: func(context.Context) error {
+			calls.Add(1)
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	run, err := scheduler.Trigger("sync")
+	if err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	if run.Status != database.JobRunStatusRunning || run.TriggeredBy != database.JobTriggerManual {
+		t.Errorf("Unexpected run: status=%s trigger=%s", run.Status, run.TriggeredBy)
+	}
+	scheduler.wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected job to run once, ran %d times", calls.Load())
+	}
+
+	var stored database.JobRun
+	if err := db.GetDB().First(&stored, run.ID).Error; err != nil {
+		t.Fatalf("Failed to load run: %v", err)
+	}
+	if stored.Status != database.JobRunStatusSucceeded || stored.Attempts != 1 || stored.FinishedAt == nil {
+		t.Errorf("Unexpected stored run: %+v", stored)
+	}
+
+	// The lock is released once the run finishes
+	var locks int64
+	db.GetDB().Model(&database.JobLock{}).Count(&locks)
+	if locks != 0 {
+		t.Errorf("Expected lock to be released, found %d locks", locks)
+	}
+
+	if _, err := scheduler.Trigger("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Trigger() error = %v, want %v", err, ErrJobNotFound)
+	}
+}
+
+func TestScheduler_Retries(t *testing.T) {
+	db, scheduler, _ := setupScheduler(t)
+
+	var calls atomic.Int32
+	if err := scheduler.Register(Job{
+		Name: "flaky",
+		Run: func(context.Context) error {
+			if calls.Add(1) < 2 {
+				return errors.New("upstream unavailable")
+			}
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := scheduler.Register(Job{
+		Name: "broken",
+		Run: func(context.Context) error {
+			panic("boom")
+		},
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	flaky, err := scheduler.Trigger("flaky")
+	if err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	broken, err := scheduler.Trigger("broken")
+	if err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	scheduler.wg.Wait()
+
+	var stored database.JobRun
+	if err := db.GetDB().First(&stored, flaky.ID).Error; err != nil {
+		t.Fatalf("Failed to load run: %v", err)
+	}
+	if stored.Status != database.JobRunStatusSucceeded || stored.Attempts != 2 {
+		t.Errorf("Expected flaky job to succeed on attempt 2, got status=%s attempts=%d", stored.Status, stored.Attempts)
+	}
+
+	stored = database.JobRun{}
+	if err := db.GetDB().First(&stored, broken.ID).Error; err != nil {
+		t.Fatalf("Failed to load run: %v", err)
+	}
+	if stored.Status != database.JobRunStatusFailed || stored.Attempts != 3 || stored.Error != "job panicked: boom" {
+		t.Errorf("Expected broken job to fail after 3 attempts, got status=%s attempts=%d error=%q", stored.Status, stored.Attempts, stored.Error)
+	}
+}
+
+func TestScheduler_LockHeldByAnotherInstance(t *testing.T) {
+	db, scheduler, clock := setupScheduler(t)
+
+	if err := scheduler.Register(Job{Name: "sync", Run: func(context.Context) error { return nil }}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	lock := database.JobLock{Name: "sync", Owner: "other-instance", ExpiresAt: time.Now().Add(time.Minute)}
+	if err := db.GetDB().Create(&lock).Error; err != nil {
+		t.Fatalf("Failed to create lock: %v", err)
+	}
+
+	if _, err := scheduler.Trigger("sync"); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("Trigger() error = %v, want %v", err, ErrJobRunning)
+	}
+
+	// Locks expire by the system clock, whatever time the application clock shows
+	clock.Set(clock.Now().Add(2 * time.Minute))
+	if _, err := scheduler.Trigger("sync"); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("Trigger() error = %v, want %v", err, ErrJobRunning)
+	}
+
+	// Expired locks are taken over
+	if err := db.GetDB().Model(&lock).Update("expires_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatalf("Failed to expire lock: %v", err)
+	}
+	if _, err := scheduler.Trigger("sync"); err != nil {
+		t.Errorf("Trigger() error = %v", err)
+	}
+	scheduler.wg.Wait()
+}
+
+func TestScheduler_RunDue(t *testing.T) {
+	db, scheduler, clock := setupScheduler(t)
+
+	var calls atomic.Int32
+	if err := scheduler.Register(Job{
+		Name:     "hourly",
+		Schedule: "@hourly",
+		Run: func(context.Context) error {
+			calls.Add(1)
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	// Nothing is due before the first activation
+	clock.Set(time.Date(2025, 9, 1, 12, 59, 0, 0, time.UTC))
+	scheduler.runDue(context.Background())
+	scheduler.wg.Wait()
+	if calls.Load() != 0 {
+		t.Fatalf("Expected no runs, got %d", calls.Load())
+	}
+
+	clock.Set(time.Date(2025, 9, 1, 13, 0, 1, 0, time.UTC))
+	scheduler.runDue(context.Background())
+	scheduler.wg.Wait()
+	if calls.Load() != 1 {
+		t.Fatalf("Expected 1 run, got %d", calls.Load())
+	}
+
+	infos, err := scheduler.Jobs()
+	if err != nil {
+		t.Fatalf("Jobs() error = %v", err)
+	}
+	if len(infos) != 1 || infos[0].NextRun == nil || !infos[0].NextRun.Equal(time.Date(2025, 9, 1, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected job info: %+v", infos)
+	}
+	if infos[0].LastRun == nil || infos[0].LastRun.TriggeredBy != database.JobTriggerSchedule {
+		t.Errorf("Expected last run to be a scheduled run, got %+v", infos[0].LastRun)
+	}
+
+	// Another instance has already run the next activation
+	other := database.JobRun{
+		JobName:     "hourly",
+		TriggeredBy: database.JobTriggerSchedule,
+		Status:      database.JobRunStatusSucceeded,
+		StartedAt:   time.Date(2025, 9, 1, 14, 0, 0, 0, time.UTC),
+	}
+	if err := db.GetDB().Create(&other).Error; err != nil {
+		t.Fatalf("Failed to create run: %v", err)
+	}
+
+	clock.Set(time.Date(2025, 9, 1, 14, 0, 2, 0, time.UTC))
+	scheduler.runDue(context.Background())
+	scheduler.wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("Expected activation run by another instance to be skipped, got %d runs", calls.Load())
+	}
+
+	runs, err := scheduler.Runs("hourly", 10)
+	if err != nil {
+		t.Fatalf("Runs() error = %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != other.ID {
+		t.Errorf("Expected 2 runs newest first, got %+v", runs)
+	}
+
+	if _, err := scheduler.Runs("missing", 10); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Runs() error = %v, want %v", err, ErrJobNotFound)
+	}
+}
+
+func TestScheduler_Cadence(t *testing.T) {
+	_, scheduler, clock := setupScheduler(t)
+
+	// The interval shortens once the job has run
+	var calls atomic.Int32
+	cadence := ScheduleFunc(func(t time.Time) time.Time {
+		if calls.Load() > 0 {
+			return t.Truncate(time.Minute).Add(time.Minute)
+		}
+		return t.Truncate(time.Minute).Add(time.Hour)
+	})
+	if err := scheduler.Register(Job{
+		Name:     "adaptive",
+		Schedule: "not parsed",
+		Cadence:  cadence,
+		Run: func(context.Context) error {
+			calls.Add(1)
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	next, ok := scheduler.NextRun("adaptive")
+	if !ok || !next.Equal(time.Date(2025, 9, 1, 13, 0, 0, 0, time.UTC)) {
+		t.Fatalf("NextRun() = %v, %v", next, ok)
+	}
+
+	clock.Set(time.Date(2025, 9, 1, 13, 0, 0, 0, time.UTC))
+	scheduler.runDue(context.Background())
+	scheduler.wg.Wait()
+	if calls.Load() != 1 {
+		t.Fatalf("Expected 1 run, got %d", calls.Load())
+	}
+
+	// The next activation was computed when the run was dispatched
+	if next, _ := scheduler.NextRun("adaptive"); !next.Equal(time.Date(2025, 9, 1, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextRun() = %v, want 14:00", next)
+	}
+
+	clock.Set(time.Date(2025, 9, 1, 14, 0, 0, 0, time.UTC))
+	scheduler.runDue(context.Background())
+	scheduler.wg.Wait()
+	if next, _ := scheduler.NextRun("adaptive"); !next.Equal(time.Date(2025, 9, 1, 14, 1, 0, 0, time.UTC)) {
+		t.Errorf("NextRun() = %v, want 14:01", next)
+	}
+}
+
+func TestScheduler_StartupJobsRunInOrder(t *testing.T) {
+	_, scheduler, _ := setupScheduler(t)
+
+	var order []string
+	for _, name := range []string{"calendar", "sync", "backfill"} {
+		if err := scheduler.Register(Job{
+			Name:       name,
+			RunOnStart: name != "backfill",
+			Run: func(context.Context) error {
+				order = append(order, name)
+				return nil
+			},
+		}); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+
+	scheduler.runStartupJobs(context.Background(), []*registeredJob{scheduler.jobs["calendar"], scheduler.jobs["sync"]})
+
+	if len(order) != 2 || order[0] != "calendar" || order[1] != "sync" {
+		t.Errorf("Unexpected startup order: %v", order)
+	}
+}
+
+func TestScheduler_StartupRunSkippedAfterActivation(t *testing.T) {
+	db, scheduler, clock := setupScheduler(t)
+
+	var calls atomic.Int32
+	for _, name := range []string{"spreads", "backfill"} {
+		if err := scheduler.Register(Job{
+			Name:       name,
+			Schedule:   "@every 6h",
+			RunOnStart: true,
+			Run: func(context.Context) error {
+				calls.Add(1)
+				return nil
+			},
+		}); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+
+	// The spreads already ran since the last activation at noon, before a restart
+	earlier := database.JobRun{
+		JobName:     "spreads",
+		TriggeredBy: database.JobTriggerStartup,
+		Status:      database.JobRunStatusSucceeded,
+		StartedAt:   time.Date(2025, 9, 1, 12, 0, 5, 0, time.UTC),
+	}
+	if err := db.GetDB().Create(&earlier).Error; err != nil {
+		t.Fatalf("Failed to create run: %v", err)
+	}
+
+	clock.Set(time.Date(2025, 9, 1, 13, 0, 0, 0, time.UTC))
+	scheduler.runStartupJobs(context.Background(), []*registeredJob{scheduler.jobs["spreads"], scheduler.jobs["backfill"]})
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected only the backfill to run at startup, got %d runs", calls.Load())
+	}
+	runs, err := scheduler.Runs("spreads", 10)
+	if err != nil {
+		t.Fatalf("Runs() error = %v", err)
+	}
+	if len(runs) != 1 {
+		t.Errorf("Expected the spreads not to run again, got %+v", runs)
+	}
+}
+
+func TestScheduler_StartDisabled(t *testing.T) {
+	_, scheduler, _ := setupScheduler(t)
+	scheduler.config.Jobs.Enabled = false
+
+	// Start returns immediately when disabled
+	scheduler.Start(context.Background())
+}
@@ -0,0 +1,140 @@
+package mockupstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api"
+)
+
+// backendClockPath is the backend's admin endpoint that moves its application clock. It is
+// only served while the backend runs in E2E test mode.
+const backendClockPath = "/api/admin/e2e/clock"
+
+// BackendClock moves the backend's application clock to the simulated time, so that the
+// backend locks weeks and scores games at the same time the mock plays them. It signs in
+// as an admin, and signs in again when the session expires.
+type BackendClock struct {
+	baseURL  string
+	email    string
+	password string
+	client   *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewBackendClock creates a BackendClock for the backend at baseURL, signing in with the
+// credentials of an admin.
+func NewBackendClock(baseURL, email, password string) *BackendClock {
+	return &BackendClock{
+		baseURL:  baseURL,
+		email:    email,
+		password: password,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Push moves the backend's clock to now.
+func (b *BackendClock) Push(ctx context.Context, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.token == "" {
+		if err := b.login(ctx); err != nil {
+			return err
+		}
+	}
+	status, err := b.setClock(ctx, now)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusUnauthorized {
+		if err := b.login(ctx); err != nil {
+			return err
+		}
+		if status, err = b.setClock(ctx, now); err != nil {
+			return err
+		}
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("backend clock update failed with status %d", status)
+	}
+	return nil
+}
+
+// Follow pushes the simulated time to the backend every interval until ctx is done. The
+// backend's clock runs at real time between pushes, so a faster simulated clock is followed
+// to within interval times its speed.
+func (b *BackendClock) Follow(ctx context.Context, clock *Clock, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.Push(ctx, clock.Now()); err != nil {
+			slog.Warn("Failed to move the backend clock", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// login signs in to the backend. The caller must hold the lock.
+func (b *BackendClock) login(ctx context.Context) error {
+	body, err := json.Marshal(api.LoginRequest{Email: b.email, Password: b.password})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/api/login", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to sign in to the backend: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to sign in to the backend: status %d", resp.StatusCode)
+	}
+
+	var login api.LoginResponse
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		return fmt.Errorf("failed to decode the backend sign in: %w", err)
+	}
+	b.token = login.Token
+	return nil
+}
+
+// setClock sends the time to the backend's clock endpoint and returns the response status.
+// The caller must hold the lock.
+func (b *BackendClock) setClock(ctx context.Context, now time.Time) (int, error) {
+	body, err := json.Marshal(api.ClockRequest{Now: &now})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.baseURL+backendClockPath, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+b.token)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to move the backend clock: %w", err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
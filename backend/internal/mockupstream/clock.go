@@ -0,0 +1,68 @@
+package mockupstream
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the simulated time that drives the games. It starts at a chosen time and runs
+// at a multiple of real time, so a season can be played out in minutes.
+type Clock struct {
+	// realNow returns the real time, and is replaced in tests
+	realNow func() time.Time
+
+	mu sync.Mutex
+	// The simulated time was simStart at realStart
+	realStart time.Time
+	simStart  time.Time
+	speed     float64
+}
+
+// NewClock creates a clock that starts at start and runs speed times faster than real time.
+// A speed of zero stops the clock.
+func NewClock(start time.Time, speed float64) *Clock {
+	return &Clock{
+		realNow:   time.Now,
+		realStart: time.Now(),
+		simStart:  start,
+		speed:     speed,
+	}
+}
+
+// Now returns the simulated time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now()
+}
+
+// now returns the simulated time. The caller must hold the lock.
+func (c *Clock) now() time.Time {
+	elapsed := c.realNow().Sub(c.realStart)
+	return c.simStart.Add(time.Duration(float64(elapsed) * c.speed))
+}
+
+// Speed returns how many times faster than real time the clock runs.
+func (c *Clock) Speed() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.speed
+}
+
+// Set moves the clock to the given time and sets its speed.
+func (c *Clock) Set(now time.Time, speed float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.realStart = c.realNow()
+	c.simStart = now
+	c.speed = speed
+}
+
+// SetSpeed changes the speed of the clock from its current time.
+func (c *Clock) SetSpeed(speed float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.simStart = c.now()
+	c.realStart = c.realNow()
+	c.speed = speed
+}
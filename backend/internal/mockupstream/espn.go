@@ -0,0 +1,178 @@
+package mockupstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api-espn"
+)
+
+// statusTypes are ESPN's status descriptions of each game state.
+var statusTypes = map[string]struct{ id, name, description string }{
+	"pre":  {"1", "STATUS_SCHEDULED", "Scheduled"},
+	"in":   {"2", "STATUS_IN_PROGRESS", "In Progress"},
+	"post": {"3", "STATUS_FINAL", "Final"},
+}
+
+// handleScoreboard serves ESPN's scoreboard. The week and seasontype parameters select a
+// week, and without them the current week is served. The season calendar is always included.
+func (s *Server) handleScoreboard(w http.ResponseWriter, r *http.Request) {
+	now := s.clock.Now()
+	week := s.season.currentWeek(now)
+
+	query := r.URL.Query()
+	if weekStr := query.Get("week"); weekStr != "" {
+		number, err := strconv.Atoi(weekStr)
+		if err != nil {
+			http.Error(w, "invalid week", http.StatusBadRequest)
+			return
+		}
+		seasonType := seasonTypeRegular
+		if seasonTypeStr := query.Get("seasontype"); seasonTypeStr != "" {
+			if seasonType, err = strconv.Atoi(seasonTypeStr); err != nil {
+				http.Error(w, "invalid seasontype", http.StatusBadRequest)
+				return
+			}
+		}
+		week = calendarWeek{seasonType: seasonType, week: number}
+	}
+
+	games := s.season.gamesOf(week.seasonType, week.week, now)
+	events := make([]apiespn.Event, len(games))
+	for i, g := range games {
+		events[i] = s.event(g, now)
+	}
+
+	scoreboard := apiespn.Scoreboard{
+		Events:  &events,
+		Leagues: &[]apiespn.League{s.league()},
+		Season:  &apiespn.SeasonInfo{Type: &week.seasonType, Year: &s.season.year},
+		Week:    &apiespn.WeekInfo{Number: &week.week},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(scoreboard)
+}
+
+// league returns the NFL league with the season calendar.
+func (s *Server) league() apiespn.League {
+	calendar := []apiespn.CalendarSeasonType{
+		{Label: ptr("Regular Season"), Value: ptr(strconv.Itoa(seasonTypeRegular))},
+		{Label: ptr("Postseason"), Value: ptr(strconv.Itoa(seasonTypePostseason))},
+	}
+	for i := range calendar {
+		var entries []apiespn.CalendarEntry
+		for _, week := range s.season.calendar {
+			if strconv.Itoa(week.seasonType) != *calendar[i].Value {
+				continue
+			}
+			entries = append(entries, apiespn.CalendarEntry{
+				Label:     ptr(week.label),
+				Value:     ptr(strconv.Itoa(week.week)),
+				StartDate: &apiespn.ESPNDateTime{Time: week.start},
+				EndDate:   &apiespn.ESPNDateTime{Time: week.end},
+			})
+		}
+		calendar[i].Entries = &entries
+		calendar[i].StartDate = entries[0].StartDate
+		calendar[i].EndDate = entries[len(entries)-1].EndDate
+	}
+
+	return apiespn.League{
+		Abbreviation: ptr("NFL"),
+		Name:         ptr("National Football League"),
+		Calendar:     &calendar,
+		Season:       &apiespn.Season{Year: &s.season.year},
+	}
+}
+
+// event returns the ESPN event of a game as it stands at the given time.
+func (s *Server) event(g *game, now time.Time) apiespn.Event {
+	state := g.stateAt(now)
+	status := statusTypes[state.state]
+	completed := state.state == "post"
+
+	detail := status.description
+	if state.state == "in" {
+		minutes, seconds := int(state.clock.Minutes()), int(state.clock.Seconds())%60
+		detail = fmt.Sprintf("%d:%02d - %s Quarter", minutes, seconds, ordinal(state.period))
+	}
+	gameStatus := &apiespn.Status{
+		Period: &state.period,
+		Type: &apiespn.StatusType{
+			Id:          ptr(status.id),
+			Name:        ptr(status.name),
+			State:       ptr(state.state),
+			Completed:   &completed,
+			Description: ptr(status.description),
+			Detail:      ptr(detail),
+			ShortDetail: ptr(detail),
+		},
+	}
+
+	competitor := func(t team, homeAway string, score int) apiespn.Competitor {
+		return apiespn.Competitor{
+			Id:       ptr(t.id),
+			HomeAway: ptr(homeAway),
+			Score:    ptr(strconv.Itoa(score)),
+			Team: &apiespn.Team{
+				Id:               ptr(t.id),
+				Abbreviation:     ptr(t.abbreviation),
+				DisplayName:      ptr(t.displayName()),
+				ShortDisplayName: ptr(t.name),
+				Location:         ptr(t.location),
+				Name:             ptr(t.name),
+			},
+		}
+	}
+	competitors := []apiespn.Competitor{
+		competitor(g.home, "home", state.homeScore),
+		competitor(g.away, "away", state.awayScore),
+	}
+
+	venue := g.home.venue
+	if g.neutral {
+		venue = "Caesars Superdome"
+	}
+	kickoff := &apiespn.ESPNDateTime{Time: g.kickoff}
+
+	return apiespn.Event{
+		Id:        ptr(g.id),
+		Name:      ptr(g.away.displayName() + " at " + g.home.displayName()),
+		ShortName: ptr(g.away.abbreviation + " @ " + g.home.abbreviation),
+		Date:      kickoff,
+		Season:    &apiespn.EventSeason{Type: &g.seasonType, Year: &s.season.year},
+		Week:      &apiespn.EventWeek{Number: &g.week},
+		Status:    gameStatus,
+		Competitions: &[]apiespn.Competition{{
+			Id:          ptr(g.id),
+			Date:        kickoff,
+			NeutralSite: &g.neutral,
+			Status:      gameStatus,
+			Venue:       &apiespn.Venue{FullName: ptr(venue)},
+			Competitors: &competitors,
+		}},
+	}
+}
+
+// ordinal returns a quarter as "1st", "2nd", "3rd" or "4th".
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return strconv.Itoa(n) + "th"
+	}
+}
+
+// ptr returns a pointer to a value.
+func ptr[T any](v T) *T {
+	return &v
+}
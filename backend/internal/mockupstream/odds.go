@@ -0,0 +1,123 @@
+package mockupstream
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// oddsQuota is the number of requests in the simulated Odds API billing period.
+const oddsQuota = 500
+
+// oddsEvent is an event of The Odds API's odds endpoint.
+type oddsEvent struct {
+	ID           string          `json:"id"`
+	SportKey     string          `json:"sport_key"`
+	SportTitle   string          `json:"sport_title"`
+	CommenceTime time.Time       `json:"commence_time"`
+	HomeTeam     string          `json:"home_team"`
+	AwayTeam     string          `json:"away_team"`
+	Bookmakers   []oddsBookmaker `json:"bookmakers"`
+}
+
+// oddsBookmaker is a bookmaker's markets for an event.
+type oddsBookmaker struct {
+	Key        string       `json:"key"`
+	Title      string       `json:"title"`
+	LastUpdate time.Time    `json:"last_update"`
+	Markets    []oddsMarket `json:"markets"`
+}
+
+// oddsMarket is a bookmaker's market for an event.
+type oddsMarket struct {
+	Key        string        `json:"key"`
+	LastUpdate time.Time     `json:"last_update"`
+	Outcomes   []oddsOutcome `json:"outcomes"`
+}
+
+// oddsOutcome is a side of a market.
+type oddsOutcome struct {
+	Name  string  `json:"name"`
+	Price int     `json:"price"`
+	Point float64 `json:"point"`
+}
+
+// handleOdds serves The Odds API's spreads for the games that have not finished, filtered by
+// the commenceTimeFrom and commenceTimeTo parameters. Every request counts against the quota
+// reported in the usage headers.
+func (s *Server) handleOdds(w http.ResponseWriter, r *http.Request) {
+	now := s.clock.Now()
+	query := r.URL.Query()
+
+	var from, to time.Time
+	for _, bound := range []struct {
+		param string
+		value *time.Time
+	}{{"commenceTimeFrom", &from}, {"commenceTimeTo", &to}} {
+		if raw := query.Get(bound.param); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				http.Error(w, "invalid "+bound.param, http.StatusUnprocessableEntity)
+				return
+			}
+			*bound.value = t
+		}
+	}
+
+	used := s.oddsRequests.Add(1)
+	w.Header().Set("x-requests-used", strconv.FormatInt(used, 10))
+	w.Header().Set("x-requests-remaining", strconv.FormatInt(max(oddsQuota-used, 0), 10))
+	w.Header().Set("x-requests-last", "1")
+
+	events := []oddsEvent{}
+	for _, g := range s.season.games {
+		if now.Before(g.revealAt) || !now.Before(g.end()) {
+			continue
+		}
+		if (!from.IsZero() && g.kickoff.Before(from)) || (!to.IsZero() && g.kickoff.After(to)) {
+			continue
+		}
+		events = append(events, oddsEvent{
+			ID:           oddsEventID(g),
+			SportKey:     r.PathValue("sport"),
+			SportTitle:   "NFL",
+			CommenceTime: g.kickoff,
+			HomeTeam:     g.home.displayName(),
+			AwayTeam:     g.away.displayName(),
+			Bookmakers: []oddsBookmaker{
+				spreadsBookmaker("draftkings", "DraftKings", g, g.spread, now),
+				spreadsBookmaker("fanduel", "FanDuel", g, g.altSpread, now),
+			},
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(events)
+}
+
+// spreadsBookmaker returns a bookmaker offering the given home line on a game.
+func spreadsBookmaker(key, title string, g *game, homePoint float64, now time.Time) oddsBookmaker {
+	updated := now.Truncate(time.Minute)
+	return oddsBookmaker{
+		Key:        key,
+		Title:      title,
+		LastUpdate: updated,
+		Markets: []oddsMarket{{
+			Key:        "spreads",
+			LastUpdate: updated,
+			Outcomes: []oddsOutcome{
+				{Name: g.home.displayName(), Price: -110, Point: homePoint},
+				{Name: g.away.displayName(), Price: -110, Point: -homePoint},
+			},
+		}},
+	}
+}
+
+// oddsEventID returns the Odds API's ID of a game, a hex string unrelated to ESPN's IDs.
+func oddsEventID(g *game) string {
+	hash := sha256.Sum256([]byte("odds-" + g.id))
+	return fmt.Sprintf("%x", hash[:16])
+}
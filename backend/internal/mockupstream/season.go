@@ -0,0 +1,379 @@
+package mockupstream
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ESPN season types.
+const (
+	seasonTypeRegular    = 2
+	seasonTypePostseason = 3
+)
+
+const (
+	// regularSeasonWeeks is the number of weeks in the regular season.
+	regularSeasonWeeks = 18
+	// gameLength is how long a simulated game lasts from kickoff to the final whistle.
+	gameLength = 3*time.Hour + 10*time.Minute
+	// homeFieldAdvantage is the number of points the home team is favored by.
+	homeFieldAdvantage = 1.5
+)
+
+// scoringPlay is a score in a simulated game.
+type scoringPlay struct {
+	// at is when the score happens, as a fraction of the game
+	at     float64
+	home   bool
+	points int
+}
+
+// game is a simulated game. Its result is decided when the season is generated and
+// revealed as the game is played.
+type game struct {
+	id         string
+	seasonType int
+	// week is ESPN's week number within the season type
+	week    int
+	home    team
+	away    team
+	neutral bool
+	kickoff time.Time
+	// revealAt is when the matchup becomes known, which for playoff games is once the
+	// previous round is over
+	revealAt time.Time
+	plays    []scoringPlay
+	// spread is the home team's line in the Odds API's convention, negative when the home
+	// team is favored, and altSpread a second bookmaker's line
+	spread    float64
+	altSpread float64
+}
+
+// gameState is the progress of a game at a point in time.
+type gameState struct {
+	// state is ESPN's state: "pre", "in" or "post"
+	state     string
+	homeScore int
+	awayScore int
+	// period is the quarter being played, and clock the time left in it
+	period int
+	clock  time.Duration
+}
+
+// stateAt returns the progress of the game at the given time.
+func (g *game) stateAt(now time.Time) gameState {
+	elapsed := now.Sub(g.kickoff)
+	if elapsed < 0 {
+		return gameState{state: "pre"}
+	}
+
+	fraction := float64(elapsed) / float64(gameLength)
+	state := gameState{state: "in"}
+	if fraction >= 1 {
+		state = gameState{state: "post", period: 4}
+		fraction = 1
+	} else {
+		quarter := 15 * time.Minute
+		played := time.Duration(fraction * 4 * float64(quarter))
+		state.period = int(played/quarter) + 1
+		state.clock = quarter - played%quarter
+	}
+
+	for _, play := range g.plays {
+		if play.at > fraction {
+			continue
+		}
+		if play.home {
+			state.homeScore += play.points
+		} else {
+			state.awayScore += play.points
+		}
+	}
+	return state
+}
+
+// end returns when the game is over.
+func (g *game) end() time.Time {
+	return g.kickoff.Add(gameLength)
+}
+
+// calendarWeek is an entry of the season calendar.
+type calendarWeek struct {
+	seasonType int
+	week       int
+	label      string
+	start      time.Time
+	end        time.Time
+}
+
+// Season is a generated NFL season. The schedule, lines and results are derived from the
+// year alone, so every run serves the same season.
+type Season struct {
+	year int
+	// week1 is the Thursday of the first week, at midnight UTC
+	week1    time.Time
+	games    []*game
+	calendar []calendarWeek
+}
+
+// DefaultWeek1 returns the Thursday after Labor Day of a year, when the NFL season starts.
+func DefaultWeek1(year int) time.Time {
+	laborDay := time.Date(year, time.September, 1, 0, 0, 0, 0, time.UTC)
+	for laborDay.Weekday() != time.Monday {
+		laborDay = laborDay.AddDate(0, 0, 1)
+	}
+	return laborDay.AddDate(0, 0, 3)
+}
+
+// NewSeason generates the season of a year whose first week starts on the Thursday week1.
+func NewSeason(year int, week1 time.Time) *Season {
+	s := &Season{
+		year:  year,
+		week1: time.Date(week1.Year(), week1.Month(), week1.Day(), 0, 0, 0, 0, time.UTC),
+	}
+	rng := rand.New(rand.NewPCG(uint64(year), 0))
+
+	// Each team's strength is the number of points it is better than an average team
+	strength := make(map[string]float64, len(teams))
+	for _, t := range teams {
+		strength[t.id] = rng.NormFloat64() * 4
+	}
+
+	s.buildCalendar()
+	s.scheduleRegularSeason(rng, strength)
+	s.schedulePostseason(rng, strength)
+	return s
+}
+
+// thursday returns the Thursday of the week with the given index, counting the regular
+// season's weeks from zero and continuing through the postseason.
+func (s *Season) thursday(index int) time.Time {
+	return s.week1.AddDate(0, 0, 7*index)
+}
+
+// buildCalendar builds the week boundaries, which run from Wednesday morning to the
+// following Wednesday morning Eastern time.
+func (s *Season) buildCalendar() {
+	add := func(seasonType, week, index int, label string) {
+		start := s.thursday(index).Add(-17 * time.Hour)
+		if index == 0 {
+			start = s.thursday(0).Add(7 * time.Hour)
+		}
+		end := s.thursday(index + 1).Add(-17*time.Hour - time.Minute)
+		s.calendar = append(s.calendar, calendarWeek{seasonType: seasonType, week: week, label: label, start: start, end: end})
+	}
+
+	for week := 1; week <= regularSeasonWeeks; week++ {
+		add(seasonTypeRegular, week, week-1, "Week "+strconv.Itoa(week))
+	}
+	for week, label := range []string{"Wild Card", "Divisional Round", "Conference Championship", "Pro Bowl", "Super Bowl"} {
+		add(seasonTypePostseason, week+1, regularSeasonWeeks+week, label)
+	}
+}
+
+// regularSeasonSlots are the kickoffs of a regular season week's 16 games, relative to its
+// Thursday: Thursday night, Sunday early and late afternoon, Sunday night and Monday night.
+var regularSeasonSlots = func() []time.Duration {
+	slots := []time.Duration{24*time.Hour + 15*time.Minute}
+	for range 11 {
+		slots = append(slots, 3*24*time.Hour+17*time.Hour)
+	}
+	slots = append(slots, 3*24*time.Hour+20*time.Hour+25*time.Minute, 3*24*time.Hour+20*time.Hour+25*time.Minute)
+	return append(slots, 4*24*time.Hour+20*time.Minute, 5*24*time.Hour+15*time.Minute)
+}()
+
+// scheduleRegularSeason pairs the teams with the circle method, so every team plays once a week.
+func (s *Season) scheduleRegularSeason(rng *rand.Rand, strength map[string]float64) {
+	order := rng.Perm(len(teams))
+	for week := 1; week <= regularSeasonWeeks; week++ {
+		// Keep the first team in place and rotate the others
+		round := []int{order[0]}
+		rest := order[1:]
+		shift := (week - 1) % len(rest)
+		round = append(round, rest[len(rest)-shift:]...)
+		round = append(round, rest[:len(rest)-shift]...)
+
+		for i := range len(round) / 2 {
+			home, away := teams[round[i]], teams[round[len(round)-1-i]]
+			if (week+i)%2 == 0 {
+				home, away = away, home
+			}
+			g := &game{
+				seasonType: seasonTypeRegular,
+				week:       week,
+				home:       home,
+				away:       away,
+				kickoff:    s.thursday(week - 1).Add(regularSeasonSlots[i]),
+			}
+			s.play(rng, g, strength, false)
+		}
+	}
+}
+
+// playoffSlots are the kickoffs of each postseason round, relative to the round's Thursday.
+var playoffSlots = map[int][]time.Duration{
+	1: {
+		2*24*time.Hour + 21*time.Hour + 30*time.Minute,
+		3*24*time.Hour + 1*time.Hour + 15*time.Minute,
+		3*24*time.Hour + 18*time.Hour,
+		3*24*time.Hour + 21*time.Hour + 30*time.Minute,
+		4*24*time.Hour + 1*time.Hour + 15*time.Minute,
+		5*24*time.Hour + 1*time.Hour + 15*time.Minute,
+	},
+	2: {
+		2*24*time.Hour + 21*time.Hour + 30*time.Minute,
+		3*24*time.Hour + 1*time.Hour + 15*time.Minute,
+		3*24*time.Hour + 20*time.Hour,
+		3*24*time.Hour + 23*time.Hour + 30*time.Minute,
+	},
+	3: {
+		3*24*time.Hour + 20*time.Hour,
+		3*24*time.Hour + 23*time.Hour + 30*time.Minute,
+	},
+	5: {
+		3*24*time.Hour + 23*time.Hour + 30*time.Minute,
+	},
+}
+
+// schedulePostseason seeds the 14 teams with the most regular season wins and plays out the
+// bracket. The top two seeds skip the wild card round, and the better seed hosts every game
+// but the Super Bowl.
+func (s *Season) schedulePostseason(rng *rand.Rand, strength map[string]float64) {
+	wins := make(map[string]int, len(teams))
+	var regularSeasonEnd time.Time
+	for _, g := range s.games {
+		state := g.stateAt(g.end())
+		switch {
+		case state.homeScore > state.awayScore:
+			wins[g.home.id]++
+		case state.awayScore > state.homeScore:
+			wins[g.away.id]++
+		}
+		regularSeasonEnd = maxTime(regularSeasonEnd, g.end())
+	}
+
+	seeds := slices.Clone(teams)
+	slices.SortStableFunc(seeds, func(a, b team) int {
+		if wins[a.id] != wins[b.id] {
+			return wins[b.id] - wins[a.id]
+		}
+		return cmp.Compare(strength[b.id], strength[a.id])
+	})
+	seed := make(map[string]int, len(seeds))
+	for i, t := range seeds {
+		seed[t.id] = i + 1
+	}
+
+	// Each round pairs the best remaining seed with the worst
+	remaining := seeds[:14]
+	byes := seeds[:2]
+	remaining = remaining[2:]
+	revealAt := regularSeasonEnd
+	for _, week := range []int{1, 2, 3, 5} {
+		slices.SortFunc(remaining, func(a, b team) int { return seed[a.id] - seed[b.id] })
+
+		var winners []team
+		var roundEnd time.Time
+		for i := range len(remaining) / 2 {
+			home, away := remaining[i], remaining[len(remaining)-1-i]
+			g := &game{
+				seasonType: seasonTypePostseason,
+				week:       week,
+				home:       home,
+				away:       away,
+				neutral:    week == 5,
+				kickoff:    s.thursday(regularSeasonWeeks + week - 1).Add(playoffSlots[week][i]),
+				revealAt:   revealAt,
+			}
+			s.play(rng, g, strength, true)
+
+			final := g.stateAt(g.end())
+			if final.homeScore > final.awayScore {
+				winners = append(winners, home)
+			} else {
+				winners = append(winners, away)
+			}
+			roundEnd = maxTime(roundEnd, g.end())
+		}
+
+		remaining = append(winners, byes...)
+		byes = nil
+		revealAt = roundEnd
+	}
+}
+
+// play sets the game's ID, lines and scoring plays, and adds it to the season. Playoff games
+// cannot end in a tie, so a tied playoff game is decided by an overtime field goal.
+func (s *Season) play(rng *rand.Rand, g *game, strength map[string]float64, playoff bool) {
+	g.id = strconv.Itoa(401770000 + len(s.games) + 1)
+
+	edge := strength[g.home.id] - strength[g.away.id]
+	if !g.neutral {
+		edge += homeFieldAdvantage
+	}
+	g.spread = -roundToHalf(edge)
+	g.altSpread = g.spread + float64(rng.IntN(3)-1)/2
+
+	for _, home := range []bool{true, false} {
+		expected := 21 + edge/2
+		if !home {
+			expected = 21 - edge/2
+		}
+		target := max(0, int(math.Round(expected+rng.NormFloat64()*8)))
+		for total := 0; total < target-2; {
+			points := 7
+			if rng.IntN(10) < 3 {
+				points = 3
+			}
+			g.plays = append(g.plays, scoringPlay{at: rng.Float64(), home: home, points: points})
+			total += points
+		}
+	}
+	slices.SortFunc(g.plays, func(a, b scoringPlay) int { return cmp.Compare(a.at, b.at) })
+
+	if final := g.stateAt(g.end()); playoff && final.homeScore == final.awayScore {
+		g.plays = append(g.plays, scoringPlay{at: 1, home: edge >= 0, points: 3})
+	}
+
+	s.games = append(s.games, g)
+}
+
+// gamesOf returns the known games of a week at the given time, in kickoff order.
+func (s *Season) gamesOf(seasonType, week int, now time.Time) []*game {
+	var games []*game
+	for _, g := range s.games {
+		if g.seasonType == seasonType && g.week == week && !now.Before(g.revealAt) {
+			games = append(games, g)
+		}
+	}
+	return games
+}
+
+// currentWeek returns the calendar week containing the given time. Before the season it
+// is the first week and after it the Super Bowl.
+func (s *Season) currentWeek(now time.Time) calendarWeek {
+	current := s.calendar[0]
+	for _, week := range s.calendar {
+		if !now.Before(week.start) {
+			current = week
+		}
+	}
+	return current
+}
+
+// roundToHalf rounds a line to the nearest half point.
+func roundToHalf(x float64) float64 {
+	return math.Round(x*2) / 2
+}
+
+// maxTime returns the later of two times.
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,159 @@
+package mockupstream
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSeason(t *testing.T) {
+	season := NewSeason(2025, DefaultWeek1(2025))
+
+	if got, want := season.week1, time.Date(2025, time.September, 4, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Expected week 1 to start on %v, got %v", want, got)
+	}
+	if len(season.calendar) != regularSeasonWeeks+5 {
+		t.Errorf("Expected %d calendar weeks, got %d", regularSeasonWeeks+5, len(season.calendar))
+	}
+
+	// Every team plays once in every regular season week
+	for week := 1; week <= regularSeasonWeeks; week++ {
+		games := season.gamesOf(seasonTypeRegular, week, time.Time{})
+		if len(games) != len(teams)/2 {
+			t.Fatalf("Expected %d games in week %d, got %d", len(teams)/2, week, len(games))
+		}
+		playing := make(map[string]bool)
+		for _, g := range games {
+			if playing[g.home.id] || playing[g.away.id] {
+				t.Errorf("Team plays twice in week %d: %s vs %s", week, g.home.abbreviation, g.away.abbreviation)
+			}
+			playing[g.home.id], playing[g.away.id] = true, true
+		}
+	}
+
+	// The postseason has 6 wild card, 4 divisional and 2 conference games and the Super Bowl
+	postseason := 0
+	for _, g := range season.games {
+		if g.seasonType == seasonTypePostseason {
+			postseason++
+		}
+	}
+	if postseason != 13 {
+		t.Errorf("Expected 13 postseason games, got %d", postseason)
+	}
+
+	// Generating the same season again gives the same games
+	again := NewSeason(2025, DefaultWeek1(2025))
+	for i, g := range season.games {
+		other := again.games[i]
+		if g.id != other.id || g.home.id != other.home.id || g.away.id != other.away.id || !g.kickoff.Equal(other.kickoff) || g.spread != other.spread {
+			t.Fatalf("Expected the same game %d, got %+v and %+v", i, g, other)
+		}
+	}
+}
+
+func TestSeason_PostseasonRevealedAfterPreviousRound(t *testing.T) {
+	season := NewSeason(2025, DefaultWeek1(2025))
+
+	var wildCard, divisional []*game
+	for _, g := range season.games {
+		if g.seasonType != seasonTypePostseason {
+			continue
+		}
+		switch g.week {
+		case 1:
+			wildCard = append(wildCard, g)
+		case 2:
+			divisional = append(divisional, g)
+		}
+	}
+	if len(wildCard) != 6 || len(divisional) != 4 {
+		t.Fatalf("Expected 6 wild card and 4 divisional games, got %d and %d", len(wildCard), len(divisional))
+	}
+
+	// The divisional round is unknown until the wild card round is over
+	lastWildCard := wildCard[0].end()
+	for _, g := range wildCard {
+		lastWildCard = maxTime(lastWildCard, g.end())
+	}
+	if games := season.gamesOf(seasonTypePostseason, 2, lastWildCard.Add(-time.Minute)); len(games) != 0 {
+		t.Errorf("Expected no divisional games before the wild card round ends, got %d", len(games))
+	}
+	if games := season.gamesOf(seasonTypePostseason, 2, lastWildCard); len(games) != 4 {
+		t.Errorf("Expected 4 divisional games after the wild card round ends, got %d", len(games))
+	}
+}
+
+func TestGame_StateAt(t *testing.T) {
+	season := NewSeason(2025, DefaultWeek1(2025))
+	g := season.games[0]
+
+	before := g.stateAt(g.kickoff.Add(-time.Minute))
+	if before.state != "pre" || before.homeScore != 0 || before.awayScore != 0 {
+		t.Errorf("Expected a scheduled game without scores, got %+v", before)
+	}
+
+	during := g.stateAt(g.kickoff.Add(gameLength / 2))
+	if during.state != "in" || during.period < 1 || during.period > 4 {
+		t.Errorf("Expected a game in progress, got %+v", during)
+	}
+
+	final := g.stateAt(g.end())
+	if final.state != "post" {
+		t.Errorf("Expected a final game, got %+v", final)
+	}
+	if final.homeScore < during.homeScore || final.awayScore < during.awayScore {
+		t.Errorf("Expected scores to only increase, got %+v then %+v", during, final)
+	}
+}
+
+func TestSeason_CurrentWeek(t *testing.T) {
+	season := NewSeason(2025, DefaultWeek1(2025))
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		seasonType int
+		week       int
+	}{
+		{"before the season", time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC), seasonTypeRegular, 1},
+		{"week 1 Sunday", time.Date(2025, time.September, 7, 17, 0, 0, 0, time.UTC), seasonTypeRegular, 1},
+		{"week 2 Wednesday", time.Date(2025, time.September, 10, 12, 0, 0, 0, time.UTC), seasonTypeRegular, 2},
+		{"wild card weekend", time.Date(2026, time.January, 10, 18, 0, 0, 0, time.UTC), seasonTypePostseason, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			week := season.currentWeek(tt.now)
+			if week.seasonType != tt.seasonType || week.week != tt.week {
+				t.Errorf("Expected season type %d week %d, got season type %d week %d", tt.seasonType, tt.week, week.seasonType, week.week)
+			}
+		})
+	}
+}
+
+func TestClock(t *testing.T) {
+	start := time.Date(2025, time.September, 4, 0, 0, 0, 0, time.UTC)
+	wall := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	clock := NewClock(start, 60)
+	clock.realNow = func() time.Time { return wall }
+	clock.realStart = wall
+
+	wall = wall.Add(time.Minute)
+	if got, want := clock.Now(), start.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Expected %v after a minute at 60x, got %v", want, got)
+	}
+
+	clock.SetSpeed(0)
+	wall = wall.Add(time.Hour)
+	if got, want := clock.Now(), start.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Expected a stopped clock at %v, got %v", want, got)
+	}
+
+	jump := time.Date(2025, time.December, 25, 0, 0, 0, 0, time.UTC)
+	clock.Set(jump, 1)
+	wall = wall.Add(time.Second)
+	if got, want := clock.Now(), jump.Add(time.Second); !got.Equal(want) {
+		t.Errorf("Expected %v after setting the clock, got %v", want, got)
+	}
+}
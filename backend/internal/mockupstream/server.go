@@ -0,0 +1,144 @@
+// Package mockupstream serves simulated ESPN scoreboard and The Odds API endpoints, so the
+// backend can run without network access.
+package mockupstream
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/upstream"
+)
+
+// ESPNBasePath is the path of the mock ESPN API. The backend's ESPN.BaseURL is the server's
+// address followed by ESPNBasePath, and its TheOddsAPI.BaseURL is the server's address.
+const ESPNBasePath = "/apis/site/v2/sports/football/nfl"
+
+// Server serves the mock ESPN and Odds API endpoints from a generated season, with the
+// games progressing as the simulated clock runs. Requests that were recorded as fixtures
+// are answered with the recorded responses instead.
+type Server struct {
+	season *Season
+	clock  *Clock
+	// fixtures replays the recorded responses of each upstream, keyed by upstream name
+	fixtures map[string]*upstream.FixtureDoer
+
+	oddsRequests atomic.Int64
+}
+
+// ClockResponse is the state of the simulated clock.
+type ClockResponse struct {
+	Now   time.Time `json:"now"`
+	Speed float64   `json:"speed"`
+}
+
+// ClockRequest moves the simulated clock or changes its speed. Omitted fields are unchanged.
+type ClockRequest struct {
+	Now   *time.Time `json:"now,omitempty"`
+	Speed *float64   `json:"speed,omitempty"`
+}
+
+// NewServer creates a server for the season driven by the clock. If fixturesDir is not empty,
+// the responses recorded there are replayed before falling back to the generated season.
+func NewServer(season *Season, clock *Clock, fixturesDir string) (*Server, error) {
+	s := &Server{season: season, clock: clock, fixtures: make(map[string]*upstream.FixtureDoer)}
+	if fixturesDir == "" {
+		return s, nil
+	}
+
+	for _, name := range []string{"espn", "theoddsapi"} {
+		doer, err := upstream.NewFixtureDoer(filepath.Join(fixturesDir, name), config.FixtureModeReplay, nil)
+		if err != nil {
+			return nil, err
+		}
+		s.fixtures[name] = doer
+	}
+	return s, nil
+}
+
+// Handler returns the server's routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+ESPNBasePath+"/scoreboard", s.withFixtures("espn", s.handleScoreboard))
+	mux.HandleFunc("GET /v4/sports/{sport}/odds", s.withFixtures("theoddsapi", s.handleOdds))
+	mux.HandleFunc("GET /mock/clock", s.handleGetClock)
+	mux.HandleFunc("POST /mock/clock", s.handleSetClock)
+	return logRequests(mux)
+}
+
+// withFixtures answers a request with its recorded fixture if there is one, and with the
+// handler otherwise.
+func (s *Server) withFixtures(name string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		doer, ok := s.fixtures[name]
+		if !ok {
+			handler(w, r)
+			return
+		}
+
+		resp, err := doer.Do(r)
+		if errors.Is(err, upstream.ErrFixtureNotFound) {
+			handler(w, r)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to replay fixture", "upstream", name, "url", r.URL.String(), "error", err)
+			http.Error(w, "failed to replay fixture", http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		for key, values := range resp.Header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, resp.Body)
+	}
+}
+
+// handleGetClock returns the simulated clock.
+func (s *Server) handleGetClock(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(ClockResponse{Now: s.clock.Now(), Speed: s.clock.Speed()})
+}
+
+// handleSetClock moves the simulated clock or changes its speed.
+func (s *Server) handleSetClock(w http.ResponseWriter, r *http.Request) {
+	var req ClockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Speed != nil && *req.Speed < 0 {
+		http.Error(w, "speed must not be negative", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case req.Now != nil:
+		speed := s.clock.Speed()
+		if req.Speed != nil {
+			speed = *req.Speed
+		}
+		s.clock.Set(*req.Now, speed)
+	case req.Speed != nil:
+		s.clock.SetSpeed(*req.Speed)
+	}
+
+	slog.Info("Simulated clock changed", "now", s.clock.Now(), "speed", s.clock.Speed())
+	s.handleGetClock(w, r)
+}
+
+// logRequests logs every request at debug level.
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slog.Debug("Mock upstream request", "method", r.Method, "url", r.URL.String())
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,247 @@
+package mockupstream
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/api"
+	"github.com/dhpollack/football-pool/internal/api-espn"
+	theoddsapi "github.com/dhpollack/football-pool/internal/api-the-odds-api"
+	"github.com/dhpollack/football-pool/internal/upstream"
+)
+
+// newTestServer starts a mock upstream for the 2025 season with a stopped clock at now.
+func newTestServer(t *testing.T, now time.Time, fixturesDir string) (*Server, *httptest.Server) {
+	t.Helper()
+	srv, err := NewServer(NewSeason(2025, DefaultWeek1(2025)), NewClock(now, 0), fixturesDir)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+	return srv, ts
+}
+
+func TestServer_Scoreboard(t *testing.T) {
+	srv, ts := newTestServer(t, time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC), "")
+	client, err := apiespn.NewClientWithResponses(ts.URL + ESPNBasePath)
+	if err != nil {
+		t.Fatalf("Failed to create ESPN client: %v", err)
+	}
+
+	scoreboard := func(params *apiespn.GetScoreboardParams) *apiespn.Scoreboard {
+		t.Helper()
+		resp, err := client.GetScoreboardWithResponse(context.Background(), params)
+		if err != nil {
+			t.Fatalf("Failed to get scoreboard: %v", err)
+		}
+		if resp.JSON200 == nil {
+			t.Fatalf("Expected a scoreboard, got status %d: %s", resp.StatusCode(), resp.Body)
+		}
+		return resp.JSON200
+	}
+
+	// Before the season the first week is served, with the whole calendar
+	board := scoreboard(&apiespn.GetScoreboardParams{})
+	if *board.Week.Number != 1 || *board.Season.Type != seasonTypeRegular {
+		t.Errorf("Expected regular season week 1, got type %d week %d", *board.Season.Type, *board.Week.Number)
+	}
+	if len(*board.Events) != 16 {
+		t.Errorf("Expected 16 events, got %d", len(*board.Events))
+	}
+	calendar := *(*board.Leagues)[0].Calendar
+	if len(calendar) != 2 || len(*calendar[0].Entries) != regularSeasonWeeks || len(*calendar[1].Entries) != 5 {
+		t.Errorf("Expected the regular season and postseason calendars, got %+v", calendar)
+	}
+
+	// A game progresses from scheduled to final as the clock runs
+	week := 3
+	g := srv.season.gamesOf(seasonTypeRegular, week, time.Time{})[0]
+	states := map[time.Time]string{
+		g.kickoff.Add(-time.Hour):     "pre",
+		g.kickoff.Add(gameLength / 2): "in",
+		g.end():                       "post",
+	}
+	for now, want := range states {
+		srv.clock.Set(now, 0)
+		board := scoreboard(&apiespn.GetScoreboardParams{Week: &week})
+		var event *apiespn.Event
+		for _, e := range *board.Events {
+			if *e.Id == g.id {
+				event = &e
+			}
+		}
+		if event == nil {
+			t.Fatalf("Expected event %s in week %d", g.id, week)
+		}
+		if got := *event.Status.Type.State; got != want {
+			t.Errorf("Expected state %q at %v, got %q", want, now, got)
+		}
+		if want == "post" && !*event.Status.Type.Completed {
+			t.Errorf("Expected a completed event at %v", now)
+		}
+	}
+}
+
+func TestServer_Odds(t *testing.T) {
+	srv, ts := newTestServer(t, time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC), "")
+	client, err := theoddsapi.NewClientWithResponses(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to create Odds API client: %v", err)
+	}
+
+	week1 := srv.season.calendar[0]
+	from, to := week1.start.Format(time.RFC3339), week1.end.Format(time.RFC3339)
+	params := &theoddsapi.GetOddsParams{
+		ApiKey:           "test",
+		Regions:          theoddsapi.GetOddsParamsRegionsUs,
+		CommenceTimeFrom: &from,
+		CommenceTimeTo:   &to,
+	}
+
+	for i := 1; i <= 2; i++ {
+		resp, err := client.GetOddsWithResponse(context.Background(), "americanfootball_nfl", params)
+		if err != nil {
+			t.Fatalf("Failed to get odds: %v", err)
+		}
+		if resp.JSON200 == nil {
+			t.Fatalf("Expected odds, got status %d: %s", resp.StatusCode(), resp.Body)
+		}
+		if len(*resp.JSON200) != 16 {
+			t.Errorf("Expected odds for the 16 games of week 1, got %d", len(*resp.JSON200))
+		}
+		if got := resp.HTTPResponse.Header.Get("x-requests-used"); got != strconv.Itoa(i) {
+			t.Errorf("Expected %d requests used, got %s", i, got)
+		}
+	}
+
+	// Games that have finished are no longer offered
+	srv.clock.Set(week1.end, 0)
+	resp, err := client.GetOddsWithResponse(context.Background(), "americanfootball_nfl", params)
+	if err != nil {
+		t.Fatalf("Failed to get odds: %v", err)
+	}
+	if resp.JSON200 == nil || len(*resp.JSON200) != 0 {
+		t.Errorf("Expected no odds after week 1, got %s", resp.Body)
+	}
+}
+
+func TestServer_Clock(t *testing.T) {
+	start := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	_, ts := newTestServer(t, start, "")
+
+	jump := time.Date(2025, time.October, 1, 12, 0, 0, 0, time.UTC)
+	resp, err := http.Post(ts.URL+"/mock/clock", "application/json", strings.NewReader(`{"now": "2025-10-01T12:00:00Z"}`))
+	if err != nil {
+		t.Fatalf("Failed to set clock: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var clock ClockResponse
+	if err := json.NewDecoder(resp.Body).Decode(&clock); err != nil {
+		t.Fatalf("Failed to decode clock: %v", err)
+	}
+	if !clock.Now.Equal(jump) || clock.Speed != 0 {
+		t.Errorf("Expected the stopped clock at %v, got %+v", jump, clock)
+	}
+
+	resp, err = http.Post(ts.URL+"/mock/clock", "application/json", strings.NewReader(`{"speed": -1}`))
+	if err != nil {
+		t.Fatalf("Failed to set clock: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status %d for a negative speed, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestBackendClock(t *testing.T) {
+	var logins int
+	var pushed []time.Time
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			logins++
+			_ = json.NewEncoder(w).Encode(api.LoginResponse{Token: "token-" + strconv.Itoa(logins)})
+		case backendClockPath:
+			// The first session expires after one update
+			if r.Header.Get("Authorization") != "Bearer token-"+strconv.Itoa(logins) || (logins == 1 && len(pushed) == 1) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var req api.ClockRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Now == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			pushed = append(pushed, *req.Now)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(backend.Close)
+
+	clock := NewBackendClock(backend.URL, "admin@example.com", "password")
+	kickoff := time.Date(2025, time.September, 7, 17, 0, 0, 0, time.UTC)
+	for _, now := range []time.Time{kickoff, kickoff.Add(time.Hour)} {
+		if err := clock.Push(context.Background(), now); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+
+	if logins != 2 || len(pushed) != 2 || !pushed[0].Equal(kickoff) || !pushed[1].Equal(kickoff.Add(time.Hour)) {
+		t.Errorf("Unexpected backend clock updates: %d logins, pushed %v", logins, pushed)
+	}
+}
+
+func TestServer_ReplaysFixtures(t *testing.T) {
+	dir := t.TempDir()
+	req := httptest.NewRequest(http.MethodGet, ESPNBasePath+"/scoreboard?week=3", nil)
+	fixture, err := json.Marshal(upstream.Fixture{
+		Method: http.MethodGet,
+		URL:    req.URL.String(),
+		Status: http.StatusOK,
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   json.RawMessage(`{"week": {"number": 3}, "events": []}`),
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal fixture: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "espn"), 0755); err != nil {
+		t.Fatalf("Failed to create fixtures directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "espn", upstream.FixtureName(req)), fixture, 0644); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	_, ts := newTestServer(t, time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC), dir)
+	get := func(path string) map[string]any {
+		t.Helper()
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("Failed to get %s: %v", path, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		var body map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode %s: %v", path, err)
+		}
+		return body
+	}
+
+	// The recorded week is replayed, and other weeks are generated
+	if events := get(ESPNBasePath + "/scoreboard?week=3")["events"].([]any); len(events) != 0 {
+		t.Errorf("Expected the fixture's empty events, got %d", len(events))
+	}
+	if events := get(ESPNBasePath + "/scoreboard?week=4")["events"].([]any); len(events) != 16 {
+		t.Errorf("Expected 16 generated events, got %d", len(events))
+	}
+}
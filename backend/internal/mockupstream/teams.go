@@ -0,0 +1,51 @@
+package mockupstream
+
+// team is an NFL team as the mock upstreams describe it.
+type team struct {
+	id           string
+	location     string
+	name         string
+	abbreviation string
+	venue        string
+}
+
+// displayName returns the team's full name, such as "Philadelphia Eagles".
+func (t team) displayName() string {
+	return t.location + " " + t.name
+}
+
+// teams are the 32 NFL teams, with ESPN's team IDs.
+var teams = []team{
+	{"22", "Arizona", "Cardinals", "ARI", "State Farm Stadium"},
+	{"1", "Atlanta", "Falcons", "ATL", "Mercedes-Benz Stadium"},
+	{"33", "Baltimore", "Ravens", "BAL", "M&T Bank Stadium"},
+	{"2", "Buffalo", "Bills", "BUF", "Highmark Stadium"},
+	{"29", "Carolina", "Panthers", "CAR", "Bank of America Stadium"},
+	{"3", "Chicago", "Bears", "CHI", "Soldier Field"},
+	{"4", "Cincinnati", "Bengals", "CIN", "Paycor Stadium"},
+	{"5", "Cleveland", "Browns", "CLE", "Huntington Bank Field"},
+	{"6", "Dallas", "Cowboys", "DAL", "AT&T Stadium"},
+	{"7", "Denver", "Broncos", "DEN", "Empower Field at Mile High"},
+	{"8", "Detroit", "Lions", "DET", "Ford Field"},
+	{"9", "Green Bay", "Packers", "GB", "Lambeau Field"},
+	{"34", "Houston", "Texans", "HOU", "NRG Stadium"},
+	{"11", "Indianapolis", "Colts", "IND", "Lucas Oil Stadium"},
+	{"30", "Jacksonville", "Jaguars", "JAX", "EverBank Stadium"},
+	{"12", "Kansas City", "Chiefs", "KC", "GEHA Field at Arrowhead Stadium"},
+	{"13", "Las Vegas", "Raiders", "LV", "Allegiant Stadium"},
+	{"24", "Los Angeles", "Chargers", "LAC", "SoFi Stadium"},
+	{"14", "Los Angeles", "Rams", "LAR", "SoFi Stadium"},
+	{"15", "Miami", "Dolphins", "MIA", "Hard Rock Stadium"},
+	{"16", "Minnesota", "Vikings", "MIN", "U.S. Bank Stadium"},
+	{"17", "New England", "Patriots", "NE", "Gillette Stadium"},
+	{"18", "New Orleans", "Saints", "NO", "Caesars Superdome"},
+	{"19", "New York", "Giants", "NYG", "MetLife Stadium"},
+	{"20", "New York", "Jets", "NYJ", "MetLife Stadium"},
+	{"21", "Philadelphia", "Eagles", "PHI", "Lincoln Financial Field"},
+	{"23", "Pittsburgh", "Steelers", "PIT", "Acrisure Stadium"},
+	{"25", "San Francisco", "49ers", "SF", "Levi's Stadium"},
+	{"26", "Seattle", "Seahawks", "SEA", "Lumen Field"},
+	{"27", "Tampa Bay", "Buccaneers", "TB", "Raymond James Stadium"},
+	{"10", "Tennessee", "Titans", "TEN", "Nissan Stadium"},
+	{"28", "Washington", "Commanders", "WSH", "Northwest Stadium"},
+}
@@ -0,0 +1,108 @@
+package oddssync
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+// bookmakerLine is the line a single bookmaker quotes for a game. The home edge is
+// the spread from the home team's side: positive when the home team is the favorite.
+type bookmakerLine struct {
+	bookmaker string
+	homeEdge  float32
+}
+
+// spreadSelector chooses a game's line from the lines quoted by the bookmakers.
+type spreadSelector struct {
+	strategy    string
+	preferred   []string
+	roundToHalf bool
+}
+
+// newSpreadSelector creates a spreadSelector from the configuration. An empty strategy uses the preferred bookmakers.
+func newSpreadSelector(cfg *config.Config) (*spreadSelector, error) {
+	strategy := cfg.TheOddsAPI.SpreadStrategy
+	switch strategy {
+	case "":
+		strategy = config.SpreadStrategyPreferred
+	case config.SpreadStrategyPreferred, config.SpreadStrategyMedian, config.SpreadStrategyMean:
+	default:
+		return nil, fmt.Errorf("invalid spread strategy %q", strategy)
+	}
+
+	return &spreadSelector{
+		strategy:    strategy,
+		preferred:   cfg.TheOddsAPI.PreferredBookmakers,
+		roundToHalf: cfg.TheOddsAPI.RoundToHalf,
+	}, nil
+}
+
+// selectLine returns the home edge of the game's line and the bookmakers it was derived from.
+func (s *spreadSelector) selectLine(lines []bookmakerLine) (float32, []string, error) {
+	if len(lines) == 0 {
+		return 0, nil, fmt.Errorf("no valid spread data found for event")
+	}
+
+	var edge float64
+	var bookmakers []string
+	switch s.strategy {
+	case config.SpreadStrategyMedian, config.SpreadStrategyMean:
+		edges := make([]float64, len(lines))
+		bookmakers = make([]string, len(lines))
+		for i, line := range lines {
+			edges[i] = float64(line.homeEdge)
+			bookmakers[i] = line.bookmaker
+		}
+		if s.strategy == config.SpreadStrategyMedian {
+			edge = median(edges)
+		} else {
+			edge = mean(edges)
+		}
+	default:
+		line := s.preferredLine(lines)
+		edge = float64(line.homeEdge)
+		bookmakers = []string{line.bookmaker}
+	}
+
+	if s.roundToHalf {
+		edge = math.Round(edge*2) / 2
+	}
+	return float32(edge), bookmakers, nil
+}
+
+// preferredLine returns the line of the first preferred bookmaker quoting the game,
+// or the first line if none of them do.
+func (s *spreadSelector) preferredLine(lines []bookmakerLine) bookmakerLine {
+	for _, bookmaker := range s.preferred {
+		for _, line := range lines {
+			if line.bookmaker == bookmaker {
+				return line
+			}
+		}
+	}
+	return lines[0]
+}
+
+// median returns the median of the values, averaging the middle two for an even count.
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// mean returns the arithmetic mean of the values.
+func mean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
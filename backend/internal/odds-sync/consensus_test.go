@@ -0,0 +1,128 @@
+package oddssync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+func TestSpreadSelector_SelectLine(t *testing.T) {
+	lines := []bookmakerLine{
+		{bookmaker: "fanduel", homeEdge: 3},
+		{bookmaker: "draftkings", homeEdge: 3.5},
+		{bookmaker: "betmgm", homeEdge: 2.5},
+		{bookmaker: "caesars", homeEdge: 4.5},
+	}
+
+	tests := []struct {
+		name               string
+		strategy           string
+		preferred          []string
+		roundToHalf        bool
+		expectedEdge       float32
+		expectedBookmakers []string
+	}{
+		{name: "first listed", strategy: config.SpreadStrategyPreferred, expectedEdge: 3, expectedBookmakers: []string{"fanduel"}},
+		{name: "preferred", strategy: config.SpreadStrategyPreferred, preferred: []string{"pinnacle", "draftkings"}, expectedEdge: 3.5, expectedBookmakers: []string{"draftkings"}},
+		{name: "median", strategy: config.SpreadStrategyMedian, expectedEdge: 3.25, expectedBookmakers: []string{"fanduel", "draftkings", "betmgm", "caesars"}},
+		{name: "median rounded", strategy: config.SpreadStrategyMedian, roundToHalf: true, expectedEdge: 3.5, expectedBookmakers: []string{"fanduel", "draftkings", "betmgm", "caesars"}},
+		{name: "mean", strategy: config.SpreadStrategyMean, expectedEdge: 3.375, expectedBookmakers: []string{"fanduel", "draftkings", "betmgm", "caesars"}},
+		{name: "mean rounded", strategy: config.SpreadStrategyMean, roundToHalf: true, expectedEdge: 3.5, expectedBookmakers: []string{"fanduel", "draftkings", "betmgm", "caesars"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := &spreadSelector{strategy: tt.strategy, preferred: tt.preferred, roundToHalf: tt.roundToHalf}
+			edge, bookmakers, err := selector.selectLine(lines)
+			if err != nil {
+				t.Fatalf("selectLine() error = %v", err)
+			}
+			if edge != tt.expectedEdge {
+				t.Errorf("selectLine() edge = %v, want %v", edge, tt.expectedEdge)
+			}
+			if !slices.Equal(bookmakers, tt.expectedBookmakers) {
+				t.Errorf("selectLine() bookmakers = %v, want %v", bookmakers, tt.expectedBookmakers)
+			}
+		})
+	}
+
+	selector := &spreadSelector{strategy: config.SpreadStrategyMedian}
+	if _, _, err := selector.selectLine(nil); err == nil {
+		t.Error("selectLine() expected error without lines")
+	}
+}
+
+func TestNewSpreadSelector(t *testing.T) {
+	cfg := &config.Config{}
+	selector, err := newSpreadSelector(cfg)
+	if err != nil {
+		t.Fatalf("newSpreadSelector() error = %v", err)
+	}
+	if selector.strategy != config.SpreadStrategyPreferred {
+		t.Errorf("Expected the default strategy to be %s, got %s", config.SpreadStrategyPreferred, selector.strategy)
+	}
+
+	cfg.TheOddsAPI.SpreadStrategy = "mode"
+	if _, err := newSpreadSelector(cfg); err == nil {
+		t.Error("newSpreadSelector() expected error for unknown strategy")
+	}
+}
+
+func TestOddsService_ConsensusSpread(t *testing.T) {
+	// The away team is favored by every bookmaker except one
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{
+			"home_team": "Buffalo Bills",
+			"away_team": "Kansas City Chiefs",
+			"bookmakers": [
+				{"key": "fanduel", "markets": [{"key": "spreads", "outcomes": [{"name": "Buffalo Bills", "point": -2.5}, {"name": "Kansas City Chiefs", "point": 2.5}]}]},
+				{"key": "draftkings", "markets": [{"key": "spreads", "outcomes": [{"name": "Buffalo Bills", "point": -1.5}, {"name": "Kansas City Chiefs", "point": 1.5}]}]},
+				{"key": "betmgm", "markets": [{"key": "spreads", "outcomes": [{"name": "Buffalo Bills", "point": 1}, {"name": "Kansas City Chiefs", "point": -1}]}]},
+				{"key": "novig", "markets": [{"key": "h2h", "outcomes": []}]}
+			]
+		}]`))
+	}))
+	defer upstream.Close()
+
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.TheOddsAPI.BaseURL = upstream.URL
+	cfg.TheOddsAPI.SpreadStrategy = config.SpreadStrategyMedian
+	cfg.TheOddsAPI.RoundToHalf = true
+	cfg.ESPN.Week1Date = time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC))
+	service, err := NewOddsServiceWithTimeProvider(db, cfg, appClock)
+	if err != nil {
+		t.Fatalf("Failed to create odds service: %v", err)
+	}
+
+	spreads, err := service.FetchSpreadsForWeek(context.Background(), 2025, 2, PriorityEssential)
+	if err != nil {
+		t.Fatalf("FetchSpreadsForWeek() error = %v", err)
+	}
+	if len(spreads) != 1 {
+		t.Fatalf("Expected 1 spread, got %d", len(spreads))
+	}
+
+	spread := spreads[0]
+	if spread.Spread != 1.5 || spread.Favorite != "Away" || spread.Underdog != "Home" {
+		t.Errorf("Unexpected line: %+v", spread)
+	}
+	if spread.Strategy != config.SpreadStrategyMedian || !slices.Equal(spread.Bookmakers, []string{"fanduel", "draftkings", "betmgm"}) {
+		t.Errorf("Unexpected line source: strategy=%s bookmakers=%v", spread.Strategy, spread.Bookmakers)
+	}
+}
@@ -0,0 +1,152 @@
+package oddssync
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+// SpreadLine is a game's line as published by a league or entered by an admin. The
+// favorite is either "Home", "Away" or the name of one of the teams, and the spread is its margin.
+type SpreadLine struct {
+	HomeTeam string  `json:"home_team"`
+	AwayTeam string  `json:"away_team"`
+	Favorite string  `json:"favorite"`
+	Spread   float32 `json:"spread"`
+}
+
+// spreadColumns are the columns required in the header of a CSV spreads file.
+var spreadColumns = []string{"home_team", "away_team", "favorite", "spread"}
+
+// fileProvider reads the lines of leagues that publish their own from files dropped in a
+// directory, at <dir>/<season>/week-<week>.csv or <dir>/<season>/week-<week>.json.
+type fileProvider struct {
+	dir string
+}
+
+// newFileProvider creates a provider reading spreads files from dir.
+func newFileProvider(dir string) (*fileProvider, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("the file odds provider requires an odds file directory")
+	}
+	return &fileProvider{dir: dir}, nil
+}
+
+// Name returns the provider's name.
+func (p *fileProvider) Name() string {
+	return config.OddsProviderFile
+}
+
+// FetchSpreads reads the week's spreads file, preferring CSV when both formats are present.
+func (p *fileProvider) FetchSpreads(_ context.Context, season, week int, _ FetchPriority) ([]GameSpread, error) {
+	base := filepath.Join(p.dir, strconv.Itoa(season), fmt.Sprintf("week-%d", week))
+
+	var lines []SpreadLine
+	path := base + ".csv"
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		path = base + ".json"
+		data, err = os.ReadFile(path)
+	}
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("no spreads file for season %d week %d in %s: %w", season, week, p.dir, err)
+	case err != nil:
+		return nil, fmt.Errorf("failed to read spreads file: %w", err)
+	case strings.HasSuffix(path, ".csv"):
+		lines, err = ParseSpreadLinesCSV(data)
+	default:
+		err = json.Unmarshal(data, &lines)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spreads file %s: %w", path, err)
+	}
+
+	spreads := make([]GameSpread, 0, len(lines))
+	for i, line := range lines {
+		spread, err := line.toSpread()
+		if err != nil {
+			return nil, fmt.Errorf("invalid line %d in spreads file %s: %w", i+1, path, err)
+		}
+		spreads = append(spreads, spread)
+	}
+	return spreads, nil
+}
+
+// toSpread converts a published line to a GameSpread.
+func (l SpreadLine) toSpread() (GameSpread, error) {
+	if l.HomeTeam == "" || l.AwayTeam == "" {
+		return GameSpread{}, fmt.Errorf("missing team")
+	}
+	if l.Spread < 0 {
+		return GameSpread{}, fmt.Errorf("spread %v must not be negative", l.Spread)
+	}
+
+	spread := GameSpread{
+		Strategy: config.OddsProviderFile,
+		HomeTeam: l.HomeTeam,
+		AwayTeam: l.AwayTeam,
+		Spread:   l.Spread,
+	}
+	switch l.Favorite {
+	case "Home", l.HomeTeam:
+		spread.Favorite, spread.Underdog = "Home", "Away"
+	case "Away", l.AwayTeam:
+		spread.Favorite, spread.Underdog = "Away", "Home"
+	default:
+		return GameSpread{}, fmt.Errorf("favorite %q is neither team", l.Favorite)
+	}
+	return spread, nil
+}
+
+// ParseSpreadLinesCSV parses a CSV spreads file. The columns are matched by the names in the header row.
+func ParseSpreadLinesCSV(data []byte) ([]SpreadLine, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range spreadColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var lines []SpreadLine
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		spread, err := strconv.ParseFloat(strings.TrimSpace(record[columns["spread"]]), 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spread %q: %w", record[columns["spread"]], err)
+		}
+		lines = append(lines, SpreadLine{
+			HomeTeam: strings.TrimSpace(record[columns["home_team"]]),
+			AwayTeam: strings.TrimSpace(record[columns["away_team"]]),
+			Favorite: strings.TrimSpace(record[columns["favorite"]]),
+			Spread:   float32(spread),
+		})
+	}
+}
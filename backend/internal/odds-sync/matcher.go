@@ -0,0 +1,99 @@
+package oddssync
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// kickoffTolerance is how far an event's commence time may be from a game's start time for
+// the event to match the game. It allows for flexed games whose new kickoff the schedule
+// sync has not picked up yet.
+const kickoffTolerance = 36 * time.Hour
+
+// spreadMatch is a provider's line matched to one of the week's games.
+type spreadMatch struct {
+	game *database.Game
+	// spread is oriented to the game's home and away teams
+	spread GameSpread
+}
+
+// matchSpreads matches a provider's lines to the week's games. The provider's team names are
+// resolved to the registered teams, and its home and away teams may be swapped relative to the
+// game's, as they are for neutral-site games. When the teams match more than one game, the
+// game kicking off closest to the event's commence time is chosen. Lines that match no game,
+// or a game already matched by another line, are returned as unmatched.
+func matchSpreads(spreads []GameSpread, games []database.Game, resolver *database.TeamResolver, provider string) ([]spreadMatch, []database.UnmatchedSpread) {
+	var matched []spreadMatch
+	var unmatched []database.UnmatchedSpread
+	seen := make(map[uint]bool)
+
+	for _, spread := range spreads {
+		reject := func(reason string) {
+			entry := database.UnmatchedSpread{
+				Provider: provider,
+				EventID:  spread.EventID,
+				HomeTeam: spread.HomeTeam,
+				AwayTeam: spread.AwayTeam,
+				Reason:   reason,
+			}
+			if !spread.CommenceTime.IsZero() {
+				commenceTime := spread.CommenceTime
+				entry.CommenceTime = &commenceTime
+			}
+			unmatched = append(unmatched, entry)
+		}
+
+		home := resolveTeam(resolver, provider, spread.HomeTeam)
+		away := resolveTeam(resolver, provider, spread.AwayTeam)
+
+		var game *database.Game
+		var swapped bool
+		var closest time.Duration
+		candidates := 0
+		for i := range games {
+			candidate := &games[i]
+			same := database.TeamMatches(home, candidate.HomeTeamID, candidate.HomeTeam) &&
+				database.TeamMatches(away, candidate.AwayTeamID, candidate.AwayTeam)
+			reversed := database.TeamMatches(home, candidate.AwayTeamID, candidate.AwayTeam) &&
+				database.TeamMatches(away, candidate.HomeTeamID, candidate.HomeTeam)
+			if !same && !reversed {
+				continue
+			}
+			candidates++
+
+			if spread.CommenceTime.IsZero() {
+				game, swapped = candidate, !same
+				continue
+			}
+			drift := candidate.StartTime.Sub(spread.CommenceTime).Abs()
+			if drift <= kickoffTolerance && (game == nil || drift < closest) {
+				game, swapped, closest = candidate, !same, drift
+			}
+		}
+
+		switch {
+		case candidates == 0:
+			reject("no game found for the teams")
+			continue
+		case game == nil:
+			reject(fmt.Sprintf("the kickoff differs from the game's by more than %s", kickoffTolerance))
+			continue
+		case candidates > 1 && spread.CommenceTime.IsZero():
+			reject("the teams match more than one game")
+			continue
+		case seen[game.ID]:
+			reject("the game already has a line from another event")
+			continue
+		}
+		seen[game.ID] = true
+
+		if swapped {
+			spread.HomeTeam, spread.AwayTeam = spread.AwayTeam, spread.HomeTeam
+			spread.Favorite, spread.Underdog = spread.Underdog, spread.Favorite
+		}
+		matched = append(matched, spreadMatch{game: game, spread: spread})
+	}
+	return matched, unmatched
+}
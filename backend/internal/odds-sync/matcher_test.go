@@ -0,0 +1,129 @@
+package oddssync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+func TestMatchSpreads(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	teams := []database.Team{
+		{ESPNID: "15", DisplayName: "Miami Dolphins", Name: "Dolphins"},
+		{ESPNID: "18", DisplayName: "New Orleans Saints", Name: "Saints"},
+		{ESPNID: "22", DisplayName: "Philadelphia Eagles", Name: "Eagles"},
+	}
+	for i := range teams {
+		if err := db.UpsertTeam(&teams[i]); err != nil {
+			t.Fatalf("Failed to create team: %v", err)
+		}
+	}
+	if err := database.AddTeamAlias(db.GetDB(), teams[2].ID, config.OddsProviderTheOddsAPI, "Philly Eagles"); err != nil {
+		t.Fatalf("Failed to add alias: %v", err)
+	}
+
+	sunday := time.Date(2025, 10, 12, 17, 0, 0, 0, time.UTC)
+	games := []database.Game{
+		// A neutral-site game the provider lists with the teams the other way around
+		{Model: gorm.Model{ID: 1}, Season: 2025, Week: 6, HomeTeam: "Miami Dolphins", AwayTeam: "New Orleans Saints", HomeTeamID: &teams[0].ID, AwayTeamID: &teams[1].ID, StartTime: sunday.Add(-3 * time.Hour)},
+		// A game flexed to the night slot after the schedule was synced
+		{Model: gorm.Model{ID: 2}, Season: 2025, Week: 6, HomeTeam: "Philadelphia Eagles", AwayTeam: "New York Giants", HomeTeamID: &teams[2].ID, StartTime: sunday},
+		{Model: gorm.Model{ID: 3}, Season: 2025, Week: 6, HomeTeam: "Chicago Bears", AwayTeam: "Detroit Lions", StartTime: sunday},
+	}
+
+	spreads := []GameSpread{
+		{EventID: "a", HomeTeam: "New Orleans Saints", AwayTeam: "Miami Dolphins", Favorite: "Home", Underdog: "Away", Spread: 3, CommenceTime: sunday.Add(-3 * time.Hour)},
+		{EventID: "b", HomeTeam: "Philly Eagles", AwayTeam: "New York Giants", Favorite: "Home", Underdog: "Away", Spread: 7, CommenceTime: sunday.Add(200 * time.Minute)},
+		{EventID: "c", HomeTeam: "Chicago Bears", AwayTeam: "Detroit Lions", Favorite: "Away", Underdog: "Home", Spread: 4, CommenceTime: sunday.Add(4 * 24 * time.Hour)},
+		{EventID: "d", HomeTeam: "Dallas Cowboys", AwayTeam: "Carolina Panthers", Favorite: "Home", Underdog: "Away", Spread: 2, CommenceTime: sunday},
+		{EventID: "e", HomeTeam: "Philadelphia Eagles", AwayTeam: "New York Giants", Favorite: "Home", Underdog: "Away", Spread: 6.5},
+	}
+
+	matches, unmatched := matchSpreads(spreads, games, database.NewTeamResolver(db.GetDB()), config.OddsProviderTheOddsAPI)
+
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].game.ID != 1 || matches[0].spread.Favorite != "Away" || matches[0].spread.HomeTeam != "Miami Dolphins" {
+		t.Errorf("Unexpected neutral-site match: game %d, %+v", matches[0].game.ID, matches[0].spread)
+	}
+	if matches[1].game.ID != 2 || matches[1].spread.Favorite != "Home" || matches[1].spread.Spread != 7 {
+		t.Errorf("Unexpected flexed match: game %d, %+v", matches[1].game.ID, matches[1].spread)
+	}
+
+	if len(unmatched) != 3 {
+		t.Fatalf("Expected 3 unmatched events, got %d: %+v", len(unmatched), unmatched)
+	}
+	for i, eventID := range []string{"c", "d", "e"} {
+		if unmatched[i].EventID != eventID || unmatched[i].Provider != config.OddsProviderTheOddsAPI || unmatched[i].Reason == "" {
+			t.Errorf("Unexpected unmatched event %d: %+v", i, unmatched[i])
+		}
+	}
+	if unmatched[0].CommenceTime == nil || unmatched[2].CommenceTime != nil {
+		t.Errorf("Unexpected commence times: %v, %v", unmatched[0].CommenceTime, unmatched[2].CommenceTime)
+	}
+}
+
+func TestOddsService_RecordsUnmatchedSpreads(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	kickoff := time.Date(2025, 9, 12, 0, 15, 0, 0, time.UTC)
+	game := database.Game{Week: 2, Season: 2025, HomeTeam: "Green Bay Packers", AwayTeam: "Washington Commanders", StartTime: kickoff}
+	if err := db.GetDB().Create(&game).Error; err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Odds.Provider = config.OddsProviderFile
+	cfg.Odds.FileDir = dir
+	cfg.ESPN.Week1Date = time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(time.Date(2025, 9, 8, 12, 0, 0, 0, time.UTC))
+	service, err := NewOddsServiceWithTimeProvider(db, cfg, appClock)
+	if err != nil {
+		t.Fatalf("Failed to create odds service: %v", err)
+	}
+
+	writeSpreadsFile(t, dir, 2025, "week-2.csv", "home_team,away_team,favorite,spread\n"+
+		"Green Bay Packers,Washington Commanders,Home,3\n"+
+		"Chicago Bears,Detroit Lions,Away,1\n"+
+		"Dallas Cowboys,New York Giants,Home,6\n")
+	if err := service.UpdateGameSpreads(context.Background(), 2025, 2, PriorityRoutine); err != nil {
+		t.Fatalf("UpdateGameSpreads() error = %v", err)
+	}
+
+	var unmatched []database.UnmatchedSpread
+	db.GetDB().Where("season = ? AND week = ?", 2025, 2).Order("id").Find(&unmatched)
+	if len(unmatched) != 2 || unmatched[0].HomeTeam != "Chicago Bears" || unmatched[1].HomeTeam != "Dallas Cowboys" {
+		t.Fatalf("Unexpected unmatched spreads: %+v", unmatched)
+	}
+	if unmatched[0].Provider != config.OddsProviderFile || !unmatched[0].FetchedAt.Truncate(time.Minute).Equal(time.Date(2025, 9, 8, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected unmatched spread: %+v", unmatched[0])
+	}
+
+	// The next update replaces the week's unmatched events
+	writeSpreadsFile(t, dir, 2025, "week-2.csv", "home_team,away_team,favorite,spread\n"+
+		"Green Bay Packers,Washington Commanders,Home,3\n")
+	if err := service.UpdateGameSpreads(context.Background(), 2025, 2, PriorityRoutine); err != nil {
+		t.Fatalf("UpdateGameSpreads() error = %v", err)
+	}
+	var count int64
+	db.GetDB().Unscoped().Model(&database.UnmatchedSpread{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no unmatched spreads, got %d", count)
+	}
+}
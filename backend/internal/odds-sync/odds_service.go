@@ -3,275 +3,258 @@ package oddssync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
-	theoddsapi "github.com/dhpollack/football-pool/internal/api-the-odds-api"
+	"github.com/dhpollack/football-pool/internal/clock"
 	"github.com/dhpollack/football-pool/internal/config"
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/upstream"
+	"gorm.io/gorm"
 )
 
-// OddsService orchestrates the fetching and updating of game spreads from The Odds API.
+// OddsService orchestrates the fetching and updating of game spreads from the configured odds providers.
 type OddsService struct {
 	db           *database.Database
-	oddsClient   *theoddsapi.ClientWithResponses
 	config       *config.Config
 	timeProvider TimeProvider
-}
 
-// TimeProvider defines an interface for getting the current time.
-// This allows for dependency injection in tests.
-type TimeProvider interface {
-	Now() time.Time
+	spreadLock *spreadLock
+	// oddsAPI is always created so its quota and client stats can be reported
+	oddsAPI   *theOddsAPIProvider
+	providers map[string]Provider
 }
 
-// RealTimeProvider provides the actual current time.
-type RealTimeProvider struct{}
-
-// Now returns the current time.
-func (r RealTimeProvider) Now() time.Time {
-	return time.Now()
-}
+// TimeProvider is the application clock. It is injected so that tests and E2E runs can
+// control the time.
+type TimeProvider = clock.Clock
 
 // NewOddsService creates a new OddsService instance.
 func NewOddsService(db *database.Database, config *config.Config) (*OddsService, error) {
-	return NewOddsServiceWithTimeProvider(db, config, RealTimeProvider{})
+	return NewOddsServiceWithTimeProvider(db, config, clock.Real{})
 }
 
 // NewOddsServiceWithTimeProvider creates a new OddsService instance with a custom time provider.
 // This is primarily for testing purposes.
 func NewOddsServiceWithTimeProvider(db *database.Database, config *config.Config, timeProvider TimeProvider) (*OddsService, error) {
-	// Create The Odds API client
-	client, err := theoddsapi.NewClientWithResponses(config.TheOddsAPI.BaseURL)
+	lock, err := parseSpreadLock(config.Pool.SpreadLock, config.Pool.SpreadLockTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	oddsAPI, err := newTheOddsAPIProvider(db, config, timeProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	providers, err := newProviders(config, oddsAPI)
 	if err != nil {
 		return nil, err
 	}
 
 	return &OddsService{
 		db:           db,
-		oddsClient:   client,
 		config:       config,
 		timeProvider: timeProvider,
+
+		spreadLock: lock,
+		oddsAPI:    oddsAPI,
+		providers:  providers,
 	}, nil
 }
 
+// UpstreamStats returns the request counters and circuit breaker state of The Odds API client.
+func (s *OddsService) UpstreamStats() upstream.Stats {
+	return s.oddsAPI.http.Stats()
+}
+
 // GameSpread represents the spread information for a game.
 type GameSpread struct {
-	HomeTeam string
-	AwayTeam string
-	Spread   float32
-	Favorite string // "Home" or "Away"
-	Underdog string // "Home" or "Away"
+	// Strategy is the spread strategy that chose the line from the Bookmakers' lines,
+	// or the name of the provider for lines that were not chosen from bookmakers
+	Strategy   string
+	Bookmakers []string
+	HomeTeam   string
+	AwayTeam   string
+	Spread     float32
+	Favorite   string // "Home" or "Away"
+	Underdog   string // "Home" or "Away"
+	// EventID and CommenceTime identify the provider's event, and are empty
+	// for providers that do not report them
+	EventID      string
+	CommenceTime time.Time
 }
 
-// FetchSpreadsForWeek fetches spreads for games in a specific week and season.
-func (s *OddsService) FetchSpreadsForWeek(ctx context.Context, season, week int) ([]GameSpread, error) {
-	slog.Info("Fetching spreads for week", "season", season, "week", week)
-
-	// Calculate date range for the week
-	weekStart, weekEnd := s.getWeekDateRange(season, week)
-
-	// Fetch odds from The Odds API
-	params := &theoddsapi.GetOddsParams{
-		ApiKey:  s.config.TheOddsAPI.APIKey,
-		Regions: theoddsapi.GetOddsParamsRegions(s.config.TheOddsAPI.Region),
-		Markets: func() *theoddsapi.GetOddsParamsMarkets {
-			market := theoddsapi.GetOddsParamsMarketsSpreads
-			return &market
-		}(),
-		CommenceTimeFrom: func() *string {
-			from := weekStart.Format(time.RFC3339)
-			return &from
-		}(),
-		CommenceTimeTo: func() *string {
-			to := weekEnd.Format(time.RFC3339)
-			return &to
-		}(),
+// FetchSpreadsForWeek fetches spreads for games in a specific week and season from the season's provider.
+func (s *OddsService) FetchSpreadsForWeek(ctx context.Context, season, week int, priority FetchPriority) ([]GameSpread, error) {
+	provider := s.providerFor(season)
+	slog.Info("Fetching spreads for week", "season", season, "week", week, "provider", provider.Name())
+
+	spreads, err := provider.FetchSpreads(ctx, season, week, priority)
+	if err != nil {
+		return nil, err
 	}
 
-	response, err := s.oddsClient.GetOddsWithResponse(ctx, "americanfootball_nfl", params)
+	slog.Info("Fetched spreads", "provider", provider.Name(), "count", len(spreads))
+	return spreads, nil
+}
+
+// UpdateGameSpreads records the latest spreads for games in the database. Every fetched
+// line is stored as a snapshot, while the pool line on the game only follows the market
+// until the week's spread lock time.
+func (s *OddsService) UpdateGameSpreads(ctx context.Context, season, week int, priority FetchPriority) error {
+	slog.Info("Updating game spreads", "season", season, "week", week)
+
+	// Fetch spreads from the season's provider
+	spreads, err := s.FetchSpreadsForWeek(ctx, season, week, priority)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch odds: %w", err)
+		return fmt.Errorf("failed to fetch spreads: %w", err)
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("the Odds API returned status %d: %s", response.StatusCode(), response.Status())
+	lockAt, err := s.SpreadLockTime(season, week)
+	if err != nil {
+		return err
 	}
+	now := s.timeProvider.Now()
 
-	if response.JSON200 == nil {
-		slog.Error("The Odds API returned nil JSON200 response", "status", response.StatusCode(), "body", string(response.Body))
-		return nil, fmt.Errorf("the Odds API returned empty response")
+	var games []database.Game
+	if err := s.db.GetDB().Where("season = ? AND week = ?", season, week).Find(&games).Error; err != nil {
+		return fmt.Errorf("failed to load games: %w", err)
 	}
 
-	// Parse the response and extract spreads
-	spreads := make([]GameSpread, 0, len(*response.JSON200))
-	for _, event := range *response.JSON200 {
-		if event.HomeTeam == nil || event.AwayTeam == nil {
-			slog.Debug("Skipping event with missing team information")
+	// Match the lines to the games, resolving the provider's team names to the registered teams
+	provider := s.ProviderName(season)
+	matches, unmatched := matchSpreads(spreads, games, database.NewTeamResolver(s.db.GetDB()), provider)
+	s.recordUnmatchedSpreads(season, week, now, unmatched)
+
+	updatedCount := 0
+	for _, match := range matches {
+		game := *match.game
+		spread := match.spread
+
+		snapshot := database.SpreadSnapshot{
+			GameID:     game.ID,
+			Strategy:   spread.Strategy,
+			Bookmakers: strings.Join(spread.Bookmakers, ","),
+			Spread:     spread.Spread,
+			Favorite:   spread.Favorite,
+			FetchedAt:  now,
+		}
+		if err := s.db.GetDB().Create(&snapshot).Error; err != nil {
+			slog.Error("Failed to record spread snapshot", "game_id", game.ID, "error", err)
 			continue
 		}
 
-		spread, err := s.extractSpreadFromEvent(event)
-		if err != nil {
-			slog.Warn("Failed to extract spread from event", "home_team", *event.HomeTeam, "away_team", *event.AwayTeam, "error", err)
+		if game.SpreadLockedAt != nil {
+			continue
+		}
+
+		switch {
+		case lockAt.IsZero() || now.Before(lockAt):
+			// The pool line follows the market until the lock time
+			setGameSpread(&game, spread)
+		case game.Favorite == nil:
+			// The game had no line before the lock time, so the first one fetched after it is used
+			setGameSpread(&game, spread)
+			game.SpreadLockedAt = &now
+		default:
+			// The line set before the lock time becomes the pool line
+			game.SpreadLockedAt = &lockAt
+		}
+
+		if err := s.db.GetDB().Save(&game).Error; err != nil {
+			slog.Error("Failed to update game spread", "game_id", game.ID, "error", err)
 			continue
 		}
 
-		spreads = append(spreads, *spread)
+		updatedCount++
 	}
 
-	slog.Info("Fetched spreads from The Odds API", "count", len(spreads))
-	return spreads, nil
+	slog.Info("Updated game spreads", "updated_count", updatedCount, "unmatched_count", len(unmatched), "total_spreads", len(spreads))
+	return nil
 }
 
-// extractSpreadFromEvent extracts spread information from an odds API event.
-func (s *OddsService) extractSpreadFromEvent(event struct {
-	AwayTeam   *theoddsapi.AwayTeam `json:"away_team"`
-	Bookmakers *[]struct {
-		Key        *string    `json:"key,omitempty"`
-		LastUpdate *time.Time `json:"last_update,omitempty"`
-		Link       *string    `json:"link"`
-		Markets    *[]struct {
-			Key        *theoddsapi.GetOdds200BookmakersMarketsKey `json:"key,omitempty"`
-			LastUpdate *time.Time                                 `json:"last_update,omitempty"`
-			Link       *string                                    `json:"link"`
-			Outcomes   *[]theoddsapi.Outcome                      `json:"outcomes,omitempty"`
-			Sid        *string                                    `json:"sid"`
-		} `json:"markets,omitempty"`
-		Sid   *string `json:"sid"`
-		Title *string `json:"title,omitempty"`
-	} `json:"bookmakers,omitempty"`
-	CommenceTime *theoddsapi.CommenceTime `json:"commence_time,omitempty"`
-	HomeTeam     *theoddsapi.HomeTeam     `json:"home_team"`
-	Id           *theoddsapi.MatchId      `json:"id,omitempty"` //nolint:revive,staticcheck // Field name matches generated API
-	SportKey     *theoddsapi.SportKey     `json:"sport_key,omitempty"`
-	SportTitle   *theoddsapi.SportTitle   `json:"sport_title,omitempty"`
-},
-) (*GameSpread, error) {
-	if event.Bookmakers == nil || len(*event.Bookmakers) == 0 {
-		return nil, fmt.Errorf("no bookmakers found for event")
+// recordUnmatchedSpreads replaces the unmatched odds events of a week, so admins can
+// register the aliases or fix the games that prevented them from matching.
+func (s *OddsService) recordUnmatchedSpreads(season, week int, fetchedAt time.Time, unmatched []database.UnmatchedSpread) {
+	for i := range unmatched {
+		entry := &unmatched[i]
+		entry.Season = season
+		entry.Week = week
+		entry.FetchedAt = fetchedAt
+		slog.Warn("No game found for spread", "season", season, "week", week, "provider", entry.Provider,
+			"event_id", entry.EventID, "home", entry.HomeTeam, "away", entry.AwayTeam, "reason", entry.Reason)
 	}
 
-	// Use the first bookmaker that has spreads data
-	for _, bookmaker := range *event.Bookmakers {
-		if bookmaker.Markets == nil {
-			continue
+	err := s.db.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("season = ? AND week = ?", season, week).Delete(&database.UnmatchedSpread{}).Error; err != nil {
+			return err
 		}
-
-		for _, market := range *bookmaker.Markets {
-			if market.Key == nil || *market.Key != "spreads" {
-				continue
-			}
-
-			if market.Outcomes == nil || len(*market.Outcomes) != 2 {
-				continue
-			}
-
-			// Extract spread information from outcomes
-			outcomes := *market.Outcomes
-			var homeSpread, awaySpread float32
-			var homeTeam, awayTeam string
-
-			for _, outcome := range outcomes {
-				if outcome.Point == nil {
-					continue
-				}
-
-				// Determine which team this outcome belongs to
-				if outcome.Name != nil {
-					switch *outcome.Name {
-					case *event.HomeTeam:
-						homeSpread = *outcome.Point
-						homeTeam = *event.HomeTeam
-					case *event.AwayTeam:
-						awaySpread = *outcome.Point
-						awayTeam = *event.AwayTeam
-					}
-				}
-			}
-
-			// Determine favorite and underdog based on spreads
-			// Positive spread indicates the favorite
-			switch {
-			case homeSpread > 0 && awaySpread < 0:
-				// Home team is favorite
-				return &GameSpread{
-					HomeTeam: homeTeam,
-					AwayTeam: awayTeam,
-					Spread:   homeSpread,
-					Favorite: "Home",
-					Underdog: "Away",
-				}, nil
-			case awaySpread > 0 && homeSpread < 0:
-				// Away team is favorite
-				return &GameSpread{
-					HomeTeam: homeTeam,
-					AwayTeam: awayTeam,
-					Spread:   awaySpread,
-					Favorite: "Away",
-					Underdog: "Home",
-				}, nil
-			case homeSpread == 0 && awaySpread == 0:
-				// Even spread, default to home team as favorite
-				return &GameSpread{
-					HomeTeam: homeTeam,
-					AwayTeam: awayTeam,
-					Spread:   0,
-					Favorite: "Home",
-					Underdog: "Away",
-				}, nil
-			}
+		if len(unmatched) == 0 {
+			return nil
 		}
+		return tx.Create(&unmatched).Error
+	})
+	if err != nil {
+		slog.Error("Failed to record unmatched spreads", "season", season, "week", week, "error", err)
 	}
-
-	return nil, fmt.Errorf("no valid spread data found for event")
 }
 
-// UpdateGameSpreads updates the spreads for games in the database.
-func (s *OddsService) UpdateGameSpreads(ctx context.Context, season, week int) error {
-	slog.Info("Updating game spreads", "season", season, "week", week)
+// resolveTeam resolves a provider's name for a team. Names that do not resolve are
+// returned as an unregistered team, which only matches games by the same name.
+func resolveTeam(resolver *database.TeamResolver, provider, name string) *database.Team {
+	team, err := resolver.Resolve(provider, name)
+	switch {
+	case errors.Is(err, database.ErrTeamNotFound):
+		slog.Debug("Team is not registered", "provider", provider, "team", name)
+	case err != nil:
+		slog.Warn("Failed to resolve team", "provider", provider, "team", name, "error", err)
+	default:
+		return team
+	}
+	return &database.Team{DisplayName: name}
+}
 
-	// Fetch spreads from The Odds API
-	spreads, err := s.FetchSpreadsForWeek(ctx, season, week)
+// SpreadLockTime returns when the pool lines of a week are frozen, or the zero time if the week has no games.
+func (s *OddsService) SpreadLockTime(season, week int) (time.Time, error) {
+	var firstGame database.Game
+	err := s.db.GetDB().Where("season = ? AND week = ?", season, week).Order("start_time ASC").Limit(1).Find(&firstGame).Error
 	if err != nil {
-		return fmt.Errorf("failed to fetch spreads: %w", err)
+		return time.Time{}, fmt.Errorf("failed to load the first game of week %d: %w", week, err)
 	}
-
-	// Update games in database
-	updatedCount := 0
-	for _, spread := range spreads {
-		// Find the game by teams and week/season
-		var game database.Game
-		err := s.db.GetDB().Where("season = ? AND week = ? AND favorite_team = ? AND underdog_team = ?",
-			season, week, spread.HomeTeam, spread.AwayTeam).First(&game).Error
-		if err != nil {
-			slog.Warn("Game not found for spread update", "season", season, "week", week, "home", spread.HomeTeam, "away", spread.AwayTeam)
-			continue
-		}
-
-		// Update game with spread information
-		game.Spread = spread.Spread
-		game.Favorite = &spread.Favorite
-		game.Underdog = &spread.Underdog
-
-		if err := s.db.GetDB().Save(&game).Error; err != nil {
-			slog.Error("Failed to update game spread", "game_id", game.ID, "error", err)
-			continue
-		}
-
-		updatedCount++
+	if firstGame.ID == 0 {
+		return time.Time{}, nil
 	}
+	return s.spreadLock.lockTime(firstGame.StartTime), nil
+}
 
-	slog.Info("Updated game spreads", "updated_count", updatedCount, "total_spreads", len(spreads))
-	return nil
+// setGameSpread copies a fetched spread onto a game.
+func setGameSpread(game *database.Game, spread GameSpread) {
+	game.Spread = spread.Spread
+	game.Favorite = &spread.Favorite
+	game.Underdog = &spread.Underdog
+	game.SpreadStrategy = spread.Strategy
+	game.SpreadBookmakers = strings.Join(spread.Bookmakers, ",")
 }
 
-// getWeekDateRange calculates the start and end dates for a given week and season.
-func (s *OddsService) getWeekDateRange(_, week int) (time.Time, time.Time) {
-	// This is a simplified implementation - you may need to adjust based on your week calculation logic
-	// For now, we'll assume weeks start on Tuesday and end on Monday
-	weekStart := s.config.ESPN.Week1Date.Add(time.Duration((week-1)*7) * 24 * time.Hour)
+// weekDateRange returns the start and end dates for a given week and season.
+// Week boundaries come from the synced ESPN calendar, falling back to the configured
+// Week 1 date when the week has not been synced.
+func weekDateRange(db *database.Database, cfg *config.Config, season, week int) (time.Time, time.Time) {
+	if w, err := db.GetWeek(season, week); err == nil {
+		return w.WeekStartTime, w.WeekEndTime
+	}
+
+	// Assume weeks run for seven days from the Week 1 date
+	offset := week - 1
+	if week == database.SuperBowlWeek {
+		// The Super Bowl is played two weeks after the conference championships
+		offset++
+	}
+	weekStart := cfg.ESPN.Week1Date.Add(time.Duration(offset*7) * 24 * time.Hour)
 	weekEnd := weekStart.Add(7 * 24 * time.Hour)
 
 	return weekStart, weekEnd
@@ -0,0 +1,90 @@
+package oddssync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+// ErrManualSpreads is returned when spreads are fetched for a season whose lines are entered by an admin.
+var ErrManualSpreads = errors.New("spreads for the season are entered manually")
+
+// Provider supplies the lines of a week's games.
+type Provider interface {
+	// Name returns the configured name of the provider.
+	Name() string
+	// FetchSpreads returns the lines of the week's games. Providers without a request
+	// budget ignore the priority.
+	FetchSpreads(ctx context.Context, season, week int, priority FetchPriority) ([]GameSpread, error)
+}
+
+// manualProvider leaves the lines to be entered by an admin, so it never has any to fetch.
+type manualProvider struct{}
+
+// Name returns the provider's name.
+func (manualProvider) Name() string {
+	return config.OddsProviderManual
+}
+
+// FetchSpreads returns ErrManualSpreads.
+func (manualProvider) FetchSpreads(_ context.Context, _, _ int, _ FetchPriority) ([]GameSpread, error) {
+	return nil, ErrManualSpreads
+}
+
+// newProviders creates the default provider and every provider a season overrides it with, keyed by name.
+func newProviders(cfg *config.Config, oddsAPI *theOddsAPIProvider) (map[string]Provider, error) {
+	names := []string{cfg.Odds.Provider}
+	for season, name := range cfg.Odds.SeasonProviders {
+		if _, err := strconv.Atoi(season); err != nil {
+			return nil, fmt.Errorf("invalid odds provider season %q", season)
+		}
+		names = append(names, name)
+	}
+
+	providers := make(map[string]Provider, len(names))
+	for _, name := range names {
+		if name == "" {
+			name = config.OddsProviderTheOddsAPI
+		}
+		if _, ok := providers[name]; ok {
+			continue
+		}
+
+		switch name {
+		case config.OddsProviderTheOddsAPI:
+			providers[name] = oddsAPI
+		case config.OddsProviderFile:
+			provider, err := newFileProvider(cfg.Odds.FileDir)
+			if err != nil {
+				return nil, err
+			}
+			providers[name] = provider
+		case config.OddsProviderManual:
+			providers[name] = manualProvider{}
+		default:
+			return nil, fmt.Errorf("invalid odds provider %q", name)
+		}
+	}
+	return providers, nil
+}
+
+// providerFor returns the provider of a season's lines, which is the configured provider
+// unless the season overrides it.
+func (s *OddsService) providerFor(season int) Provider {
+	name := s.config.Odds.Provider
+	if override, ok := s.config.Odds.SeasonProviders[strconv.Itoa(season)]; ok {
+		name = override
+	}
+	if name == "" {
+		name = config.OddsProviderTheOddsAPI
+	}
+	return s.providers[name]
+}
+
+// ProviderName returns the name of the provider of a season's lines.
+func (s *OddsService) ProviderName(season int) string {
+	return s.providerFor(season).Name()
+}
@@ -0,0 +1,234 @@
+package oddssync
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// writeSpreadsFile writes a spreads file for a week to the odds file directory.
+func writeSpreadsFile(t *testing.T, dir string, season int, name, content string) {
+	t.Helper()
+	seasonDir := filepath.Join(dir, strconv.Itoa(season))
+	if err := os.MkdirAll(seasonDir, 0o755); err != nil {
+		t.Fatalf("Failed to create season directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(seasonDir, name), []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write spreads file: %v", err)
+	}
+}
+
+func TestFileProvider_FetchSpreads(t *testing.T) {
+	dir := t.TempDir()
+	writeSpreadsFile(t, dir, 2025, "week-1.csv", "home_team, away_team, favorite, spread\n"+
+		"Green Bay Packers, Detroit Lions, Green Bay Packers, 2.5\n"+
+		"Chicago Bears, Minnesota Vikings, Away, 3\n")
+	writeSpreadsFile(t, dir, 2025, "week-2.json", `[
+		{"home_team": "Green Bay Packers", "away_team": "Washington Commanders", "favorite": "Home", "spread": 3.5}
+	]`)
+	writeSpreadsFile(t, dir, 2025, "week-3.csv", "home_team,away_team,favorite,spread\n"+
+		"Green Bay Packers,Cleveland Browns,Dallas Cowboys,7\n")
+
+	provider, err := newFileProvider(dir)
+	if err != nil {
+		t.Fatalf("newFileProvider() error = %v", err)
+	}
+
+	spreads, err := provider.FetchSpreads(context.Background(), 2025, 1, PriorityRoutine)
+	if err != nil {
+		t.Fatalf("FetchSpreads() error = %v", err)
+	}
+	if len(spreads) != 2 {
+		t.Fatalf("Expected 2 spreads, got %d", len(spreads))
+	}
+	if spreads[0].Spread != 2.5 || spreads[0].Favorite != "Home" || spreads[0].Strategy != config.OddsProviderFile {
+		t.Errorf("Unexpected first spread: %+v", spreads[0])
+	}
+	if spreads[1].Spread != 3 || spreads[1].Favorite != "Away" || spreads[1].Underdog != "Home" {
+		t.Errorf("Unexpected second spread: %+v", spreads[1])
+	}
+
+	spreads, err = provider.FetchSpreads(context.Background(), 2025, 2, PriorityRoutine)
+	if err != nil {
+		t.Fatalf("FetchSpreads() error = %v", err)
+	}
+	if len(spreads) != 1 || spreads[0].HomeTeam != "Green Bay Packers" || spreads[0].Spread != 3.5 || spreads[0].Favorite != "Home" {
+		t.Errorf("Unexpected spreads: %+v", spreads)
+	}
+
+	if _, err := provider.FetchSpreads(context.Background(), 2025, 3, PriorityRoutine); err == nil {
+		t.Error("Expected an error for a favorite that is neither team")
+	}
+	if _, err := provider.FetchSpreads(context.Background(), 2025, 4, PriorityRoutine); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("FetchSpreads() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestParseSpreadLinesCSV_MissingColumn(t *testing.T) {
+	if _, err := ParseSpreadLinesCSV([]byte("home_team,away_team,spread\nA,B,3\n")); err == nil {
+		t.Error("Expected an error for a missing favorite column")
+	}
+}
+
+func TestNewProviders(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		seasons   map[string]string
+		fileDir   string
+		expectErr bool
+	}{
+		{"default", "", nil, "", false},
+		{"manual", config.OddsProviderManual, nil, "", false},
+		{"file", config.OddsProviderFile, nil, "odds", false},
+		{"file without directory", config.OddsProviderFile, nil, "", true},
+		{"unknown provider", "sportsbook", nil, "", true},
+		{"season override", config.OddsProviderTheOddsAPI, map[string]string{"2024": config.OddsProviderManual}, "", false},
+		{"invalid season", config.OddsProviderTheOddsAPI, map[string]string{"last": config.OddsProviderManual}, "", true},
+		{"unknown season provider", config.OddsProviderTheOddsAPI, map[string]string{"2024": "sportsbook"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Odds.Provider = tt.provider
+			cfg.Odds.SeasonProviders = tt.seasons
+			cfg.Odds.FileDir = tt.fileDir
+
+			_, err := newProviders(cfg, &theOddsAPIProvider{})
+			if (err != nil) != tt.expectErr {
+				t.Errorf("newProviders() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestOddsService_SeasonProviders(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	kickoff := time.Date(2025, 9, 12, 0, 15, 0, 0, time.UTC)
+	game := database.Game{Week: 2, Season: 2025, HomeTeam: "Green Bay Packers", AwayTeam: "Washington Commanders", StartTime: kickoff}
+	if err := db.GetDB().Create(&game).Error; err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeSpreadsFile(t, dir, 2025, "week-2.csv", "home_team,away_team,favorite,spread\n"+
+		"Green Bay Packers,Washington Commanders,Washington Commanders,1.5\n")
+
+	cfg := &config.Config{}
+	cfg.Odds.Provider = config.OddsProviderFile
+	cfg.Odds.FileDir = dir
+	cfg.Odds.SeasonProviders = map[string]string{"2024": config.OddsProviderManual}
+	cfg.ESPN.Week1Date = time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(time.Date(2025, 9, 8, 12, 0, 0, 0, time.UTC))
+	service, err := NewOddsServiceWithTimeProvider(db, cfg, appClock)
+	if err != nil {
+		t.Fatalf("Failed to create odds service: %v", err)
+	}
+
+	if name := service.ProviderName(2025); name != config.OddsProviderFile {
+		t.Errorf("ProviderName(2025) = %q, want %q", name, config.OddsProviderFile)
+	}
+	if name := service.ProviderName(2024); name != config.OddsProviderManual {
+		t.Errorf("ProviderName(2024) = %q, want %q", name, config.OddsProviderManual)
+	}
+
+	if err := service.UpdateGameSpreads(context.Background(), 2025, 2, PriorityRoutine); err != nil {
+		t.Fatalf("UpdateGameSpreads() error = %v", err)
+	}
+	var updated database.Game
+	if err := db.GetDB().First(&updated, game.ID).Error; err != nil {
+		t.Fatalf("Failed to load game: %v", err)
+	}
+	if updated.Spread != 1.5 || updated.Favorite == nil || *updated.Favorite != "Away" || updated.SpreadStrategy != config.OddsProviderFile {
+		t.Errorf("Unexpected pool line: spread %v, favorite %v, strategy %q", updated.Spread, updated.Favorite, updated.SpreadStrategy)
+	}
+
+	if err := service.UpdateGameSpreads(context.Background(), 2024, 2, PriorityRoutine); !errors.Is(err, ErrManualSpreads) {
+		t.Errorf("UpdateGameSpreads() error = %v, want %v", err, ErrManualSpreads)
+	}
+}
+
+func TestOddsService_ResolvesTeamAliases(t *testing.T) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	teams := []database.Team{
+		{ESPNID: "9", DisplayName: "Green Bay Packers", Name: "Packers"},
+		{ESPNID: "28", DisplayName: "Washington Commanders", Name: "Commanders"},
+	}
+	for i := range teams {
+		if err := db.UpsertTeam(&teams[i]); err != nil {
+			t.Fatalf("Failed to create team: %v", err)
+		}
+	}
+	if err := database.AddTeamAlias(db.GetDB(), teams[1].ID, config.OddsProviderFile, "Washington Football Team"); err != nil {
+		t.Fatalf("Failed to add alias: %v", err)
+	}
+
+	kickoff := time.Date(2025, 9, 12, 0, 15, 0, 0, time.UTC)
+	game := database.Game{
+		Week: 2, Season: 2025, HomeTeam: "Green Bay Packers", AwayTeam: "Washington Commanders",
+		HomeTeamID: &teams[0].ID, AwayTeamID: &teams[1].ID, StartTime: kickoff,
+	}
+	if err := db.GetDB().Create(&game).Error; err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeSpreadsFile(t, dir, 2025, "week-2.csv", "home_team,away_team,favorite,spread\n"+
+		"GB Packers,Washington Football Team,Home,2.5\n")
+
+	cfg := &config.Config{}
+	cfg.Odds.Provider = config.OddsProviderFile
+	cfg.Odds.FileDir = dir
+	cfg.ESPN.Week1Date = time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(time.Date(2025, 9, 8, 12, 0, 0, 0, time.UTC))
+	service, err := NewOddsServiceWithTimeProvider(db, cfg, appClock)
+	if err != nil {
+		t.Fatalf("Failed to create odds service: %v", err)
+	}
+
+	// "GB Packers" is not an alias, so the game is not found by the home team
+	if err := service.UpdateGameSpreads(context.Background(), 2025, 2, PriorityRoutine); err != nil {
+		t.Fatalf("UpdateGameSpreads() error = %v", err)
+	}
+	var updated database.Game
+	if err := db.GetDB().First(&updated, game.ID).Error; err != nil {
+		t.Fatalf("Failed to load game: %v", err)
+	}
+	if updated.SpreadStrategy != "" {
+		t.Fatalf("Expected the game not to be updated, got strategy %q", updated.SpreadStrategy)
+	}
+
+	if err := database.AddTeamAlias(db.GetDB(), teams[0].ID, config.OddsProviderFile, "GB Packers"); err != nil {
+		t.Fatalf("Failed to add alias: %v", err)
+	}
+	if err := service.UpdateGameSpreads(context.Background(), 2025, 2, PriorityRoutine); err != nil {
+		t.Fatalf("UpdateGameSpreads() error = %v", err)
+	}
+	if err := db.GetDB().First(&updated, game.ID).Error; err != nil {
+		t.Fatalf("Failed to load game: %v", err)
+	}
+	if updated.Spread != 2.5 || updated.Favorite == nil || *updated.Favorite != "Home" || updated.SpreadStrategy != config.OddsProviderFile {
+		t.Errorf("Unexpected pool line: spread %v, favorite %v, strategy %q", updated.Spread, updated.Favorite, updated.SpreadStrategy)
+	}
+}
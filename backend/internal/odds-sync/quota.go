@@ -0,0 +1,170 @@
+package oddssync
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// ErrBudgetReached is returned when a routine fetch is refused because the remaining
+// request quota has reached the configured budget floor.
+var ErrBudgetReached = errors.New("the Odds API request budget has been reached")
+
+// FetchPriority determines whether a fetch may spend requests below the budget floor.
+type FetchPriority int
+
+const (
+	// PriorityRoutine fetches are refused once the budget floor is reached.
+	PriorityRoutine FetchPriority = iota
+	// PriorityEssential fetches are always sent.
+	PriorityEssential
+)
+
+// Quota headers sent with every response from The Odds API.
+const (
+	headerRequestsRemaining = "x-requests-remaining"
+	headerRequestsUsed      = "x-requests-used"
+)
+
+// Quota is the request quota of the current billing period.
+type Quota struct {
+	Period            string
+	RequestsUsed      int
+	RequestsRemaining int
+	BudgetFloor       int
+	LastRequestAt     time.Time
+}
+
+// BudgetReached reports whether routine fetches are being refused.
+func (q *Quota) BudgetReached() bool {
+	return q.RequestsRemaining <= q.BudgetFloor
+}
+
+// Quota returns the request quota of the current billing period, or nil if no
+// request has been made during the period.
+func (s *OddsService) Quota() (*Quota, error) {
+	return s.oddsAPI.quota()
+}
+
+// quota returns the request quota of the current billing period, or nil if there is none.
+func (p *theOddsAPIProvider) quota() (*Quota, error) {
+	usage, err := p.currentUsage()
+	if err != nil || usage == nil {
+		return nil, err
+	}
+
+	return &Quota{
+		Period:            usage.Period,
+		RequestsUsed:      usage.RequestsUsed,
+		RequestsRemaining: usage.RequestsRemaining,
+		BudgetFloor:       p.config.TheOddsAPI.BudgetFloor,
+		LastRequestAt:     usage.LastRequestAt,
+	}, nil
+}
+
+// checkBudget returns ErrBudgetReached if a fetch of the given priority should not be sent.
+func (p *theOddsAPIProvider) checkBudget(priority FetchPriority) error {
+	if priority == PriorityEssential {
+		return nil
+	}
+
+	quota, err := p.quota()
+	if err != nil {
+		// An unknown quota should not block fetches
+		slog.Warn("Failed to load the Odds API quota", "error", err)
+		return nil
+	}
+	if quota != nil && quota.BudgetReached() {
+		return fmt.Errorf("%w: %d requests remaining, budget floor is %d", ErrBudgetReached, quota.RequestsRemaining, quota.BudgetFloor)
+	}
+	return nil
+}
+
+// recordUsage stores the quota reported by a response's headers. Responses without
+// quota headers are ignored.
+func (p *theOddsAPIProvider) recordUsage(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get(headerRequestsRemaining))
+	if err != nil {
+		return
+	}
+	used, err := strconv.Atoi(header.Get(headerRequestsUsed))
+	if err != nil {
+		return
+	}
+
+	now := p.timeProvider.Now()
+	current, err := p.currentUsage()
+	if err != nil {
+		slog.Error("Failed to load the Odds API usage", "error", err)
+		return
+	}
+
+	usage := database.OddsAPIUsage{Period: usagePeriod(now, p.config.TheOddsAPI.QuotaResetDay).Format(periodLayout)}
+	if current != nil && used < current.RequestsUsed {
+		// The quota was reset before the configured reset day, so a new period starts today
+		usage.Period = now.UTC().Format(periodLayout)
+		slog.Info("The Odds API quota was reset", "previous_period", current.Period, "period", usage.Period)
+	} else if current != nil {
+		usage.Period = current.Period
+	}
+	if err := p.db.GetDB().Where("period = ?", usage.Period).FirstOrInit(&usage).Error; err != nil {
+		slog.Error("Failed to load the Odds API usage", "period", usage.Period, "error", err)
+		return
+	}
+
+	usage.RequestsUsed = used
+	usage.RequestsRemaining = remaining
+	usage.LastRequestAt = now
+	if err := p.db.GetDB().Save(&usage).Error; err != nil {
+		slog.Error("Failed to record the Odds API usage", "period", usage.Period, "error", err)
+		return
+	}
+
+	slog.Info("The Odds API quota", "period", usage.Period, "used", used, "remaining", remaining)
+}
+
+// currentUsage returns the usage of the current billing period, or nil if there is none.
+// A period ends on the next configured reset day after it started.
+func (p *theOddsAPIProvider) currentUsage() (*database.OddsAPIUsage, error) {
+	var usage database.OddsAPIUsage
+	err := p.db.GetDB().Order("period DESC").First(&usage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(periodLayout, usage.Period)
+	if err != nil {
+		// Periods recorded by calendar month are no longer current
+		return nil, nil
+	}
+	resetDay := p.config.TheOddsAPI.QuotaResetDay
+	if !p.timeProvider.Now().Before(usagePeriod(start, resetDay).AddDate(0, 1, 0)) {
+		return nil, nil
+	}
+	return &usage, nil
+}
+
+// periodLayout formats the start date of a billing period.
+const periodLayout = "2006-01-02"
+
+// usagePeriod returns the start of the billing period containing t. The Odds API quota resets
+// monthly on the day of the month the subscription started, which is capped at the 28th so
+// that every month has one.
+func usagePeriod(t time.Time, resetDay int) time.Time {
+	resetDay = min(max(resetDay, 1), 28)
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), resetDay, 0, 0, 0, 0, time.UTC)
+	if t.Before(start) {
+		start = start.AddDate(0, -1, 0)
+	}
+	return start
+}
@@ -0,0 +1,180 @@
+package oddssync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// setupQuotaTest creates an odds service backed by a fake Odds API reporting the given remaining requests.
+func setupQuotaTest(t *testing.T, remaining *int) (*OddsService, *clock.Adjustable, *int) {
+	var requests int
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("x-requests-remaining", strconv.Itoa(*remaining))
+		w.Header().Set("x-requests-used", strconv.Itoa(500-*remaining))
+		_, _ = w.Write([]byte("[]"))
+	}))
+	t.Cleanup(upstream.Close)
+
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.TheOddsAPI.BaseURL = upstream.URL
+	cfg.TheOddsAPI.Region = "us"
+	cfg.TheOddsAPI.BudgetFloor = 50
+	cfg.ESPN.Week1Date = time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC))
+	service, err := NewOddsServiceWithTimeProvider(db, cfg, appClock)
+	if err != nil {
+		t.Fatalf("Failed to create odds service: %v", err)
+	}
+	return service, appClock, &requests
+}
+
+func TestOddsService_RecordsQuota(t *testing.T) {
+	remaining := 120
+	service, _, _ := setupQuotaTest(t, &remaining)
+
+	quota, err := service.Quota()
+	if err != nil {
+		t.Fatalf("Quota() error = %v", err)
+	}
+	if quota != nil {
+		t.Fatalf("Expected no quota before the first request, got %+v", quota)
+	}
+
+	if _, err := service.FetchSpreadsForWeek(context.Background(), 2025, 2, PriorityRoutine); err != nil {
+		t.Fatalf("FetchSpreadsForWeek() error = %v", err)
+	}
+
+	quota, err = service.Quota()
+	if err != nil {
+		t.Fatalf("Quota() error = %v", err)
+	}
+	if quota == nil || quota.Period != "2025-09-01" || quota.RequestsRemaining != 120 || quota.RequestsUsed != 380 || quota.BudgetReached() {
+		t.Errorf("Unexpected quota: %+v", quota)
+	}
+}
+
+func TestOddsService_BudgetFloor(t *testing.T) {
+	remaining := 50
+	service, appClock, requests := setupQuotaTest(t, &remaining)
+
+	if _, err := service.FetchSpreadsForWeek(context.Background(), 2025, 2, PriorityRoutine); err != nil {
+		t.Fatalf("FetchSpreadsForWeek() error = %v", err)
+	}
+
+	// Routine fetches are refused without spending a request
+	if _, err := service.FetchSpreadsForWeek(context.Background(), 2025, 2, PriorityRoutine); !errors.Is(err, ErrBudgetReached) {
+		t.Errorf("FetchSpreadsForWeek() error = %v, want %v", err, ErrBudgetReached)
+	}
+	if err := service.UpdateGameSpreads(context.Background(), 2025, 2, PriorityRoutine); !errors.Is(err, ErrBudgetReached) {
+		t.Errorf("UpdateGameSpreads() error = %v, want %v", err, ErrBudgetReached)
+	}
+	if *requests != 1 {
+		t.Errorf("Expected 1 request, got %d", *requests)
+	}
+
+	// Essential fetches are still sent
+	remaining = 49
+	if _, err := service.FetchSpreadsForWeek(context.Background(), 2025, 2, PriorityEssential); err != nil {
+		t.Errorf("FetchSpreadsForWeek() error = %v", err)
+	}
+	if *requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", *requests)
+	}
+
+	// A new billing period starts with an unknown quota
+	appClock.Set(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC))
+	remaining = 500
+	if _, err := service.FetchSpreadsForWeek(context.Background(), 2025, 5, PriorityRoutine); err != nil {
+		t.Errorf("FetchSpreadsForWeek() error = %v", err)
+	}
+
+	var periods int64
+	service.db.GetDB().Model(&database.OddsAPIUsage{}).Count(&periods)
+	if periods != 2 {
+		t.Errorf("Expected usage for 2 periods, got %d", periods)
+	}
+}
+
+func TestOddsService_QuotaReset(t *testing.T) {
+	remaining := 40
+	service, appClock, _ := setupQuotaTest(t, &remaining)
+	service.config.TheOddsAPI.QuotaResetDay = 15
+
+	// The billing period started on the 15th of last month
+	if _, err := service.FetchSpreadsForWeek(context.Background(), 2025, 2, PriorityEssential); err != nil {
+		t.Fatalf("FetchSpreadsForWeek() error = %v", err)
+	}
+	quota, err := service.Quota()
+	if err != nil {
+		t.Fatalf("Quota() error = %v", err)
+	}
+	if quota == nil || quota.Period != "2025-08-15" || !quota.BudgetReached() {
+		t.Fatalf("Unexpected quota: %+v", quota)
+	}
+
+	// A drop in the requests used starts a new period before the reset day
+	appClock.Set(time.Date(2025, 9, 12, 12, 0, 0, 0, time.UTC))
+	remaining = 498
+	if _, err := service.FetchSpreadsForWeek(context.Background(), 2025, 2, PriorityEssential); err != nil {
+		t.Fatalf("FetchSpreadsForWeek() error = %v", err)
+	}
+	quota, err = service.Quota()
+	if err != nil {
+		t.Fatalf("Quota() error = %v", err)
+	}
+	if quota == nil || quota.Period != "2025-09-12" || quota.RequestsUsed != 2 || quota.BudgetReached() {
+		t.Errorf("Unexpected quota after the reset: %+v", quota)
+	}
+
+	// The period ends on the configured reset day
+	appClock.Set(time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC))
+	quota, err = service.Quota()
+	if err != nil {
+		t.Fatalf("Quota() error = %v", err)
+	}
+	if quota != nil {
+		t.Errorf("Expected no quota on the reset day, got %+v", quota)
+	}
+}
+
+func TestUsagePeriod(t *testing.T) {
+	tests := []struct {
+		name     string
+		t        time.Time
+		resetDay int
+		expected string
+	}{
+		{"calendar month", time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC), 1, "2025-09-01"},
+		{"before the reset day", time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC), 20, "2025-08-20"},
+		{"on the reset day", time.Date(2025, 9, 20, 0, 0, 0, 0, time.UTC), 20, "2025-09-20"},
+		{"before the reset day in January", time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC), 5, "2025-12-05"},
+		{"unset reset day", time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC), 0, "2025-09-01"},
+		{"reset day missing from some months", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 31, "2025-02-28"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := usagePeriod(tt.t, tt.resetDay).Format(periodLayout); got != tt.expected {
+				t.Errorf("usagePeriod() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package oddssync
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+// spreadLock determines when the pool line of a week is frozen.
+type spreadLock struct {
+	// atPickLock freezes the line at the week's first kickoff
+	atPickLock bool
+	weekday    time.Weekday
+	hour       int
+	minute     int
+	location   *time.Location
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// parseSpreadLock parses the spread lock configuration. An empty value locks at pick lock.
+func parseSpreadLock(spec, timezone string) (*spreadLock, error) {
+	if spec == "" || spec == config.SpreadLockPickLock {
+		return &spreadLock{atPickLock: true}, nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid spread lock %q: expected %q or a weekday and time such as \"Tue 12:00\"", spec, config.SpreadLockPickLock)
+	}
+
+	// Weekdays may be abbreviated to three letters or spelled out
+	name := strings.ToLower(fields[0])
+	weekday, ok := weekdays[name[:min(3, len(name))]]
+	if !ok || len(name) < 3 || !strings.HasPrefix(strings.ToLower(weekday.String()), name) {
+		return nil, fmt.Errorf("invalid spread lock weekday %q", fields[0])
+	}
+
+	clock, err := time.Parse("15:04", fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid spread lock time %q: %w", fields[1], err)
+	}
+
+	location := time.UTC
+	if timezone != "" {
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spread lock timezone %q: %w", timezone, err)
+		}
+	}
+
+	return &spreadLock{
+		weekday:  weekday,
+		hour:     clock.Hour(),
+		minute:   clock.Minute(),
+		location: location,
+	}, nil
+}
+
+// lockTime returns when the pool line is frozen for a week whose first game kicks off at firstKickoff.
+// Weekday locks use the last occurrence of the weekday and time before the first kickoff.
+func (l *spreadLock) lockTime(firstKickoff time.Time) time.Time {
+	if l.atPickLock {
+		return firstKickoff
+	}
+
+	local := firstKickoff.In(l.location)
+	candidate := time.Date(local.Year(), local.Month(), local.Day(), l.hour, l.minute, 0, 0, l.location)
+	for candidate.Weekday() != l.weekday || !candidate.Before(firstKickoff) {
+		candidate = candidate.AddDate(0, 0, -1)
+	}
+	return candidate
+}
@@ -0,0 +1,154 @@
+package oddssync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+func TestParseSpreadLock(t *testing.T) {
+	valid := []string{"", config.SpreadLockPickLock, "Tue 12:00", "tuesday 09:30", "SAT 23:59"}
+	for _, spec := range valid {
+		if _, err := parseSpreadLock(spec, "America/New_York"); err != nil {
+			t.Errorf("parseSpreadLock(%q) error = %v", spec, err)
+		}
+	}
+
+	invalid := []string{"Tue", "Tu 12:00", "Tuexyz 12:00", "Tue 25:00", "Tue noon", "Tue 12:00 ET"}
+	for _, spec := range invalid {
+		if _, err := parseSpreadLock(spec, "America/New_York"); err == nil {
+			t.Errorf("parseSpreadLock(%q) expected error", spec)
+		}
+	}
+
+	if _, err := parseSpreadLock("Tue 12:00", "Nowhere/Invalid"); err == nil {
+		t.Error("parseSpreadLock() expected error for invalid timezone")
+	}
+}
+
+func TestSpreadLock_LockTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+	// Thursday night kickoff, 8:20pm Eastern
+	firstKickoff := time.Date(2025, 9, 12, 0, 20, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{spec: config.SpreadLockPickLock, expected: firstKickoff},
+		{spec: "Tue 12:00", expected: time.Date(2025, 9, 9, 12, 0, 0, 0, newYork)},
+		{spec: "Thu 12:00", expected: time.Date(2025, 9, 11, 12, 0, 0, 0, newYork)},
+		// The lock must come before the kickoff, so a later time falls back a week
+		{spec: "Thu 21:00", expected: time.Date(2025, 9, 4, 21, 0, 0, 0, newYork)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			lock, err := parseSpreadLock(tt.spec, "America/New_York")
+			if err != nil {
+				t.Fatalf("parseSpreadLock() error = %v", err)
+			}
+			if got := lock.lockTime(firstKickoff); !got.Equal(tt.expected) {
+				t.Errorf("lockTime() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOddsService_UpdateGameSpreadsFreezesPoolLine(t *testing.T) {
+	homePoint := 3.5
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `[{
+			"id": "abc",
+			"home_team": "Green Bay Packers",
+			"away_team": "Washington Commanders",
+			"bookmakers": [{
+				"key": "draftkings",
+				"markets": [{
+					"key": "spreads",
+					"outcomes": [
+						{"name": "Green Bay Packers", "point": %v},
+						{"name": "Washington Commanders", "point": %v}
+					]
+				}]
+			}]
+		}]`, homePoint, -homePoint)
+	}))
+	defer upstream.Close()
+
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	kickoff := time.Date(2025, 9, 12, 0, 15, 0, 0, time.UTC)
+	game := database.Game{Week: 2, Season: 2025, HomeTeam: "Green Bay Packers", AwayTeam: "Washington Commanders", StartTime: kickoff}
+	if err := db.GetDB().Create(&game).Error; err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.TheOddsAPI.BaseURL = upstream.URL
+	cfg.Pool.SpreadLock = "Tue 12:00"
+	cfg.Pool.SpreadLockTimezone = "America/New_York"
+	cfg.ESPN.Week1Date = time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(time.Date(2025, 9, 8, 12, 0, 0, 0, time.UTC))
+	service, err := NewOddsServiceWithTimeProvider(db, cfg, appClock)
+	if err != nil {
+		t.Fatalf("Failed to create odds service: %v", err)
+	}
+
+	// Before the lock the pool line follows the market
+	if err := service.UpdateGameSpreads(context.Background(), 2025, 2, PriorityEssential); err != nil {
+		t.Fatalf("UpdateGameSpreads() error = %v", err)
+	}
+	homePoint = 4.5
+	appClock.Set(time.Date(2025, 9, 9, 15, 0, 0, 0, time.UTC))
+	if err := service.UpdateGameSpreads(context.Background(), 2025, 2, PriorityEssential); err != nil {
+		t.Fatalf("UpdateGameSpreads() error = %v", err)
+	}
+	assertPoolLine(t, db, game.ID, 4.5, false)
+
+	// After Tuesday noon Eastern the line is frozen while snapshots keep being recorded
+	homePoint = 6
+	appClock.Set(time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC))
+	if err := service.UpdateGameSpreads(context.Background(), 2025, 2, PriorityEssential); err != nil {
+		t.Fatalf("UpdateGameSpreads() error = %v", err)
+	}
+	assertPoolLine(t, db, game.ID, 4.5, true)
+
+	var snapshots []database.SpreadSnapshot
+	if err := db.GetDB().Where("game_id = ?", game.ID).Order("fetched_at").Find(&snapshots).Error; err != nil {
+		t.Fatalf("Failed to load snapshots: %v", err)
+	}
+	if len(snapshots) != 3 || snapshots[2].Spread != 6 || snapshots[2].Bookmakers != "draftkings" || snapshots[2].Strategy != config.SpreadStrategyPreferred || snapshots[2].Favorite != "Home" {
+		t.Errorf("Unexpected snapshots: %+v", snapshots)
+	}
+}
+
+func assertPoolLine(t *testing.T, db *database.Database, gameID uint, spread float32, locked bool) {
+	t.Helper()
+	var game database.Game
+	if err := db.GetDB().First(&game, gameID).Error; err != nil {
+		t.Fatalf("Failed to load game: %v", err)
+	}
+	if game.Spread != spread || game.Favorite == nil || *game.Favorite != "Home" {
+		t.Errorf("Pool line = %v (favorite %v), want %v", game.Spread, game.Favorite, spread)
+	}
+	if (game.SpreadLockedAt != nil) != locked {
+		t.Errorf("Pool line locked = %v, want %v", game.SpreadLockedAt != nil, locked)
+	}
+}
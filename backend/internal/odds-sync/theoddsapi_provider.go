@@ -0,0 +1,237 @@
+package oddssync
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	theoddsapi "github.com/dhpollack/football-pool/internal/api-the-odds-api"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/upstream"
+)
+
+// theOddsAPIProvider fetches lines from The Odds API, choosing each game's line from
+// the bookmakers' lines with the configured spread strategy.
+type theOddsAPIProvider struct {
+	db           *database.Database
+	client       *theoddsapi.ClientWithResponses
+	http         *upstream.Client
+	config       *config.Config
+	timeProvider TimeProvider
+	selector     *spreadSelector
+}
+
+// newTheOddsAPIProvider creates a provider for The Odds API.
+func newTheOddsAPIProvider(db *database.Database, cfg *config.Config, timeProvider TimeProvider) (*theOddsAPIProvider, error) {
+	selector, err := newSpreadSelector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create The Odds API client
+	oddsHTTP, err := upstream.NewClient("theoddsapi", cfg)
+	if err != nil {
+		return nil, err
+	}
+	client, err := theoddsapi.NewClientWithResponses(cfg.TheOddsAPI.BaseURL, theoddsapi.WithHTTPClient(oddsHTTP))
+	if err != nil {
+		return nil, err
+	}
+
+	return &theOddsAPIProvider{
+		db:           db,
+		client:       client,
+		http:         oddsHTTP,
+		config:       cfg,
+		timeProvider: timeProvider,
+		selector:     selector,
+	}, nil
+}
+
+// Name returns the provider's name.
+func (p *theOddsAPIProvider) Name() string {
+	return config.OddsProviderTheOddsAPI
+}
+
+// FetchSpreads fetches the spreads of a week's games from The Odds API.
+// Routine fetches are refused with ErrBudgetReached once the request quota reaches the budget floor.
+func (p *theOddsAPIProvider) FetchSpreads(ctx context.Context, season, week int, priority FetchPriority) ([]GameSpread, error) {
+	if err := p.checkBudget(priority); err != nil {
+		return nil, err
+	}
+
+	// Calculate date range for the week
+	weekStart, weekEnd := weekDateRange(p.db, p.config, season, week)
+
+	// Fetch odds from The Odds API
+	params := &theoddsapi.GetOddsParams{
+		ApiKey:  p.config.TheOddsAPI.APIKey,
+		Regions: theoddsapi.GetOddsParamsRegions(p.config.TheOddsAPI.Region),
+		Markets: func() *theoddsapi.GetOddsParamsMarkets {
+			market := theoddsapi.GetOddsParamsMarketsSpreads
+			return &market
+		}(),
+		CommenceTimeFrom: func() *string {
+			from := weekStart.Format(time.RFC3339)
+			return &from
+		}(),
+		CommenceTimeTo: func() *string {
+			to := weekEnd.Format(time.RFC3339)
+			return &to
+		}(),
+	}
+
+	response, err := p.client.GetOddsWithResponse(ctx, "americanfootball_nfl", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch odds: %w", err)
+	}
+
+	p.recordUsage(response.HTTPResponse.Header)
+
+	if response.StatusCode() != 200 {
+		return nil, fmt.Errorf("the Odds API returned status %d: %s", response.StatusCode(), response.Status())
+	}
+
+	if response.JSON200 == nil {
+		slog.Error("The Odds API returned nil JSON200 response", "status", response.StatusCode(), "body", string(response.Body))
+		return nil, fmt.Errorf("the Odds API returned empty response")
+	}
+
+	// Parse the response and extract spreads
+	spreads := make([]GameSpread, 0, len(*response.JSON200))
+	for _, event := range *response.JSON200 {
+		if event.HomeTeam == nil || event.AwayTeam == nil {
+			slog.Debug("Skipping event with missing team information")
+			continue
+		}
+
+		spread, err := p.extractSpreadFromEvent(event)
+		if err != nil {
+			slog.Warn("Failed to extract spread from event", "home_team", *event.HomeTeam, "away_team", *event.AwayTeam, "error", err)
+			continue
+		}
+
+		spreads = append(spreads, *spread)
+	}
+
+	return spreads, nil
+}
+
+// extractSpreadFromEvent extracts spread information from an odds API event.
+func (p *theOddsAPIProvider) extractSpreadFromEvent(event struct {
+	AwayTeam   *theoddsapi.AwayTeam `json:"away_team"`
+	Bookmakers *[]struct {
+		Key        *string    `json:"key,omitempty"`
+		LastUpdate *time.Time `json:"last_update,omitempty"`
+		Link       *string    `json:"link"`
+		Markets    *[]struct {
+			Key        *theoddsapi.GetOdds200BookmakersMarketsKey `json:"key,omitempty"`
+			LastUpdate *time.Time                                 `json:"last_update,omitempty"`
+			Link       *string                                    `json:"link"`
+			Outcomes   *[]theoddsapi.Outcome                      `json:"outcomes,omitempty"`
+			Sid        *string                                    `json:"sid"`
+		} `json:"markets,omitempty"`
+		Sid   *string `json:"sid"`
+		Title *string `json:"title,omitempty"`
+	} `json:"bookmakers,omitempty"`
+	CommenceTime *theoddsapi.CommenceTime `json:"commence_time,omitempty"`
+	HomeTeam     *theoddsapi.HomeTeam     `json:"home_team"`
+	Id           *theoddsapi.MatchId      `json:"id,omitempty"` //nolint:revive,staticcheck // Field name matches generated API
+	SportKey     *theoddsapi.SportKey     `json:"sport_key,omitempty"`
+	SportTitle   *theoddsapi.SportTitle   `json:"sport_title,omitempty"`
+},
+) (*GameSpread, error) {
+	if event.Bookmakers == nil || len(*event.Bookmakers) == 0 {
+		return nil, fmt.Errorf("no bookmakers found for event")
+	}
+
+	// Collect the line of every bookmaker that has spreads data
+	var lines []bookmakerLine
+	var homeTeam, awayTeam string
+	for _, bookmaker := range *event.Bookmakers {
+		if bookmaker.Markets == nil {
+			continue
+		}
+
+		var bookmakerKey string
+		if bookmaker.Key != nil {
+			bookmakerKey = *bookmaker.Key
+		}
+
+		for _, market := range *bookmaker.Markets {
+			if market.Key == nil || *market.Key != "spreads" {
+				continue
+			}
+
+			if market.Outcomes == nil || len(*market.Outcomes) != 2 {
+				continue
+			}
+
+			// Extract spread information from outcomes
+			outcomes := *market.Outcomes
+			var homeSpread, awaySpread float32
+
+			for _, outcome := range outcomes {
+				if outcome.Point == nil {
+					continue
+				}
+
+				// Determine which team this outcome belongs to
+				if outcome.Name != nil {
+					switch *outcome.Name {
+					case *event.HomeTeam:
+						homeSpread = *outcome.Point
+						homeTeam = *event.HomeTeam
+					case *event.AwayTeam:
+						awaySpread = *outcome.Point
+						awayTeam = *event.AwayTeam
+					}
+				}
+			}
+
+			// Determine favorite and underdog based on spreads
+			// Positive spread indicates the favorite
+			switch {
+			case homeSpread > 0 && awaySpread < 0:
+				// Home team is favorite
+				lines = append(lines, bookmakerLine{bookmaker: bookmakerKey, homeEdge: homeSpread})
+			case awaySpread > 0 && homeSpread < 0:
+				// Away team is favorite
+				lines = append(lines, bookmakerLine{bookmaker: bookmakerKey, homeEdge: -awaySpread})
+			case homeSpread == 0 && awaySpread == 0:
+				// Even spread
+				lines = append(lines, bookmakerLine{bookmaker: bookmakerKey})
+			}
+		}
+	}
+
+	edge, bookmakers, err := p.selector.selectLine(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	spread := &GameSpread{
+		Strategy:   p.selector.strategy,
+		Bookmakers: bookmakers,
+		HomeTeam:   homeTeam,
+		AwayTeam:   awayTeam,
+		Spread:     edge,
+		Favorite:   "Home",
+		Underdog:   "Away",
+	}
+	if event.Id != nil {
+		spread.EventID = *event.Id
+	}
+	if event.CommenceTime != nil {
+		spread.CommenceTime = *event.CommenceTime
+	}
+	if edge < 0 {
+		spread.Spread = -edge
+		spread.Favorite = "Away"
+		spread.Underdog = "Home"
+	}
+	// Even spreads default to the home team as favorite
+	return spread, nil
+}
@@ -0,0 +1,225 @@
+// Package scoring grades picks against game results and scores them under the pool's rule set.
+package scoring
+
+import (
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// PlayerScore is a player's score over a set of games.
+type PlayerScore struct {
+	UserID uint
+	// Points includes the week bonuses
+	Points    float32
+	Bonus     float32
+	Correct   int
+	Incorrect int
+	Pushes    int
+	Pending   int
+	// HighestCorrectRank is the rank of the player's highest-ranked correct pick
+	HighestCorrectRank int
+	// MaxPoints is the most the player can still score if every pending pick is correct and
+	// every game they can still pick is picked correctly, including the week bonuses that
+	// remain possible
+	MaxPoints float32
+	// QuickPick is set when any of the player's picks was made with the quick pick
+	QuickPick bool
+}
+
+// weekKey identifies a week of a season.
+type weekKey struct {
+	season, week int
+}
+
+// Score grades the picks on the given games and scores them under the rules, keyed by
+// player. Picks on other games are ignored, and week bonuses are awarded for each week
+// of the games. Every given player is scored, along with everyone who picked.
+//
+// The most a player can score counts the games they have not picked in weeks whose pick
+// sheets are still open at now, as if they were picked correctly with the highest ranks of
+// the week the player has left.
+func Score(rules Rules, games []database.Game, results []database.Result, picks []database.Pick, players []uint, now time.Time) map[uint]*PlayerScore {
+	gamesMap := make(map[uint]database.Game, len(games))
+	weekGames := make(map[weekKey][]database.Game)
+	for _, game := range games {
+		gamesMap[game.ID] = game
+		key := weekKey{game.Season, game.Week}
+		weekGames[key] = append(weekGames[key], game)
+	}
+
+	resultsMap := make(map[uint]database.Result, len(results))
+	for _, result := range results {
+		resultsMap[result.GameID] = result
+	}
+
+	scores := make(map[uint]*PlayerScore)
+	weeks := make(map[uint]map[weekKey]*Week)
+	// bestWeeks are the weeks as they turn out if every pending pick is correct
+	bestWeeks := make(map[uint]map[weekKey]*Week)
+	addPlayer := func(userID uint) *PlayerScore {
+		score, ok := scores[userID]
+		if !ok {
+			score = &PlayerScore{UserID: userID}
+			scores[userID] = score
+			weeks[userID] = make(map[weekKey]*Week)
+			bestWeeks[userID] = make(map[weekKey]*Week)
+		}
+		return score
+	}
+	for _, userID := range players {
+		addPlayer(userID)
+	}
+
+	for _, pick := range picks {
+		game, ok := gamesMap[pick.GameID]
+		if !ok {
+			continue
+		}
+		score := addPlayer(pick.UserID)
+
+		graded := GradedPick{Pick: pick, Grade: Pending}
+		if result, ok := resultsMap[pick.GameID]; ok {
+			graded.Grade = rules.Grade(pick, game, result)
+			graded.Points = rules.Points(pick, graded.Grade)
+		}
+
+		switch graded.Grade {
+		case Correct:
+			score.Correct++
+			score.HighestCorrectRank = max(score.HighestCorrectRank, pick.Rank)
+		case Incorrect:
+			score.Incorrect++
+		case Push:
+			score.Pushes++
+		default:
+			score.Pending++
+		}
+		score.Points += graded.Points
+		score.QuickPick = score.QuickPick || pick.QuickPick
+
+		best := graded
+		if best.Grade == Pending {
+			best.Grade = Correct
+			best.Points = rules.Points(pick, Correct)
+		}
+		score.MaxPoints += best.Points
+
+		key := weekKey{game.Season, game.Week}
+		week, ok := weeks[pick.UserID][key]
+		if !ok {
+			week = &Week{Games: len(weekGames[key])}
+			weeks[pick.UserID][key] = week
+		}
+		week.Picks = append(week.Picks, graded)
+		playerBest := bestWeek(bestWeeks[pick.UserID], key, len(weekGames[key]))
+		playerBest.Picks = append(playerBest.Picks, best)
+	}
+
+	for key, games := range weekGames {
+		if !sheetOpen(games, resultsMap, now) {
+			continue
+		}
+		for userID, score := range scores {
+			week := bestWeek(bestWeeks[userID], key, len(games))
+			for _, pick := range unpickedGames(games, week.Picks) {
+				best := GradedPick{Pick: pick, Grade: Correct, Points: rules.Points(pick, Correct)}
+				score.MaxPoints += best.Points
+				week.Picks = append(week.Picks, best)
+			}
+		}
+	}
+
+	for userID, playerWeeks := range weeks {
+		for _, week := range playerWeeks {
+			bonus := rules.WeekBonus(*week)
+			scores[userID].Bonus += bonus
+			scores[userID].Points += bonus
+		}
+	}
+	for userID, playerWeeks := range bestWeeks {
+		for _, week := range playerWeeks {
+			scores[userID].MaxPoints += rules.WeekBonus(*week)
+		}
+	}
+	return scores
+}
+
+// bestWeek returns a player's best week of the given key, creating it if they have none.
+func bestWeek(weeks map[weekKey]*Week, key weekKey, games int) *Week {
+	week, ok := weeks[key]
+	if !ok {
+		week = &Week{Games: games}
+		weeks[key] = week
+	}
+	return week
+}
+
+// sheetOpen reports whether picks can still be made on the games of a week, which is until
+// the first of them kicks off.
+func sheetOpen(games []database.Game, results map[uint]database.Result, now time.Time) bool {
+	for _, game := range games {
+		if _, ok := results[game.ID]; ok || !game.StartTime.After(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// unpickedGames returns picks on the games of a week that a player has not picked, ranked
+// with the highest confidence ranks of the week they have left.
+func unpickedGames(games []database.Game, picks []GradedPick) []database.Pick {
+	picked := make(map[uint]bool, len(picks))
+	used := make(map[int]bool, len(picks))
+	for _, pick := range picks {
+		picked[pick.GameID] = true
+		used[pick.Rank] = true
+	}
+
+	var ranks []int
+	for rank := len(games); rank > 0; rank-- {
+		if !used[rank] {
+			ranks = append(ranks, rank)
+		}
+	}
+
+	var unpicked []database.Pick
+	for _, game := range games {
+		if picked[game.ID] {
+			continue
+		}
+		pick := database.Pick{GameID: game.ID}
+		if len(ranks) > 0 {
+			pick.Rank, ranks = ranks[0], ranks[1:]
+		}
+		unpicked = append(unpicked, pick)
+	}
+	return unpicked
+}
+
+// ScoreGames loads the results and picks of the given games and scores them under the rules
+// for every pool member.
+func ScoreGames(db *gorm.DB, rules Rules, games []database.Game, now time.Time) (map[uint]*PlayerScore, error) {
+	gameIDs := make([]uint, len(games))
+	for i, game := range games {
+		gameIDs[i] = game.ID
+	}
+
+	var results []database.Result
+	if err := db.Where("game_id IN ?", gameIDs).Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	var picks []database.Pick
+	if err := db.Where("game_id IN ?", gameIDs).Find(&picks).Error; err != nil {
+		return nil, err
+	}
+
+	var members []uint
+	if err := db.Model(&database.User{}).Pluck("id", &members).Error; err != nil {
+		return nil, err
+	}
+
+	return Score(rules, games, results, picks, members, now), nil
+}
@@ -0,0 +1,118 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+func TestScore(t *testing.T) {
+	games := []database.Game{
+		{Week: 1, Season: 2025},
+		{Week: 1, Season: 2025},
+		{Week: 2, Season: 2025},
+	}
+	for i := range games {
+		games[i].ID = uint(i + 1)
+	}
+	results := []database.Result{
+		{GameID: 1, Outcome: SideFavorite, FavoriteScore: 30, UnderdogScore: 10},
+		{GameID: 2, Outcome: OutcomePush, FavoriteScore: 20, UnderdogScore: 17},
+	}
+	picks := []database.Pick{
+		// Alice picks week 1 perfectly under push as win
+		{UserID: 1, GameID: 1, Picked: SideFavorite, Rank: 2},
+		{UserID: 1, GameID: 2, Picked: SideUnderdog, Rank: 1},
+		{UserID: 1, GameID: 3, Picked: SideFavorite, Rank: 1},
+		// Bob misses the first game and quick picks the second
+		{UserID: 2, GameID: 1, Picked: SideUnderdog, Rank: 1},
+		{UserID: 2, GameID: 2, Picked: SideFavorite, Rank: 2, QuickPick: true},
+		// Picks on other games are ignored
+		{UserID: 2, GameID: 99, Picked: SideFavorite, Rank: 16},
+	}
+
+	scores := Score(Classic(), games, results, picks, nil, time.Time{})
+	if len(scores) != 2 {
+		t.Fatalf("Expected 2 players, got %d", len(scores))
+	}
+	alice, bob := scores[1], scores[2]
+	if alice.Points != 2.5 || alice.Correct != 1 || alice.Pushes != 1 || alice.Pending != 1 || alice.Bonus != 0 || alice.MaxPoints != 3.5 || alice.QuickPick || alice.HighestCorrectRank != 2 {
+		t.Errorf("Unexpected score for Alice: %+v", alice)
+	}
+	if bob.Points != 1 || bob.Incorrect != 1 || bob.Pushes != 1 || bob.MaxPoints != 1 || !bob.QuickPick {
+		t.Errorf("Unexpected score for Bob: %+v", bob)
+	}
+
+	cfg := &config.Config{}
+	cfg.Pool.Scoring.Push = config.PushScoringWin
+	cfg.Pool.Scoring.PerfectWeekBonus = 5
+	rules, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	scores = Score(rules, games, results, picks, nil, time.Time{})
+	// Alice can still make week 2 perfect
+	if alice := scores[1]; alice.Points != 8 || alice.Bonus != 5 || alice.MaxPoints != 14 {
+		t.Errorf("Unexpected score for Alice with a perfect week bonus: %+v", alice)
+	}
+	if bob := scores[2]; bob.Points != 2 || bob.Bonus != 0 {
+		t.Errorf("Unexpected score for Bob with a perfect week bonus: %+v", bob)
+	}
+}
+
+func TestScoreOpenSheets(t *testing.T) {
+	now := time.Date(2025, 9, 20, 12, 0, 0, 0, time.UTC)
+	games := []database.Game{
+		// Week 3 has kicked off, so its sheet is locked
+		{Week: 3, Season: 2025, StartTime: now.Add(-time.Hour)},
+		{Week: 3, Season: 2025, StartTime: now.Add(time.Hour)},
+		// Weeks 4 and 5 are still open
+		{Week: 4, Season: 2025, StartTime: now.Add(5 * 24 * time.Hour)},
+		{Week: 4, Season: 2025, StartTime: now.Add(5 * 24 * time.Hour)},
+		{Week: 4, Season: 2025, StartTime: now.Add(6 * 24 * time.Hour)},
+		{Week: 5, Season: 2025, StartTime: now.Add(12 * 24 * time.Hour)},
+		{Week: 5, Season: 2025, StartTime: now.Add(13 * 24 * time.Hour)},
+	}
+	for i := range games {
+		games[i].ID = uint(i + 1)
+	}
+	picks := []database.Pick{
+		// Alice picks one game of week 3 and puts her top rank of week 4 on its first game
+		{UserID: 1, GameID: 1, Picked: SideFavorite, Rank: 1},
+		{UserID: 1, GameID: 3, Picked: SideFavorite, Rank: 3},
+	}
+
+	// Bob has not picked anything and can still pick weeks 4 and 5
+	scores := Score(Classic(), games, nil, picks, []uint{1, 2}, now)
+	if len(scores) != 2 {
+		t.Fatalf("Expected 2 players, got %d", len(scores))
+	}
+	// Alice keeps ranks 2 and 1 of week 4 and every rank of week 5
+	if alice := scores[1]; alice.MaxPoints != 1+3+2+1+2+1 || alice.Pending != 2 {
+		t.Errorf("Unexpected score for Alice: %+v", alice)
+	}
+	if bob := scores[2]; bob.MaxPoints != 3+2+1+2+1 || bob.Pending != 0 {
+		t.Errorf("Unexpected score for Bob: %+v", bob)
+	}
+
+	cfg := &config.Config{}
+	cfg.Pool.Scoring.Rules = config.ScoringRulesFixed
+	cfg.Pool.Scoring.PointsPerPick = 1
+	cfg.Pool.Scoring.PerfectWeekBonus = 5
+	rules, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// The perfect week bonus stays possible in the open weeks only
+	scores = Score(rules, games, nil, picks, []uint{1, 2}, now)
+	if alice := scores[1]; alice.MaxPoints != 1+3+5+2+5 {
+		t.Errorf("Unexpected score for Alice with a perfect week bonus: %+v", alice)
+	}
+	if bob := scores[2]; bob.MaxPoints != 3+5+2+5 {
+		t.Errorf("Unexpected score for Bob with a perfect week bonus: %+v", bob)
+	}
+}
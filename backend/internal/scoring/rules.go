@@ -0,0 +1,219 @@
+package scoring
+
+import (
+	"fmt"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// Sides of a game a pick is made on, which are also the outcomes stored on results.
+const (
+	SideFavorite = "favorite"
+	SideUnderdog = "underdog"
+	// OutcomePush is the outcome of a game whose favorite just makes the spread
+	OutcomePush = "push"
+)
+
+// Outcome returns the outcome against the spread of a game with the given score.
+func Outcome(spread float32, favoriteScore, underdogScore int) string {
+	switch margin := float32(favoriteScore - underdogScore); {
+	case margin > spread:
+		return SideFavorite
+	case margin < spread:
+		return SideUnderdog
+	default:
+		return OutcomePush
+	}
+}
+
+// GameResult returns the result of a game if it ended at its current score. The scores are
+// oriented by the game's favorite, the home team when it has none, and the outcome is graded
+// against its spread.
+func GameResult(game database.Game) database.Result {
+	favoriteScore, underdogScore := game.HomeScore, game.AwayScore
+	if game.Favorite != nil && *game.Favorite == "Away" {
+		favoriteScore, underdogScore = underdogScore, favoriteScore
+	}
+	return database.Result{
+		GameID:        game.ID,
+		FavoriteScore: favoriteScore,
+		UnderdogScore: underdogScore,
+		Outcome:       Outcome(game.Spread, favoriteScore, underdogScore),
+	}
+}
+
+// Grade is how a pick turned out.
+type Grade int
+
+// Pick grades.
+const (
+	// Pending is the grade of a pick whose game has no result yet
+	Pending Grade = iota
+	Correct
+	Incorrect
+	Push
+)
+
+// String returns the name of the grade.
+func (g Grade) String() string {
+	switch g {
+	case Correct:
+		return "correct"
+	case Incorrect:
+		return "incorrect"
+	case Push:
+		return "push"
+	default:
+		return "pending"
+	}
+}
+
+// GradedPick is a pick with its grade and the points it scored.
+type GradedPick struct {
+	database.Pick
+	Grade  Grade
+	Points float32
+}
+
+// Week is a player's picks on the games of a week.
+type Week struct {
+	// Games is the number of games of the week
+	Games int
+	Picks []GradedPick
+}
+
+// Rules decide how picks are graded and how many points they score.
+type Rules interface {
+	// Name returns the configured name of the rule set.
+	Name() string
+	// Grade returns how a pick on a game with a result turned out.
+	Grade(pick database.Pick, game database.Game, result database.Result) Grade
+	// Points returns the points a graded pick scores.
+	Points(pick database.Pick, grade Grade) float32
+	// WeekBonus returns the bonus points a player scores for a week on top of their picks.
+	WeekBonus(week Week) float32
+}
+
+// ruleSet is a rule set assembled from the pool's scoring configuration.
+type ruleSet struct {
+	name             string
+	straightUp       bool
+	pointsPerPick    float32
+	push             string
+	perfectWeekBonus float32
+}
+
+// New creates the rule set selected by the pool's scoring configuration. An empty rule set
+// is classic confidence scoring and an empty push scoring is half points.
+func New(cfg *config.Config) (Rules, error) {
+	scoring := cfg.Pool.Scoring
+	rules := &ruleSet{
+		name:             scoring.Rules,
+		pointsPerPick:    scoring.PointsPerPick,
+		push:             scoring.Push,
+		perfectWeekBonus: scoring.PerfectWeekBonus,
+	}
+
+	switch rules.name {
+	case "":
+		rules.name = config.ScoringRulesConfidence
+	case config.ScoringRulesConfidence:
+	case config.ScoringRulesStraightUp:
+		rules.straightUp = true
+	case config.ScoringRulesFixed:
+	default:
+		return nil, fmt.Errorf("invalid scoring rules %q", rules.name)
+	}
+
+	switch rules.push {
+	case "":
+		rules.push = config.PushScoringHalf
+	case config.PushScoringHalf, config.PushScoringLoss, config.PushScoringWin:
+	default:
+		return nil, fmt.Errorf("invalid push scoring %q", rules.push)
+	}
+
+	if rules.name != config.ScoringRulesConfidence && rules.pointsPerPick <= 0 {
+		return nil, fmt.Errorf("scoring rules %q need positive points per pick", rules.name)
+	}
+	if rules.perfectWeekBonus < 0 {
+		return nil, fmt.Errorf("invalid perfect week bonus %v", rules.perfectWeekBonus)
+	}
+	return rules, nil
+}
+
+// Classic returns the classic confidence rules: a correct pick against the spread scores
+// its rank and a push scores half of it.
+func Classic() Rules {
+	return &ruleSet{name: config.ScoringRulesConfidence, push: config.PushScoringHalf}
+}
+
+// Name returns the configured name of the rule set.
+func (r *ruleSet) Name() string {
+	return r.name
+}
+
+// Grade grades a pick against the spread by the result's outcome, or on the winner
+// under the straight up rules.
+func (r *ruleSet) Grade(pick database.Pick, _ database.Game, result database.Result) Grade {
+	outcome := result.Outcome
+	if r.straightUp {
+		switch {
+		case result.FavoriteScore > result.UnderdogScore:
+			outcome = SideFavorite
+		case result.FavoriteScore < result.UnderdogScore:
+			outcome = SideUnderdog
+		default:
+			outcome = OutcomePush
+		}
+	}
+
+	switch {
+	case outcome == OutcomePush:
+		return Push
+	case outcome == pick.Picked:
+		return Correct
+	default:
+		return Incorrect
+	}
+}
+
+// Points scores a correct pick its rank under the confidence rules, or the points per pick
+// otherwise. Pushes score according to the push scoring.
+func (r *ruleSet) Points(pick database.Pick, grade Grade) float32 {
+	points := r.pointsPerPick
+	if r.name == config.ScoringRulesConfidence {
+		points = float32(pick.Rank)
+	}
+
+	switch grade {
+	case Correct:
+		return points
+	case Push:
+		switch r.push {
+		case config.PushScoringWin:
+			return points
+		case config.PushScoringLoss:
+			return 0
+		default:
+			return points / 2
+		}
+	default:
+		return 0
+	}
+}
+
+// WeekBonus awards the perfect week bonus to a player who picked every game of the week
+// and scored full points on each of them.
+func (r *ruleSet) WeekBonus(week Week) float32 {
+	if r.perfectWeekBonus == 0 || week.Games == 0 || len(week.Picks) < week.Games {
+		return 0
+	}
+	for _, pick := range week.Picks {
+		if pick.Grade != Correct && (pick.Grade != Push || r.push != config.PushScoringWin) {
+			return 0
+		}
+	}
+	return r.perfectWeekBonus
+}
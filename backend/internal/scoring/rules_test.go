@@ -0,0 +1,199 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+func newRules(t *testing.T, rules, push string, pointsPerPick, perfectWeekBonus float32) Rules {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Pool.Scoring.Rules = rules
+	cfg.Pool.Scoring.Push = push
+	cfg.Pool.Scoring.PointsPerPick = pointsPerPick
+	cfg.Pool.Scoring.PerfectWeekBonus = perfectWeekBonus
+	r, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return r
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         string
+		push          string
+		pointsPerPick float32
+		bonus         float32
+		expected      string
+		wantErr       bool
+	}{
+		{name: "defaults", expected: config.ScoringRulesConfidence},
+		{name: "straight up", rules: config.ScoringRulesStraightUp, pointsPerPick: 1, expected: config.ScoringRulesStraightUp},
+		{name: "fixed", rules: config.ScoringRulesFixed, push: config.PushScoringLoss, pointsPerPick: 2, expected: config.ScoringRulesFixed},
+		{name: "fixed without points", rules: config.ScoringRulesFixed, wantErr: true},
+		{name: "unknown rules", rules: "survivor", wantErr: true},
+		{name: "unknown push", push: "replay", wantErr: true},
+		{name: "negative bonus", bonus: -5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Pool.Scoring.Rules = tt.rules
+			cfg.Pool.Scoring.Push = tt.push
+			cfg.Pool.Scoring.PointsPerPick = tt.pointsPerPick
+			cfg.Pool.Scoring.PerfectWeekBonus = tt.bonus
+
+			rules, err := New(cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("New() expected an error, got %s", rules.Name())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if rules.Name() != tt.expected {
+				t.Errorf("Name() = %s, want %s", rules.Name(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		name          string
+		spread        float32
+		favoriteScore int
+		underdogScore int
+		expected      string
+	}{
+		{name: "favorite covers", spread: 6.5, favoriteScore: 24, underdogScore: 17, expected: SideFavorite},
+		{name: "favorite wins without covering", spread: 6.5, favoriteScore: 20, underdogScore: 17, expected: SideUnderdog},
+		{name: "favorite just makes the spread", spread: 7, favoriteScore: 24, underdogScore: 17, expected: OutcomePush},
+		{name: "pick'em tie", spread: 0, favoriteScore: 10, underdogScore: 10, expected: OutcomePush},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if outcome := Outcome(tt.spread, tt.favoriteScore, tt.underdogScore); outcome != tt.expected {
+				t.Errorf("Outcome() = %s, want %s", outcome, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRules_Grade(t *testing.T) {
+	// The favorite wins by 3 but does not cover 6.5
+	result := database.Result{FavoriteScore: 20, UnderdogScore: 17, Outcome: SideUnderdog}
+	tie := database.Result{FavoriteScore: 17, UnderdogScore: 17, Outcome: SideUnderdog}
+	push := database.Result{FavoriteScore: 24, UnderdogScore: 17, Outcome: OutcomePush}
+	favorite := database.Pick{Picked: SideFavorite, Rank: 4}
+	underdog := database.Pick{Picked: SideUnderdog, Rank: 4}
+
+	classic := Classic()
+	straightUp := newRules(t, config.ScoringRulesStraightUp, "", 1, 0)
+
+	tests := []struct {
+		name     string
+		rules    Rules
+		pick     database.Pick
+		result   database.Result
+		expected Grade
+	}{
+		{name: "underdog covers", rules: classic, pick: underdog, result: result, expected: Correct},
+		{name: "favorite does not cover", rules: classic, pick: favorite, result: result, expected: Incorrect},
+		{name: "favorite just makes the spread", rules: classic, pick: favorite, result: push, expected: Push},
+		{name: "favorite wins straight up", rules: straightUp, pick: favorite, result: result, expected: Correct},
+		{name: "underdog loses straight up", rules: straightUp, pick: underdog, result: result, expected: Incorrect},
+		{name: "spread push is a win straight up", rules: straightUp, pick: favorite, result: push, expected: Correct},
+		{name: "tie straight up", rules: straightUp, pick: underdog, result: tie, expected: Push},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if grade := tt.rules.Grade(tt.pick, database.Game{}, tt.result); grade != tt.expected {
+				t.Errorf("Grade() = %s, want %s", grade, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRules_Points(t *testing.T) {
+	pick := database.Pick{Picked: SideFavorite, Rank: 6}
+
+	tests := []struct {
+		name     string
+		rules    Rules
+		grade    Grade
+		expected float32
+	}{
+		{name: "confidence correct", rules: Classic(), grade: Correct, expected: 6},
+		{name: "confidence push", rules: Classic(), grade: Push, expected: 3},
+		{name: "confidence incorrect", rules: Classic(), grade: Incorrect, expected: 0},
+		{name: "pending", rules: Classic(), grade: Pending, expected: 0},
+		{name: "push as loss", rules: newRules(t, config.ScoringRulesConfidence, config.PushScoringLoss, 0, 0), grade: Push, expected: 0},
+		{name: "push as win", rules: newRules(t, config.ScoringRulesConfidence, config.PushScoringWin, 0, 0), grade: Push, expected: 6},
+		{name: "fixed correct", rules: newRules(t, config.ScoringRulesFixed, "", 2, 0), grade: Correct, expected: 2},
+		{name: "fixed push", rules: newRules(t, config.ScoringRulesFixed, "", 2, 0), grade: Push, expected: 1},
+		{name: "straight up correct", rules: newRules(t, config.ScoringRulesStraightUp, "", 1, 0), grade: Correct, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if points := tt.rules.Points(pick, tt.grade); points != tt.expected {
+				t.Errorf("Points() = %v, want %v", points, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRules_WeekBonus(t *testing.T) {
+	bonus := newRules(t, config.ScoringRulesConfidence, "", 0, 10)
+	pushWin := newRules(t, config.ScoringRulesConfidence, config.PushScoringWin, 0, 10)
+	perfect := []GradedPick{{Grade: Correct}, {Grade: Correct}}
+	withPush := []GradedPick{{Grade: Correct}, {Grade: Push}}
+
+	tests := []struct {
+		name     string
+		rules    Rules
+		week     Week
+		expected float32
+	}{
+		{name: "perfect week", rules: bonus, week: Week{Games: 2, Picks: perfect}, expected: 10},
+		{name: "bonus disabled", rules: Classic(), week: Week{Games: 2, Picks: perfect}},
+		{name: "missed a game", rules: bonus, week: Week{Games: 3, Picks: perfect}},
+		{name: "pending game", rules: bonus, week: Week{Games: 2, Picks: []GradedPick{{Grade: Correct}, {Grade: Pending}}}},
+		{name: "push", rules: bonus, week: Week{Games: 2, Picks: withPush}},
+		{name: "push as win", rules: pushWin, week: Week{Games: 2, Picks: withPush}, expected: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if points := tt.rules.WeekBonus(tt.week); points != tt.expected {
+				t.Errorf("WeekBonus() = %v, want %v", points, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGameResult(t *testing.T) {
+	away := "Away"
+	game := database.Game{HomeScore: 17, AwayScore: 20, Spread: 2.5, Favorite: &away}
+
+	result := GameResult(game)
+	if result.FavoriteScore != 20 || result.UnderdogScore != 17 || result.Outcome != SideFavorite {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	// A favorite that wins without covering loses against the spread
+	game.Spread = 7
+	if result := GameResult(game); result.Outcome != SideUnderdog {
+		t.Errorf("Expected the underdog to cover, got %+v", result)
+	}
+}
@@ -0,0 +1,176 @@
+package seasons
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+)
+
+// LiveGame is a game of a week with its outcome against the spread, which is provisional
+// while the game is in progress and empty before kickoff.
+type LiveGame struct {
+	database.Game
+	Outcome string
+	// Final is set once the game has a result
+	Final bool
+}
+
+// LivePlayer is a player's provisional score of a week if the games in progress ended at
+// their current score.
+type LivePlayer struct {
+	UserID     uint
+	PlayerName string
+	Rank       int
+	// Points are the provisional points, which are the secured points and the points at risk
+	Points float32
+	// SecuredPoints are scored on final results
+	SecuredPoints float32
+	// AtRiskPoints are scored on the current score of the games in progress
+	AtRiskPoints float32
+	// MaxPoints is the most the player can score if every pick without a result is correct,
+	// counting the games they can still pick
+	MaxPoints float32
+	Correct   int
+	Incorrect int
+	Pushes    int
+	Pending   int
+}
+
+// LiveWeek is the provisional leaderboard of a week during game day.
+type LiveWeek struct {
+	Season  int
+	Week    int
+	Games   []LiveGame
+	Players []LivePlayer
+}
+
+// LiveStandings scores a week as if its games in progress ended at their current score, and
+// ranks every pool member by the provisional points.
+func (s *Service) LiveStandings(season, week int) (*LiveWeek, error) {
+	db := s.db.GetDB()
+
+	var games []database.Game
+	if err := db.Where("season = ? AND week = ?", season, week).Order("start_time, id").Find(&games).Error; err != nil {
+		return nil, err
+	}
+	gameIDs := make([]uint, len(games))
+	for i, game := range games {
+		gameIDs[i] = game.ID
+	}
+
+	var results []database.Result
+	if err := db.Where("game_id IN ?", gameIDs).Find(&results).Error; err != nil {
+		return nil, err
+	}
+	var picks []database.Pick
+	if err := db.Where("game_id IN ?", gameIDs).Find(&picks).Error; err != nil {
+		return nil, err
+	}
+
+	final := make(map[uint]database.Result, len(results))
+	for _, result := range results {
+		final[result.GameID] = result
+	}
+	live := &LiveWeek{Season: season, Week: week, Games: make([]LiveGame, len(games))}
+	provisionalResults := results
+	for i, game := range games {
+		live.Games[i] = LiveGame{Game: game}
+		if result, ok := final[game.ID]; ok {
+			live.Games[i].Outcome = result.Outcome
+			live.Games[i].Final = true
+			continue
+		}
+		if game.State == database.GameStateInProgress {
+			result := scoring.GameResult(game)
+			live.Games[i].Outcome = result.Outcome
+			provisionalResults = append(provisionalResults, result)
+		}
+	}
+
+	var members []database.User
+	if err := db.Order("id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	memberIDs := make([]uint, len(members))
+	for i, member := range members {
+		memberIDs[i] = member.ID
+	}
+
+	now := s.timeProvider.Now()
+	secured := scoring.Score(s.rules, games, results, picks, memberIDs, now)
+	provisional := scoring.Score(s.rules, games, provisionalResults, picks, memberIDs, now)
+	live.Players = make([]LivePlayer, len(members))
+	for i, member := range members {
+		player := LivePlayer{UserID: member.ID, PlayerName: member.Name}
+		if score, ok := provisional[member.ID]; ok {
+			player.Points = score.Points
+			player.Correct = score.Correct
+			player.Incorrect = score.Incorrect
+			player.Pushes = score.Pushes
+			player.Pending = score.Pending
+		}
+		if score, ok := secured[member.ID]; ok {
+			player.SecuredPoints = score.Points
+			player.MaxPoints = score.MaxPoints
+		}
+		player.AtRiskPoints = player.Points - player.SecuredPoints
+		live.Players[i] = player
+	}
+
+	rankBy(live.Players, func(player *LivePlayer) (float32, string) {
+		return player.Points, player.PlayerName
+	}, func(player *LivePlayer, rank int) {
+		player.Rank = rank
+	})
+	return live, nil
+}
+
+// liveSubscribers notifies the subscribers to the live standings of a week.
+type liveSubscribers struct {
+	mu          sync.Mutex
+	subscribers map[*liveSubscriber]struct{}
+}
+
+// liveSubscriber is notified when the live standings of a week change.
+type liveSubscriber struct {
+	season, week int
+	changes      chan struct{}
+}
+
+// SubscribeLive returns a channel that receives a value when the live standings of a week
+// change, and a function that ends the subscription. Changes that arrive while the previous
+// one is unread are coalesced.
+func (s *Service) SubscribeLive(season, week int) (<-chan struct{}, func()) {
+	subscriber := &liveSubscriber{season: season, week: week, changes: make(chan struct{}, 1)}
+
+	s.live.mu.Lock()
+	defer s.live.mu.Unlock()
+	if s.live.subscribers == nil {
+		s.live.subscribers = make(map[*liveSubscriber]struct{})
+	}
+	s.live.subscribers[subscriber] = struct{}{}
+
+	return subscriber.changes, func() {
+		s.live.mu.Lock()
+		defer s.live.mu.Unlock()
+		delete(s.live.subscribers, subscriber)
+	}
+}
+
+// LiveScoresChanged notifies the subscribers to the live standings of a week. It is called
+// when the live scores or game states of the week change.
+func (s *Service) LiveScoresChanged(_ context.Context, season, week int) {
+	s.live.mu.Lock()
+	defer s.live.mu.Unlock()
+	for subscriber := range s.live.subscribers {
+		if subscriber.season != season || subscriber.week != week {
+			continue
+		}
+		select {
+		case subscriber.changes <- struct{}{}:
+		default:
+		}
+	}
+}
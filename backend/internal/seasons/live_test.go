@@ -0,0 +1,73 @@
+package seasons
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+)
+
+func TestService_LiveStandings(t *testing.T) {
+	db, service := setupSeasonTest(t)
+	gormDB := db.GetDB()
+
+	// The Eagles lead the Cowboys by 11 and cover the 7 point spread for now
+	gormDB.Model(&database.Game{}).Where("id = ?", 1).Updates(database.Game{State: database.GameStateInProgress, HomeScore: 21, AwayScore: 10, Period: 3})
+	// Bob already won the early game
+	game := database.Game{Week: 1, Season: 2025, SeasonType: database.SeasonTypeRegular, HomeTeam: "Kansas City Chiefs", AwayTeam: "Denver Broncos", Spread: 3, State: database.GameStateFinal}
+	gormDB.Create(&game)
+	gormDB.Create(&[]database.Pick{
+		{UserID: 1, GameID: game.ID, Picked: scoring.SideUnderdog, Rank: 3},
+		{UserID: 2, GameID: game.ID, Picked: scoring.SideFavorite, Rank: 5},
+	})
+	gormDB.Create(&database.Result{GameID: game.ID, FavoriteScore: 27, UnderdogScore: 20, Outcome: scoring.SideFavorite})
+
+	live, err := service.LiveStandings(2025, 1)
+	if err != nil {
+		t.Fatalf("LiveStandings() error = %v", err)
+	}
+	if len(live.Games) != 2 || live.Games[0].Outcome != scoring.SideFavorite || live.Games[0].Final || !live.Games[1].Final {
+		t.Errorf("Unexpected live games: %+v", live.Games)
+	}
+	if len(live.Players) != 2 {
+		t.Fatalf("Expected 2 players, got %+v", live.Players)
+	}
+	bob, alice := live.Players[0], live.Players[1]
+	if bob.PlayerName != "Bob" || bob.Rank != 1 || bob.Points != 5 || bob.SecuredPoints != 5 || bob.AtRiskPoints != 0 || bob.MaxPoints != 6 || bob.Incorrect != 1 {
+		t.Errorf("Unexpected live score of Bob: %+v", bob)
+	}
+	if alice.PlayerName != "Alice" || alice.Rank != 2 || alice.Points != 2 || alice.SecuredPoints != 0 || alice.AtRiskPoints != 2 || alice.MaxPoints != 2 || alice.Correct != 1 {
+		t.Errorf("Unexpected live score of Alice: %+v", alice)
+	}
+}
+
+func TestService_SubscribeLive(t *testing.T) {
+	_, service := setupSeasonTest(t)
+
+	changes, unsubscribe := service.SubscribeLive(2025, 1)
+	service.LiveScoresChanged(context.Background(), 2025, 2)
+	select {
+	case <-changes:
+		t.Error("Expected no notification for another week")
+	default:
+	}
+
+	// Changes are coalesced until they are read
+	service.LiveScoresChanged(context.Background(), 2025, 1)
+	service.LiveScoresChanged(context.Background(), 2025, 1)
+	<-changes
+	select {
+	case <-changes:
+		t.Error("Expected a single notification")
+	default:
+	}
+
+	unsubscribe()
+	service.LiveScoresChanged(context.Background(), 2025, 1)
+	select {
+	case <-changes:
+		t.Error("Expected no notification after unsubscribing")
+	default:
+	}
+}
@@ -0,0 +1,386 @@
+package seasons
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+	weeklifecycle "github.com/dhpollack/football-pool/internal/week-lifecycle"
+	"gorm.io/gorm"
+)
+
+// UpdateWeek rescores the picks of a week and updates the standings of the pools the week
+// counts toward, along with the positions players held after each later week.
+func (s *Service) UpdateWeek(season, week int) error {
+	err := s.db.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := s.scoreWeek(tx, season, week); err != nil {
+			return err
+		}
+		for _, pool := range s.weekPools(week) {
+			if err := s.updatePool(tx, season, pool, week); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update standings of season %d week %d: %w", season, week, err)
+	}
+	return nil
+}
+
+// ResultsChanged updates the standings after the results of a week change, and notifies the
+// subscribers to its live standings. Failures are logged, since the standings can be
+// recomputed later.
+func (s *Service) ResultsChanged(ctx context.Context, season, week int) {
+	if err := s.UpdateWeek(season, week); err != nil {
+		slog.Error("Failed to update standings", "season", season, "week", week, "error", err)
+	}
+	s.LiveScoresChanged(ctx, season, week)
+}
+
+// WeekChanged reacts to the week lifecycle. When a week locks, its scores are materialized
+// so that every pool member is listed with the sheet they locked in, and when it is final its
+// standings are brought up to date with the last results.
+func (s *Service) WeekChanged(ctx context.Context, event weeklifecycle.Event) {
+	switch event.Type {
+	case weeklifecycle.EventWeekLocked, weeklifecycle.EventWeekFinal:
+		s.ResultsChanged(ctx, event.Season, event.Week)
+	}
+}
+
+// Recompute rescores every week of a season and rebuilds its standings from scratch, to
+// correct them after picks or results were changed by hand.
+func (s *Service) Recompute(season int) error {
+	err := s.db.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("season = ?", season).Delete(&database.WeeklyScore{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("season = ?", season).Delete(&database.SeasonStanding{}).Error; err != nil {
+			return err
+		}
+
+		var weeks []int
+		if err := tx.Model(&database.Game{}).Where("season = ?", season).Distinct().Order("week").Pluck("week", &weeks).Error; err != nil {
+			return err
+		}
+		for _, week := range weeks {
+			if err := s.scoreWeek(tx, season, week); err != nil {
+				return err
+			}
+		}
+
+		for _, pool := range []string{database.PoolSeason, database.PoolPlayoffs} {
+			if PoolSeasonTypes(pool, s.config.Pool.PlayoffMode) == nil {
+				continue
+			}
+			if err := s.updatePool(tx, season, pool, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to recompute standings of season %d: %w", season, err)
+	}
+
+	slog.Info("Recomputed standings", "season", season)
+	return nil
+}
+
+// RecomputeIfMissing recomputes the standings of a season that has results but no
+// materialized scores, such as a season scored before the standings were materialized.
+func (s *Service) RecomputeIfMissing(season int) error {
+	var scores int64
+	if err := s.db.GetDB().Model(&database.WeeklyScore{}).Where("season = ?", season).Count(&scores).Error; err != nil {
+		return err
+	}
+	if scores > 0 {
+		return nil
+	}
+
+	var results int64
+	err := s.db.GetDB().Model(&database.Result{}).
+		Joins("JOIN games ON games.id = results.game_id AND games.deleted_at IS NULL").
+		Where("games.season = ?", season).
+		Count(&results).Error
+	if err != nil {
+		return err
+	}
+	if results == 0 {
+		return nil
+	}
+	return s.Recompute(season)
+}
+
+// WeeklyScores returns the scores of every pool member in a week, ranked by points and
+// then by name. Members without a materialized score have no points.
+func WeeklyScores(db *gorm.DB, season, week int) ([]database.WeeklyScore, error) {
+	var materialized []database.WeeklyScore
+	if err := db.Where("season = ? AND week = ?", season, week).Find(&materialized).Error; err != nil {
+		return nil, err
+	}
+	byUser := make(map[uint]database.WeeklyScore, len(materialized))
+	for _, score := range materialized {
+		byUser[score.UserID] = score
+	}
+
+	var members []database.User
+	if err := db.Order("id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	scores := make([]database.WeeklyScore, len(members))
+	for i, member := range members {
+		score, ok := byUser[member.ID]
+		if !ok {
+			score = database.WeeklyScore{Season: season, Week: week, UserID: member.ID, SeasonType: database.SeasonTypeForWeek(week)}
+		}
+		score.PlayerName = member.Name
+		scores[i] = score
+	}
+
+	rankBy(scores, func(score *database.WeeklyScore) (float32, string) {
+		return score.Points, score.PlayerName
+	}, func(score *database.WeeklyScore, rank int) {
+		score.Rank = rank
+	})
+	return scores, nil
+}
+
+// SeasonStandings returns the standings of every pool member in a pool, ranked by points and
+// then by name. Members without a materialized standing have no points.
+func SeasonStandings(db *gorm.DB, season int, pool string) ([]database.SeasonStanding, error) {
+	var materialized []database.SeasonStanding
+	if err := db.Where("season = ? AND pool = ?", season, pool).Find(&materialized).Error; err != nil {
+		return nil, err
+	}
+	byUser := make(map[uint]database.SeasonStanding, len(materialized))
+	for _, standing := range materialized {
+		byUser[standing.UserID] = standing
+	}
+
+	var members []database.User
+	if err := db.Order("id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	standings := make([]database.SeasonStanding, len(members))
+	for i, member := range members {
+		standing, ok := byUser[member.ID]
+		if !ok {
+			standing = database.SeasonStanding{Season: season, Pool: pool, UserID: member.ID}
+		}
+		standing.PlayerName = member.Name
+		standings[i] = standing
+	}
+
+	rankBy(standings, func(standing *database.SeasonStanding) (float32, string) {
+		return standing.Points, standing.PlayerName
+	}, func(standing *database.SeasonStanding, rank int) {
+		standing.Rank = rank
+	})
+	return standings, nil
+}
+
+// WeekGamesRemaining counts the games of a week without a result.
+func WeekGamesRemaining(db *gorm.DB, season, week int) (int, error) {
+	return gamesRemaining(db.Where("season = ? AND week = ?", season, week))
+}
+
+// PoolGamesRemaining counts the games of a season that count toward a pool and have no result.
+func PoolGamesRemaining(db *gorm.DB, season int, seasonTypes []int) (int, error) {
+	return gamesRemaining(db.Where("season = ? AND season_type IN ?", season, seasonTypes))
+}
+
+// gamesRemaining counts the games matching a query that have no result.
+func gamesRemaining(query *gorm.DB) (int, error) {
+	var remaining int64
+	err := query.Model(&database.Game{}).
+		Where("NOT EXISTS (SELECT 1 FROM results WHERE results.game_id = games.id AND results.deleted_at IS NULL)").
+		Count(&remaining).Error
+	return int(remaining), err
+}
+
+// scoreWeek replaces the materialized scores of a week with the scores of its picks.
+func (s *Service) scoreWeek(tx *gorm.DB, season, week int) error {
+	var games []database.Game
+	if err := tx.Where("season = ? AND week = ?", season, week).Find(&games).Error; err != nil {
+		return err
+	}
+
+	// Every pool member has a score, so that the ranks include players who did not pick
+	playerScores, err := scoring.ScoreGames(tx, s.rules, games, s.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	standings := make([]Standing, 0, len(playerScores))
+	for userID, score := range playerScores {
+		standings = append(standings, Standing{UserID: userID, Score: score.Points})
+	}
+	rank(standings)
+
+	if err := tx.Unscoped().Where("season = ? AND week = ?", season, week).Delete(&database.WeeklyScore{}).Error; err != nil {
+		return err
+	}
+	if len(standings) == 0 {
+		return nil
+	}
+
+	rows := make([]database.WeeklyScore, len(standings))
+	for i, standing := range standings {
+		score := playerScores[standing.UserID]
+		rows[i] = database.WeeklyScore{
+			Season:             season,
+			Week:               week,
+			UserID:             standing.UserID,
+			SeasonType:         database.SeasonTypeForWeek(week),
+			Points:             score.Points,
+			Bonus:              score.Bonus,
+			Correct:            score.Correct,
+			Incorrect:          score.Incorrect,
+			Pushes:             score.Pushes,
+			Pending:            score.Pending,
+			MaxPoints:          score.MaxPoints,
+			QuickPick:          score.QuickPick,
+			HighestCorrectRank: score.HighestCorrectRank,
+			Rank:               standing.Rank,
+		}
+	}
+	return tx.Create(&rows).Error
+}
+
+// updatePool rebuilds the standings of a pool from its weekly scores, and records the
+// positions players held after each week from fromWeek on.
+func (s *Service) updatePool(tx *gorm.DB, season int, pool string, fromWeek int) error {
+	var weekly []database.WeeklyScore
+	seasonTypes := PoolSeasonTypes(pool, s.config.Pool.PlayoffMode)
+	if err := tx.Where("season = ? AND season_type IN ?", season, seasonTypes).Order("week, user_id").Find(&weekly).Error; err != nil {
+		return err
+	}
+
+	totals := make(map[uint]*database.SeasonStanding)
+	var ranks, previous map[uint]int
+	for start := 0; start < len(weekly); {
+		week := weekly[start].Week
+		end := start
+		for ; end < len(weekly) && weekly[end].Week == week; end++ {
+			score := weekly[end]
+			total, ok := totals[score.UserID]
+			if !ok {
+				total = &database.SeasonStanding{Season: season, Pool: pool, UserID: score.UserID}
+				totals[score.UserID] = total
+			}
+			total.Points += score.Points
+			total.Correct += score.Correct
+			total.Incorrect += score.Incorrect
+			total.Pushes += score.Pushes
+			total.MaxPoints += score.MaxPoints
+		}
+
+		previous, ranks = ranks, rankTotals(totals)
+		if week >= fromWeek {
+			for _, score := range weekly[start:end] {
+				err := tx.Model(&database.WeeklyScore{}).Where("id = ?", score.ID).Updates(map[string]interface{}{
+					"season_points":        totals[score.UserID].Points,
+					"season_rank":          ranks[score.UserID],
+					"previous_season_rank": previous[score.UserID],
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+		start = end
+	}
+
+	// The weeks ahead still count toward the most each player can score
+	if err := s.addUnscoredWeeks(tx, season, pool, weekly, totals); err != nil {
+		return err
+	}
+	ranks = rankTotals(totals)
+
+	if err := tx.Unscoped().Where("season = ? AND pool = ?", season, pool).Delete(&database.SeasonStanding{}).Error; err != nil {
+		return err
+	}
+	if len(totals) == 0 {
+		return nil
+	}
+
+	rows := make([]database.SeasonStanding, 0, len(totals))
+	for userID, total := range totals {
+		total.Rank = ranks[userID]
+		total.PreviousRank = previous[userID]
+		rows = append(rows, *total)
+	}
+	slices.SortFunc(rows, func(a, b database.SeasonStanding) int {
+		return a.Rank - b.Rank
+	})
+	return tx.Create(&rows).Error
+}
+
+// addUnscoredWeeks adds to the season totals the most each pool member can score in the weeks
+// of a pool that have no scores yet, such as the weeks ahead that nobody has picked.
+func (s *Service) addUnscoredWeeks(tx *gorm.DB, season int, pool string, weekly []database.WeeklyScore, totals map[uint]*database.SeasonStanding) error {
+	scored := make(map[int]bool)
+	for _, score := range weekly {
+		scored[score.Week] = true
+	}
+
+	var games []database.Game
+	seasonTypes := PoolSeasonTypes(pool, s.config.Pool.PlayoffMode)
+	if err := tx.Where("season = ? AND season_type IN ?", season, seasonTypes).Find(&games).Error; err != nil {
+		return err
+	}
+	games = slices.DeleteFunc(games, func(game database.Game) bool {
+		return scored[game.Week]
+	})
+	if len(games) == 0 {
+		return nil
+	}
+
+	playerScores, err := scoring.ScoreGames(tx, s.rules, games, s.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+	for userID, score := range playerScores {
+		total, ok := totals[userID]
+		if !ok {
+			total = &database.SeasonStanding{Season: season, Pool: pool, UserID: userID}
+			totals[userID] = total
+		}
+		total.MaxPoints += score.MaxPoints
+	}
+	return nil
+}
+
+// weekPools returns the pools that a week counts toward under the playoff mode.
+func (s *Service) weekPools(week int) []string {
+	seasonType := database.SeasonTypeForWeek(week)
+	var pools []string
+	for _, pool := range []string{database.PoolSeason, database.PoolPlayoffs} {
+		if slices.Contains(PoolSeasonTypes(pool, s.config.Pool.PlayoffMode), seasonType) {
+			pools = append(pools, pool)
+		}
+	}
+	return pools
+}
+
+// rankTotals ranks players by their total points, keyed by player.
+func rankTotals(totals map[uint]*database.SeasonStanding) map[uint]int {
+	standings := make([]Standing, 0, len(totals))
+	for userID, total := range totals {
+		standings = append(standings, Standing{UserID: userID, Score: total.Points})
+	}
+	rank(standings)
+
+	ranks := make(map[uint]int, len(standings))
+	for _, standing := range standings {
+		ranks[standing.UserID] = standing.Rank
+	}
+	return ranks
+}
@@ -0,0 +1,272 @@
+package seasons
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+	weeklifecycle "github.com/dhpollack/football-pool/internal/week-lifecycle"
+)
+
+// weeklyScore returns a player's materialized score of a week.
+func weeklyScore(t *testing.T, db *database.Database, week int, userID uint) database.WeeklyScore {
+	t.Helper()
+	var score database.WeeklyScore
+	if err := db.GetDB().Where("season = ? AND week = ? AND user_id = ?", 2025, week, userID).First(&score).Error; err != nil {
+		t.Fatalf("Failed to get week %d score of user %d: %v", week, userID, err)
+	}
+	return score
+}
+
+func TestService_UpdateWeek(t *testing.T) {
+	db, service := setupSeasonTest(t)
+	gormDB := db.GetDB()
+
+	// A second regular season week, which Bob wins
+	game := database.Game{Week: 2, Season: 2025, SeasonType: database.SeasonTypeRegular, HomeTeam: "Kansas City Chiefs", AwayTeam: "Denver Broncos", Spread: 3}
+	gormDB.Create(&game)
+	gormDB.Create(&[]database.Pick{
+		{UserID: 1, GameID: game.ID, Picked: scoring.SideUnderdog, Rank: 1},
+		{UserID: 2, GameID: game.ID, Picked: scoring.SideFavorite, Rank: 5},
+	})
+
+	gormDB.Create(&database.Result{GameID: 1, FavoriteScore: 24, UnderdogScore: 14, Outcome: scoring.SideFavorite})
+	if err := service.UpdateWeek(2025, 1); err != nil {
+		t.Fatalf("UpdateWeek() error = %v", err)
+	}
+	if score := weeklyScore(t, db, 1, 1); score.Points != 2 || score.Rank != 1 || score.SeasonPoints != 2 || score.SeasonRank != 1 {
+		t.Errorf("Unexpected week 1 score of Alice: %+v", score)
+	}
+
+	gormDB.Create(&database.Result{GameID: game.ID, FavoriteScore: 31, UnderdogScore: 17, Outcome: scoring.SideFavorite})
+	if err := service.UpdateWeek(2025, 2); err != nil {
+		t.Fatalf("UpdateWeek() error = %v", err)
+	}
+	if score := weeklyScore(t, db, 2, 2); score.Points != 5 || score.SeasonPoints != 5 || score.SeasonRank != 1 || score.PreviousSeasonRank != 2 {
+		t.Errorf("Unexpected week 2 score of Bob: %+v", score)
+	}
+
+	// Alice keeps the lead she held after week 1
+	if score := weeklyScore(t, db, 1, 1); score.SeasonRank != 1 {
+		t.Errorf("Expected Alice to lead after week 1, got %+v", score)
+	}
+
+	standings, err := SeasonStandings(gormDB, 2025, database.PoolSeason)
+	if err != nil {
+		t.Fatalf("SeasonStandings() error = %v", err)
+	}
+	if len(standings) != 2 || standings[0].PlayerName != "Bob" || standings[0].Points != 5 || standings[0].Correct != 1 || standings[0].PreviousRank != 2 || standings[1].PlayerName != "Alice" || standings[1].Rank != 2 || standings[1].PreviousRank != 1 {
+		t.Fatalf("Unexpected season standings: %+v", standings)
+	}
+
+	// Correcting week 1 updates the positions held after the later weeks
+	gormDB.Model(&database.Result{}).Where("game_id = ?", 1).Update("outcome", scoring.SideUnderdog)
+	if err := service.UpdateWeek(2025, 1); err != nil {
+		t.Fatalf("UpdateWeek() error = %v", err)
+	}
+	if score := weeklyScore(t, db, 1, 1); score.Points != 0 || score.Rank != 2 || score.SeasonRank != 2 {
+		t.Errorf("Unexpected corrected week 1 score of Alice: %+v", score)
+	}
+	if score := weeklyScore(t, db, 2, 2); score.SeasonPoints != 6 || score.SeasonRank != 1 {
+		t.Errorf("Unexpected week 2 score of Bob after the correction: %+v", score)
+	}
+
+	// Postseason weeks only count toward the separate playoff pool
+	gormDB.Create(&database.Result{GameID: 2, FavoriteScore: 20, UnderdogScore: 27, Outcome: scoring.SideUnderdog})
+	if err := service.UpdateWeek(2025, 19); err != nil {
+		t.Fatalf("UpdateWeek() error = %v", err)
+	}
+	playoffs, err := SeasonStandings(gormDB, 2025, database.PoolPlayoffs)
+	if err != nil {
+		t.Fatalf("SeasonStandings() error = %v", err)
+	}
+	if len(playoffs) != 2 || playoffs[0].PlayerName != "Bob" || playoffs[0].Points != 3 || playoffs[1].PlayerName != "Alice" || playoffs[1].Rank != 2 {
+		t.Errorf("Unexpected playoff standings: %+v", playoffs)
+	}
+	standings, err = SeasonStandings(gormDB, 2025, database.PoolSeason)
+	if err != nil {
+		t.Fatalf("SeasonStandings() error = %v", err)
+	}
+	if len(standings) != 2 || standings[0].Points != 6 {
+		t.Errorf("Expected the season standings to leave out the playoffs, got %+v", standings)
+	}
+}
+
+func TestService_UpdateWeekMaxPointsCountsWeeksAhead(t *testing.T) {
+	db, service := setupSeasonTest(t)
+	gormDB := db.GetDB()
+	appClock := clock.NewAdjustable()
+	appClock.Set(time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC))
+	service.timeProvider = appClock
+
+	// Alice has picked one game of week 2 and nobody has picked week 3 yet
+	weekTwo := time.Date(2025, 9, 14, 17, 0, 0, 0, time.UTC)
+	games := []database.Game{
+		{Week: 2, Season: 2025, SeasonType: database.SeasonTypeRegular, HomeTeam: "Kansas City Chiefs", AwayTeam: "Denver Broncos", Spread: 3, StartTime: weekTwo},
+		{Week: 2, Season: 2025, SeasonType: database.SeasonTypeRegular, HomeTeam: "Detroit Lions", AwayTeam: "Chicago Bears", Spread: 4, StartTime: weekTwo},
+		{Week: 3, Season: 2025, SeasonType: database.SeasonTypeRegular, HomeTeam: "Green Bay Packers", AwayTeam: "Minnesota Vikings", Spread: 2, StartTime: weekTwo.Add(7 * 24 * time.Hour)},
+	}
+	if err := gormDB.Create(&games).Error; err != nil {
+		t.Fatalf("Failed to create games: %v", err)
+	}
+	if err := gormDB.Create(&database.Pick{UserID: 1, GameID: games[0].ID, Picked: scoring.SideFavorite, Rank: 1}).Error; err != nil {
+		t.Fatalf("Failed to create pick: %v", err)
+	}
+
+	gormDB.Create(&database.Result{GameID: 1, FavoriteScore: 24, UnderdogScore: 14, Outcome: scoring.SideFavorite})
+	if err := service.UpdateWeek(2025, 1); err != nil {
+		t.Fatalf("UpdateWeek() error = %v", err)
+	}
+
+	standings, err := SeasonStandings(gormDB, 2025, database.PoolSeason)
+	if err != nil {
+		t.Fatalf("SeasonStandings() error = %v", err)
+	}
+	if len(standings) != 2 {
+		t.Fatalf("Expected 2 standings, got %+v", standings)
+	}
+	// Alice has 2 points, can score 1 on her pick and keeps rank 2 of week 2 and rank 1 of week 3
+	if alice := standings[0]; alice.PlayerName != "Alice" || alice.Points != 2 || alice.MaxPoints != 2+1+2+1 {
+		t.Errorf("Unexpected standing of Alice: %+v", alice)
+	}
+	// Bob missed week 1 and can still pick every game ahead
+	if bob := standings[1]; bob.PlayerName != "Bob" || bob.Points != 0 || bob.MaxPoints != 2+1+1 {
+		t.Errorf("Unexpected standing of Bob: %+v", bob)
+	}
+
+	// Once week 2 kicks off, the game Alice left unpicked no longer counts
+	appClock.Set(weekTwo.Add(time.Hour))
+	if err := service.UpdateWeek(2025, 2); err != nil {
+		t.Fatalf("UpdateWeek() error = %v", err)
+	}
+	if score := weeklyScore(t, db, 2, 1); score.MaxPoints != 1 {
+		t.Errorf("Unexpected week 2 score of Alice: %+v", score)
+	}
+	standings, err = SeasonStandings(gormDB, 2025, database.PoolSeason)
+	if err != nil {
+		t.Fatalf("SeasonStandings() error = %v", err)
+	}
+	if alice := standings[0]; alice.MaxPoints != 2+1+1 {
+		t.Errorf("Unexpected standing of Alice after week 2 locked: %+v", alice)
+	}
+	if bob := standings[1]; bob.MaxPoints != 1 {
+		t.Errorf("Unexpected standing of Bob after week 2 locked: %+v", bob)
+	}
+}
+
+func TestService_WeekChanged(t *testing.T) {
+	db, service := setupSeasonTest(t)
+	gormDB := db.GetDB()
+
+	changes, unsubscribe := service.SubscribeLive(2025, 1)
+	defer unsubscribe()
+
+	// Opening a week has nothing to score
+	service.WeekChanged(context.Background(), weeklifecycle.Event{Type: weeklifecycle.EventWeekOpened, Season: 2025, Week: 1})
+	var scores int64
+	gormDB.Model(&database.WeeklyScore{}).Count(&scores)
+	if scores != 0 {
+		t.Errorf("Expected no weekly scores before the week locks, got %d", scores)
+	}
+
+	// Locking a week lists every player with the sheet they locked in
+	service.WeekChanged(context.Background(), weeklifecycle.Event{Type: weeklifecycle.EventWeekLocked, Season: 2025, Week: 1})
+	if score := weeklyScore(t, db, 1, 1); score.Points != 0 || score.Pending != 1 {
+		t.Errorf("Unexpected locked week score of Alice: %+v", score)
+	}
+	select {
+	case <-changes:
+	default:
+		t.Error("Expected the live standings subscribers to be notified when the week locks")
+	}
+
+	// The final week is scored with its results
+	gormDB.Create(&database.Result{GameID: 1, FavoriteScore: 24, UnderdogScore: 14, Outcome: scoring.SideFavorite})
+	service.WeekChanged(context.Background(), weeklifecycle.Event{Type: weeklifecycle.EventWeekFinal, Season: 2025, Week: 1})
+	if score := weeklyScore(t, db, 1, 1); score.Points != 2 || score.Pending != 0 {
+		t.Errorf("Unexpected final week score of Alice: %+v", score)
+	}
+}
+
+func TestService_Recompute(t *testing.T) {
+	db, service := setupSeasonTest(t)
+	gormDB := db.GetDB()
+
+	// Seasons without results have nothing to materialize
+	if err := service.RecomputeIfMissing(2025); err != nil {
+		t.Fatalf("RecomputeIfMissing() error = %v", err)
+	}
+	var count int64
+	gormDB.Model(&database.WeeklyScore{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no weekly scores, got %d", count)
+	}
+
+	// Results stored without updating the standings are picked up
+	finishGames(t, db)
+	if err := service.RecomputeIfMissing(2025); err != nil {
+		t.Fatalf("RecomputeIfMissing() error = %v", err)
+	}
+	standings, err := SeasonStandings(gormDB, 2025, database.PoolSeason)
+	if err != nil {
+		t.Fatalf("SeasonStandings() error = %v", err)
+	}
+	if len(standings) != 2 || standings[0].PlayerName != "Alice" || standings[0].Points != 2 || standings[1].PlayerName != "Bob" || standings[1].Points != 0 {
+		t.Fatalf("Unexpected season standings: %+v", standings)
+	}
+
+	// A pick changed by hand is corrected by a recompute
+	gormDB.Model(&database.Pick{}).Where("user_id = ? AND game_id = ?", 2, 1).Update("picked", scoring.SideFavorite)
+	if err := service.RecomputeIfMissing(2025); err != nil {
+		t.Fatalf("RecomputeIfMissing() error = %v", err)
+	}
+	if score := weeklyScore(t, db, 1, 2); score.Points != 0 {
+		t.Errorf("Expected materialized scores to be left alone, got %+v", score)
+	}
+	if err := service.Recompute(2025); err != nil {
+		t.Fatalf("Recompute() error = %v", err)
+	}
+	if score := weeklyScore(t, db, 1, 2); score.Points != 1 || score.Rank != 2 || score.SeasonPoints != 1 {
+		t.Errorf("Unexpected recomputed week 1 score of Bob: %+v", score)
+	}
+
+	gormDB.Model(&database.WeeklyScore{}).Count(&count)
+	if count != 4 {
+		t.Errorf("Expected a weekly score for each player and week, got %d", count)
+	}
+}
+
+func TestWeeklyScores(t *testing.T) {
+	db, service := setupSeasonTest(t)
+	gormDB := db.GetDB()
+
+	// Carol joins after the week was scored
+	gormDB.Create(&database.Result{GameID: 1, FavoriteScore: 24, UnderdogScore: 14, Outcome: scoring.SideFavorite})
+	if err := service.UpdateWeek(2025, 1); err != nil {
+		t.Fatalf("UpdateWeek() error = %v", err)
+	}
+	gormDB.Create(&database.User{Name: "Carol", Email: "carol@test.com", Password: "password", Role: "user"})
+
+	scores, err := WeeklyScores(gormDB, 2025, 1)
+	if err != nil {
+		t.Fatalf("WeeklyScores() error = %v", err)
+	}
+	if len(scores) != 3 || scores[0].PlayerName != "Alice" || scores[0].Rank != 1 || scores[0].MaxPoints != 2 {
+		t.Fatalf("Unexpected weekly scores: %+v", scores)
+	}
+	if scores[1].PlayerName != "Bob" || scores[1].Rank != 2 || scores[2].PlayerName != "Carol" || scores[2].Rank != 2 {
+		t.Errorf("Expected Bob and Carol to tie without points, got %+v", scores[1:])
+	}
+
+	remaining, err := WeekGamesRemaining(gormDB, 2025, 1)
+	if err != nil || remaining != 0 {
+		t.Errorf("WeekGamesRemaining() = %d, %v, want 0", remaining, err)
+	}
+	remaining, err = PoolGamesRemaining(gormDB, 2025, []int{database.SeasonTypeRegular, database.SeasonTypePostseason})
+	if err != nil || remaining != 1 {
+		t.Errorf("PoolGamesRemaining() = %d, %v, want 1", remaining, err)
+	}
+}
@@ -0,0 +1,178 @@
+// Package seasons computes season standings and survivor results, archives them when a
+// season is complete and rolls the pool over to the next season.
+package seasons
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+	"gorm.io/gorm"
+)
+
+// Season errors.
+var (
+	ErrSeasonComplete    = errors.New("season is already complete")
+	ErrSeasonNotFinished = errors.New("season has games without results")
+	ErrNotNextSeason     = errors.New("season is not after the current season")
+)
+
+// TimeProvider is the application clock. It is injected so that tests and E2E runs can
+// control the time.
+type TimeProvider = clock.Clock
+
+// Service archives complete seasons and rolls the pool over to the next season.
+type Service struct {
+	db           *database.Database
+	config       *config.Config
+	rules        scoring.Rules
+	tiebreakers  []string
+	timeProvider TimeProvider
+	// live holds the subscribers to live standings
+	live liveSubscribers
+}
+
+// NewService creates a new Service instance.
+func NewService(db *database.Database, config *config.Config) (*Service, error) {
+	return NewServiceWithTimeProvider(db, config, clock.Real{})
+}
+
+// NewServiceWithTimeProvider creates a new Service instance with a custom time provider.
+// This is primarily for testing purposes.
+func NewServiceWithTimeProvider(db *database.Database, config *config.Config, timeProvider TimeProvider) (*Service, error) {
+	rules, err := scoring.New(config)
+	if err != nil {
+		return nil, err
+	}
+	tiebreakers, err := tiebreakerChain(config.Pool.Tiebreakers)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{db: db, config: config, rules: rules, tiebreakers: tiebreakers, timeProvider: timeProvider}, nil
+}
+
+// CurrentSeason returns the year of the season being played or prepared, which is the
+// configured season year until seasons are recorded.
+func (s *Service) CurrentSeason() (int, error) {
+	return database.CurrentSeason(s.db.GetDB(), s.config.ESPN.SeasonYear)
+}
+
+// Archive freezes the final standings of every pool and the survivor results of a season,
+// and marks the season complete. Every game that counts toward a pool must have a result.
+// Archived standings are served in place of materialized ones, so later changes to picks or
+// results no longer affect a complete season.
+func (s *Service) Archive(year int) error {
+	season, err := s.db.EnsureSeason(year)
+	if err != nil {
+		return fmt.Errorf("failed to load season %d: %w", year, err)
+	}
+	if season.State == database.SeasonStateComplete {
+		return fmt.Errorf("season %d: %w", year, ErrSeasonComplete)
+	}
+
+	var pools []string
+	var counted []int
+	for _, pool := range []string{database.PoolSeason, database.PoolPlayoffs} {
+		if seasonTypes := PoolSeasonTypes(pool, s.config.Pool.PlayoffMode); seasonTypes != nil {
+			pools = append(pools, pool)
+			counted = append(counted, seasonTypes...)
+		}
+	}
+	var unfinished int64
+	err = s.db.GetDB().Model(&database.Game{}).
+		Where("season = ? AND season_type IN ?", year, counted).
+		Where("NOT EXISTS (SELECT 1 FROM results WHERE results.game_id = games.id AND results.deleted_at IS NULL)").
+		Count(&unfinished).Error
+	if err != nil {
+		return fmt.Errorf("failed to check results of season %d: %w", year, err)
+	}
+	if unfinished > 0 {
+		return fmt.Errorf("season %d has %d unfinished games: %w", year, unfinished, ErrSeasonNotFinished)
+	}
+
+	// Archive standings that reflect every correction made to the season
+	if err := s.Recompute(year); err != nil {
+		return err
+	}
+
+	now := s.timeProvider.Now()
+	err = s.db.GetDB().Transaction(func(tx *gorm.DB) error {
+		for _, pool := range pools {
+			standings, err := SeasonStandings(tx, year, pool)
+			if err != nil {
+				return fmt.Errorf("failed to load %s standings: %w", pool, err)
+			}
+			for _, standing := range standings {
+				archived := database.ArchivedStanding{
+					Season:     year,
+					Pool:       pool,
+					UserID:     standing.UserID,
+					PlayerName: standing.PlayerName,
+					Score:      standing.Points,
+					Rank:       standing.Rank,
+				}
+				if err := tx.Create(&archived).Error; err != nil {
+					return fmt.Errorf("failed to archive %s standings: %w", pool, err)
+				}
+			}
+		}
+
+		survivorResults, err := SurvivorResults(tx, year)
+		if err != nil {
+			return fmt.Errorf("failed to compute survivor results: %w", err)
+		}
+		for _, result := range survivorResults {
+			archived := database.ArchivedSurvivorResult{
+				Season:     year,
+				UserID:     result.UserID,
+				Week:       result.Week,
+				PlayerName: result.PlayerName,
+				Team:       result.Team,
+				Outcome:    result.Outcome,
+			}
+			if err := tx.Create(&archived).Error; err != nil {
+				return fmt.Errorf("failed to archive survivor results: %w", err)
+			}
+		}
+
+		return tx.Model(season).Updates(map[string]interface{}{
+			"state":       database.SeasonStateComplete,
+			"archived_at": now,
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive season %d: %w", year, err)
+	}
+
+	slog.Info("Archived season", "season", year)
+	return nil
+}
+
+// Rollover archives the current season, unless it is already complete, and creates the
+// next season as upcoming, which makes it the current season. Records of past seasons are
+// left as they are.
+func (s *Service) Rollover(next int) (*database.Season, error) {
+	current, err := s.CurrentSeason()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load current season: %w", err)
+	}
+	if next <= current {
+		return nil, fmt.Errorf("season %d, current season %d: %w", next, current, ErrNotNextSeason)
+	}
+
+	if err := s.Archive(current); err != nil && !errors.Is(err, ErrSeasonComplete) {
+		return nil, err
+	}
+
+	season, err := s.db.EnsureSeason(next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create season %d: %w", next, err)
+	}
+
+	slog.Info("Rolled over to the next season", "previous", current, "season", next)
+	return season, nil
+}
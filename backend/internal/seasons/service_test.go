@@ -0,0 +1,184 @@
+package seasons
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+)
+
+// setupSeasonTest creates a 2025 season with a regular season and a postseason game, picks
+// by two players and survivor picks, and a service running in separate playoff mode.
+func setupSeasonTest(t *testing.T) (*database.Database, *Service) {
+	t.Helper()
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	gormDB := db.GetDB()
+
+	users := []database.User{
+		{Name: "Alice", Email: "alice@test.com", Password: "password", Role: "user"},
+		{Name: "Bob", Email: "bob@test.com", Password: "password", Role: "user"},
+	}
+	if err := gormDB.Create(&users).Error; err != nil {
+		t.Fatalf("Failed to create users: %v", err)
+	}
+
+	games := []database.Game{
+		{Week: 1, Season: 2025, SeasonType: database.SeasonTypeRegular, HomeTeam: "Philadelphia Eagles", AwayTeam: "Dallas Cowboys", Spread: 7},
+		{Week: 19, Season: 2025, SeasonType: database.SeasonTypePostseason, HomeTeam: "Buffalo Bills", AwayTeam: "Baltimore Ravens", Spread: 1},
+	}
+	if err := gormDB.Create(&games).Error; err != nil {
+		t.Fatalf("Failed to create games: %v", err)
+	}
+
+	picks := []database.Pick{
+		{UserID: users[0].ID, GameID: games[0].ID, Picked: scoring.SideFavorite, Rank: 2},
+		{UserID: users[1].ID, GameID: games[0].ID, Picked: scoring.SideUnderdog, Rank: 1},
+		{UserID: users[1].ID, GameID: games[1].ID, Picked: scoring.SideUnderdog, Rank: 3},
+	}
+	if err := gormDB.Create(&picks).Error; err != nil {
+		t.Fatalf("Failed to create picks: %v", err)
+	}
+
+	survivorPicks := []database.SurvivorPick{
+		{UserID: users[0].ID, Season: 2025, Week: 1, Team: "Eagles"},
+		{UserID: users[1].ID, Season: 2025, Week: 1, Team: "Cowboys"},
+		{UserID: users[1].ID, Season: 2025, Week: 2, Team: "Cowboys"},
+		// A pick of another season is left out
+		{UserID: users[0].ID, Season: 2024, Week: 1, Team: "Eagles"},
+	}
+	if err := gormDB.Create(&survivorPicks).Error; err != nil {
+		t.Fatalf("Failed to create survivor picks: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.ESPN.SeasonYear = 2025
+	cfg.Pool.PlayoffMode = config.PlayoffModeSeparate
+
+	appClock := clock.NewAdjustable()
+	appClock.Set(time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC))
+	service, err := NewServiceWithTimeProvider(db, cfg, appClock)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	return db, service
+}
+
+// finishGames records a result for every game of the 2025 season.
+func finishGames(t *testing.T, db *database.Database) {
+	t.Helper()
+	results := []database.Result{
+		// The Eagles win by 10 and cover the 7 point spread
+		{GameID: 1, FavoriteScore: 24, UnderdogScore: 14, Outcome: scoring.SideFavorite},
+		// The Ravens win outright as underdogs
+		{GameID: 2, FavoriteScore: 20, UnderdogScore: 27, Outcome: scoring.SideUnderdog},
+	}
+	if err := db.GetDB().Create(&results).Error; err != nil {
+		t.Fatalf("Failed to create results: %v", err)
+	}
+}
+
+func TestService_Archive(t *testing.T) {
+	db, service := setupSeasonTest(t)
+
+	// Games without results keep the season from being archived
+	if err := service.Archive(2025); !errors.Is(err, ErrSeasonNotFinished) {
+		t.Fatalf("Archive() error = %v, want %v", err, ErrSeasonNotFinished)
+	}
+
+	finishGames(t, db)
+	if err := service.Archive(2025); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+
+	season, err := db.GetSeason(2025)
+	if err != nil {
+		t.Fatalf("Failed to get season: %v", err)
+	}
+	if season.State != database.SeasonStateComplete || season.ArchivedAt == nil || !season.ArchivedAt.Truncate(time.Minute).Equal(time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected archived season: %+v", season)
+	}
+
+	var standings []database.ArchivedStanding
+	if err := db.GetDB().Order("pool DESC, rank").Find(&standings).Error; err != nil {
+		t.Fatalf("Failed to get archived standings: %v", err)
+	}
+	if len(standings) != 4 || standings[0].Pool != database.PoolSeason || standings[0].PlayerName != "Alice" || standings[0].Score != 2 || standings[0].Rank != 1 {
+		t.Fatalf("Unexpected archived standings: %+v", standings)
+	}
+	if standings[1].PlayerName != "Bob" || standings[1].Score != 0 || standings[1].Rank != 2 {
+		t.Errorf("Unexpected archived season standings: %+v", standings[1])
+	}
+	if standings[2].Pool != database.PoolPlayoffs || standings[2].PlayerName != "Bob" || standings[2].Score != 3 {
+		t.Errorf("Unexpected archived playoff standings: %+v", standings[2])
+	}
+
+	var survivor []database.ArchivedSurvivorResult
+	if err := db.GetDB().Order("user_id, week").Find(&survivor).Error; err != nil {
+		t.Fatalf("Failed to get archived survivor results: %v", err)
+	}
+	if len(survivor) != 3 || survivor[0].Outcome != SurvivorWin || survivor[1].Outcome != SurvivorLoss || survivor[2].Outcome != SurvivorNoGame {
+		t.Errorf("Unexpected archived survivor results: %+v", survivor)
+	}
+
+	// Archived standings are frozen
+	if err := db.GetDB().Where("1 = 1").Delete(&database.Result{}).Error; err != nil {
+		t.Fatalf("Failed to delete results: %v", err)
+	}
+	if err := service.Archive(2025); !errors.Is(err, ErrSeasonComplete) {
+		t.Errorf("Archive() error = %v, want %v", err, ErrSeasonComplete)
+	}
+	var count int64
+	db.GetDB().Model(&database.ArchivedStanding{}).Count(&count)
+	if count != 4 {
+		t.Errorf("Expected 4 archived standings to remain, got %d", count)
+	}
+}
+
+func TestService_Rollover(t *testing.T) {
+	db, service := setupSeasonTest(t)
+
+	if _, err := service.Rollover(2025); !errors.Is(err, ErrNotNextSeason) {
+		t.Errorf("Rollover() error = %v, want %v", err, ErrNotNextSeason)
+	}
+	if _, err := service.Rollover(2026); !errors.Is(err, ErrSeasonNotFinished) {
+		t.Errorf("Rollover() error = %v, want %v", err, ErrSeasonNotFinished)
+	}
+	if _, err := db.GetSeason(2026); err == nil {
+		t.Error("Expected the next season not to be created while the current season is unfinished")
+	}
+
+	finishGames(t, db)
+	season, err := service.Rollover(2026)
+	if err != nil {
+		t.Fatalf("Rollover() error = %v", err)
+	}
+	if season.Year != 2026 || season.State != database.SeasonStateUpcoming {
+		t.Errorf("Unexpected next season: %+v", season)
+	}
+
+	current, err := service.CurrentSeason()
+	if err != nil {
+		t.Fatalf("CurrentSeason() error = %v", err)
+	}
+	if current != 2026 {
+		t.Errorf("CurrentSeason() = %d, want 2026", current)
+	}
+
+	// Past seasons are left as they are
+	var games int64
+	db.GetDB().Model(&database.Game{}).Where("season = ?", 2025).Count(&games)
+	if games != 2 {
+		t.Errorf("Expected 2 games of the past season, got %d", games)
+	}
+	previous, err := db.GetSeason(2025)
+	if err != nil || previous.State != database.SeasonStateComplete {
+		t.Errorf("Expected the past season to be complete, got %+v (%v)", previous, err)
+	}
+}
@@ -0,0 +1,64 @@
+package seasons
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// Standing is a player's score in a pool, ranked against the other players.
+type Standing struct {
+	UserID     uint
+	PlayerName string
+	Score      float32
+	// Rank is shared by players with the same score
+	Rank int
+}
+
+// PoolSeasonTypes returns the season types whose games count toward a pool under the
+// given playoff mode. It returns nil for the playoff pool unless playoffs are a separate pool.
+func PoolSeasonTypes(pool, playoffMode string) []int {
+	switch {
+	case pool == database.PoolPlayoffs && playoffMode == config.PlayoffModeSeparate:
+		return []int{database.SeasonTypePostseason}
+	case pool == database.PoolPlayoffs:
+		return nil
+	case playoffMode == config.PlayoffModeConfidence:
+		return []int{database.SeasonTypeRegular, database.SeasonTypePostseason}
+	default:
+		return []int{database.SeasonTypeRegular}
+	}
+}
+
+// rank orders standings by score and then by name, and ranks them so that tied players
+// share a rank and the next player's rank skips past them.
+func rank(standings []Standing) {
+	rankBy(standings, func(standing *Standing) (float32, string) {
+		return standing.Score, standing.PlayerName
+	}, func(standing *Standing, rank int) {
+		standing.Rank = rank
+	})
+}
+
+// rankBy orders rows by score and then by name, and sets competition ranks on them: tied
+// rows share a rank and the next row's rank skips past them.
+func rankBy[T any](rows []T, key func(*T) (float32, string), setRank func(*T, int)) {
+	slices.SortFunc(rows, func(a, b T) int {
+		scoreA, nameA := key(&a)
+		scoreB, nameB := key(&b)
+		return cmp.Or(cmp.Compare(scoreB, scoreA), cmp.Compare(nameA, nameB))
+	})
+
+	var previous float32
+	current := 0
+	for i := range rows {
+		score, _ := key(&rows[i])
+		if i == 0 || score != previous {
+			current = i + 1
+		}
+		setRank(&rows[i], current)
+		previous = score
+	}
+}
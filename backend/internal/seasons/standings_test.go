@@ -0,0 +1,50 @@
+package seasons
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+func TestPoolSeasonTypes(t *testing.T) {
+	tests := []struct {
+		pool        string
+		playoffMode string
+		expected    []int
+	}{
+		{pool: database.PoolSeason, playoffMode: config.PlayoffModeNone, expected: []int{database.SeasonTypeRegular}},
+		{pool: database.PoolSeason, playoffMode: config.PlayoffModeSeparate, expected: []int{database.SeasonTypeRegular}},
+		{pool: database.PoolSeason, playoffMode: config.PlayoffModeConfidence, expected: []int{database.SeasonTypeRegular, database.SeasonTypePostseason}},
+		{pool: database.PoolPlayoffs, playoffMode: config.PlayoffModeSeparate, expected: []int{database.SeasonTypePostseason}},
+		{pool: database.PoolPlayoffs, playoffMode: config.PlayoffModeConfidence},
+		{pool: database.PoolPlayoffs, playoffMode: config.PlayoffModeNone},
+	}
+
+	for _, tt := range tests {
+		if got := PoolSeasonTypes(tt.pool, tt.playoffMode); !slices.Equal(got, tt.expected) {
+			t.Errorf("PoolSeasonTypes(%s, %s) = %v, want %v", tt.pool, tt.playoffMode, got, tt.expected)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	standings := []Standing{
+		{PlayerName: "Dave", Score: 10},
+		{PlayerName: "Carol", Score: 12.5},
+		{PlayerName: "Bob", Score: 10},
+		{PlayerName: "Alice", Score: 7},
+	}
+	rank(standings)
+
+	expected := []Standing{
+		{PlayerName: "Carol", Score: 12.5, Rank: 1},
+		{PlayerName: "Bob", Score: 10, Rank: 2},
+		{PlayerName: "Dave", Score: 10, Rank: 2},
+		{PlayerName: "Alice", Score: 7, Rank: 4},
+	}
+	if !slices.Equal(standings, expected) {
+		t.Errorf("rank() = %+v, want %+v", standings, expected)
+	}
+}
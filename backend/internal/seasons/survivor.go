@@ -0,0 +1,97 @@
+package seasons
+
+import (
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// Survivor pick outcomes. A survivor pick wins when the team wins its game outright.
+const (
+	SurvivorWin     = "win"
+	SurvivorLoss    = "loss"
+	SurvivorTie     = "tie"
+	SurvivorPending = "pending"
+	// SurvivorNoGame is the outcome of a pick whose team has no game that week
+	SurvivorNoGame = "no_game"
+)
+
+// SurvivorResult is the outcome of a survivor pick.
+type SurvivorResult struct {
+	UserID     uint
+	PlayerName string
+	Week       int
+	Team       string
+	Outcome    string
+}
+
+// SurvivorResults returns the outcome of every survivor pick of a season, ordered by
+// player and week.
+func SurvivorResults(db *gorm.DB, season int) ([]SurvivorResult, error) {
+	var picks []database.SurvivorPick
+	if err := db.Preload("User").Where("season = ?", season).Order("user_id, week").Find(&picks).Error; err != nil {
+		return nil, err
+	}
+
+	var games []database.Game
+	if err := db.Where("season = ?", season).Find(&games).Error; err != nil {
+		return nil, err
+	}
+	gameIDs := make([]uint, len(games))
+	for i, game := range games {
+		gameIDs[i] = game.ID
+	}
+
+	var results []database.Result
+	if err := db.Where("game_id IN ?", gameIDs).Find(&results).Error; err != nil {
+		return nil, err
+	}
+	resultsMap := make(map[uint]database.Result)
+	for _, result := range results {
+		resultsMap[result.GameID] = result
+	}
+
+	survivorResults := make([]SurvivorResult, len(picks))
+	for i, pick := range picks {
+		survivorResults[i] = SurvivorResult{
+			UserID:     pick.UserID,
+			PlayerName: pick.User.Name,
+			Week:       pick.Week,
+			Team:       pick.Team,
+			Outcome:    SurvivorNoGame,
+		}
+		for _, game := range games {
+			if game.Week != pick.Week {
+				continue
+			}
+			home := database.TeamNameMatches(pick.Team, game.HomeTeam)
+			if !home && !database.TeamNameMatches(pick.Team, game.AwayTeam) {
+				continue
+			}
+			survivorResults[i].Outcome = survivorOutcome(resultsMap, game, home)
+			break
+		}
+	}
+	return survivorResults, nil
+}
+
+// survivorOutcome returns the outcome of picking the home or away team of a game.
+// Results store the home score as the favorite score and the away score as the underdog score.
+func survivorOutcome(results map[uint]database.Result, game database.Game, home bool) string {
+	result, ok := results[game.ID]
+	if !ok {
+		return SurvivorPending
+	}
+
+	picked, opponent := result.FavoriteScore, result.UnderdogScore
+	if !home {
+		picked, opponent = opponent, picked
+	}
+	switch {
+	case picked > opponent:
+		return SurvivorWin
+	case picked < opponent:
+		return SurvivorLoss
+	default:
+		return SurvivorTie
+	}
+}
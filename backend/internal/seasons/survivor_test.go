@@ -0,0 +1,54 @@
+package seasons
+
+import (
+	"testing"
+
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+func TestSurvivorResults(t *testing.T) {
+	db, _ := setupSeasonTest(t)
+
+	// Picks are pending until their game has a result
+	results, err := SurvivorResults(db.GetDB(), 2025)
+	if err != nil {
+		t.Fatalf("SurvivorResults() error = %v", err)
+	}
+	if len(results) != 3 || results[0].Outcome != SurvivorPending || results[1].Outcome != SurvivorPending || results[2].Outcome != SurvivorNoGame {
+		t.Fatalf("Unexpected survivor results: %+v", results)
+	}
+
+	finishGames(t, db)
+	results, err = SurvivorResults(db.GetDB(), 2025)
+	if err != nil {
+		t.Fatalf("SurvivorResults() error = %v", err)
+	}
+	expected := []SurvivorResult{
+		{UserID: 1, PlayerName: "Alice", Week: 1, Team: "Eagles", Outcome: SurvivorWin},
+		{UserID: 2, PlayerName: "Bob", Week: 1, Team: "Cowboys", Outcome: SurvivorLoss},
+		{UserID: 2, PlayerName: "Bob", Week: 2, Team: "Cowboys", Outcome: SurvivorNoGame},
+	}
+	for i := range expected {
+		if results[i] != expected[i] {
+			t.Errorf("SurvivorResults()[%d] = %+v, want %+v", i, results[i], expected[i])
+		}
+	}
+}
+
+func TestSurvivorOutcome(t *testing.T) {
+	game := database.Game{}
+	game.ID = 1
+	tie := map[uint]database.Result{1: {FavoriteScore: 17, UnderdogScore: 17}}
+	if outcome := survivorOutcome(tie, game, true); outcome != SurvivorTie {
+		t.Errorf("survivorOutcome() = %s, want %s", outcome, SurvivorTie)
+	}
+
+	// The away team wins with the underdog score
+	awayWin := map[uint]database.Result{1: {FavoriteScore: 10, UnderdogScore: 21}}
+	if outcome := survivorOutcome(awayWin, game, false); outcome != SurvivorWin {
+		t.Errorf("survivorOutcome() = %s, want %s", outcome, SurvivorWin)
+	}
+	if outcome := survivorOutcome(awayWin, game, true); outcome != SurvivorLoss {
+		t.Errorf("survivorOutcome() = %s, want %s", outcome, SurvivorLoss)
+	}
+}
@@ -0,0 +1,217 @@
+package seasons
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"gorm.io/gorm"
+)
+
+// defaultTiebreakers is the tiebreaker chain used when none is configured.
+var defaultTiebreakers = []string{
+	config.TiebreakerClosestTotal,
+	config.TiebreakerMostCorrect,
+	config.TiebreakerHighestCorrectPick,
+	config.TiebreakerEarliestSubmission,
+}
+
+// WeeklyStanding is a player's weekly score with their tiebreaker prediction.
+type WeeklyStanding struct {
+	database.WeeklyScore
+	// PredictedTotal is nil when the player made no prediction
+	PredictedTotal *int
+}
+
+// tiebreakerChain validates the configured tiebreakers.
+func tiebreakerChain(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return defaultTiebreakers, nil
+	}
+	for i, name := range names {
+		if !slices.Contains(defaultTiebreakers, name) {
+			return nil, fmt.Errorf("invalid tiebreaker %q", name)
+		}
+		if slices.Contains(names[:i], name) {
+			return nil, fmt.Errorf("duplicate tiebreaker %q", name)
+		}
+	}
+	return names, nil
+}
+
+// TiebreakerGame returns the designated tiebreaker game of a week, which is its last game
+// to kick off.
+func TiebreakerGame(db *gorm.DB, season, week int) (database.Game, error) {
+	var game database.Game
+	err := db.Where("season = ? AND week = ?", season, week).Order("start_time DESC, id DESC").First(&game).Error
+	return game, err
+}
+
+// WeeklyStandings returns the weekly scores of every pool member with ties broken by the
+// configured tiebreakers. Players the tiebreakers separate get distinct ranks, while players
+// who are still level share a rank.
+func (s *Service) WeeklyStandings(season, week int) ([]WeeklyStanding, error) {
+	db := s.db.GetDB()
+	scores, err := WeeklyScores(db, season, week)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := loadTiebreakStats(db, season, week)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]WeeklyStanding, len(scores))
+	for i, score := range scores {
+		standings[i] = WeeklyStanding{WeeklyScore: score}
+		if prediction, ok := stats.predictions[score.UserID]; ok {
+			standings[i].PredictedTotal = &prediction
+		}
+	}
+	breakTies(standings, s.tiebreakers, stats)
+	return standings, nil
+}
+
+// tiebreakStats holds what the tiebreakers compare besides the weekly scores.
+type tiebreakStats struct {
+	predictions map[uint]int
+	// total is the total points of the tiebreaker game, or nil before its result
+	total     *int
+	submitted map[uint]time.Time
+}
+
+// loadTiebreakStats loads the predictions, the tiebreaker game's total and the sheet
+// submission times of a week. A sheet counts as submitted at its latest pick or prediction,
+// both of which are timed by the application clock.
+func loadTiebreakStats(db *gorm.DB, season, week int) (tiebreakStats, error) {
+	stats := tiebreakStats{predictions: map[uint]int{}, submitted: map[uint]time.Time{}}
+
+	var tiebreakers []database.Tiebreaker
+	if err := db.Where("season = ? AND week = ?", season, week).Find(&tiebreakers).Error; err != nil {
+		return stats, err
+	}
+	for _, tiebreaker := range tiebreakers {
+		stats.predictions[tiebreaker.UserID] = tiebreaker.PredictedTotal
+		stats.submitted[tiebreaker.UserID] = tiebreaker.SubmittedAt
+	}
+
+	var picks []database.Pick
+	err := db.Joins("JOIN games ON games.id = picks.game_id").
+		Where("games.season = ? AND games.week = ?", season, week).
+		Find(&picks).Error
+	if err != nil {
+		return stats, err
+	}
+	for _, pick := range picks {
+		submitted := pick.SubmittedAt
+		if submitted.IsZero() {
+			// Picks made before submission times were recorded fall back to their last change
+			submitted = pick.UpdatedAt
+		}
+		if submitted.After(stats.submitted[pick.UserID]) {
+			stats.submitted[pick.UserID] = submitted
+		}
+	}
+
+	game, err := TiebreakerGame(db, season, week)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	var result database.Result
+	err = db.Where("game_id = ?", game.ID).First(&result).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	total := result.FavoriteScore + result.UnderdogScore
+	stats.total = &total
+	return stats, nil
+}
+
+// breakTies reorders the players who share a rank by the tiebreakers and ranks them again.
+// The standings must already be ranked by points.
+func breakTies(standings []WeeklyStanding, tiebreakers []string, stats tiebreakStats) {
+	compare := func(a, b WeeklyStanding) int {
+		for _, tiebreaker := range tiebreakers {
+			if c := compareTiebreaker(tiebreaker, a, b, stats); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+
+	for start := 0; start < len(standings); {
+		end := start + 1
+		for end < len(standings) && standings[end].Rank == standings[start].Rank {
+			end++
+		}
+		tied := standings[start:end]
+		slices.SortStableFunc(tied, compare)
+		for i := 1; i < len(tied); i++ {
+			if compare(tied[i-1], tied[i]) != 0 {
+				tied[i].Rank = start + i + 1
+			} else {
+				tied[i].Rank = tied[i-1].Rank
+			}
+		}
+		start = end
+	}
+}
+
+// compareTiebreaker compares two tied players by one tiebreaker, ordering the player the
+// tiebreaker favors first.
+func compareTiebreaker(tiebreaker string, a, b WeeklyStanding, stats tiebreakStats) int {
+	switch tiebreaker {
+	case config.TiebreakerClosestTotal:
+		if stats.total == nil {
+			return 0
+		}
+		predictionA, okA := stats.predictions[a.UserID]
+		predictionB, okB := stats.predictions[b.UserID]
+		if !okA || !okB {
+			// Players with a prediction come before players without one
+			return compareMissing(okA, okB)
+		}
+		return cmp.Compare(distance(predictionA, *stats.total), distance(predictionB, *stats.total))
+	case config.TiebreakerMostCorrect:
+		return cmp.Compare(b.Correct, a.Correct)
+	case config.TiebreakerHighestCorrectPick:
+		return cmp.Compare(b.HighestCorrectRank, a.HighestCorrectRank)
+	case config.TiebreakerEarliestSubmission:
+		submittedA, okA := stats.submitted[a.UserID]
+		submittedB, okB := stats.submitted[b.UserID]
+		if !okA || !okB {
+			return compareMissing(okA, okB)
+		}
+		return submittedA.Compare(submittedB)
+	}
+	return 0
+}
+
+// compareMissing orders a present value before a missing one.
+func compareMissing(okA, okB bool) int {
+	switch {
+	case okA == okB:
+		return 0
+	case okA:
+		return -1
+	}
+	return 1
+}
+
+// distance returns how far a prediction is from the actual total.
+func distance(prediction, total int) int {
+	if prediction > total {
+		return prediction - total
+	}
+	return total - prediction
+}
@@ -0,0 +1,128 @@
+package seasons
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
+)
+
+func TestTiebreakerChain(t *testing.T) {
+	chain, err := tiebreakerChain(nil)
+	if err != nil || !slices.Equal(chain, defaultTiebreakers) {
+		t.Errorf("tiebreakerChain(nil) = %v, %v, want the default chain", chain, err)
+	}
+	chain, err = tiebreakerChain([]string{config.TiebreakerMostCorrect})
+	if err != nil || !slices.Equal(chain, []string{config.TiebreakerMostCorrect}) {
+		t.Errorf("tiebreakerChain() = %v, %v", chain, err)
+	}
+	if _, err := tiebreakerChain([]string{"coin_toss"}); err == nil {
+		t.Error("Expected an error for an unknown tiebreaker")
+	}
+	if _, err := tiebreakerChain([]string{config.TiebreakerMostCorrect, config.TiebreakerMostCorrect}); err == nil {
+		t.Error("Expected an error for a repeated tiebreaker")
+	}
+}
+
+func TestBreakTies(t *testing.T) {
+	standing := func(userID uint, name string, points float32, rank, correct, highest int) WeeklyStanding {
+		return WeeklyStanding{WeeklyScore: database.WeeklyScore{UserID: userID, PlayerName: name, Points: points, Rank: rank, Correct: correct, HighestCorrectRank: highest}}
+	}
+	ranked := []WeeklyStanding{
+		standing(1, "Alice", 12, 1, 4, 8),
+		standing(2, "Bob", 10, 2, 3, 5),
+		standing(3, "Carol", 10, 2, 4, 6),
+		standing(4, "Dave", 10, 2, 3, 5),
+		standing(5, "Erin", 10, 2, 2, 4),
+		standing(6, "Frank", 10, 2, 2, 4),
+	}
+	kickoff := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+	total := 45
+	stats := tiebreakStats{
+		// Bob and Dave are one point off, while Carol is well off the total
+		predictions: map[uint]int{1: 45, 2: 44, 3: 60, 4: 46},
+		total:       &total,
+		// Bob submitted his sheet before Dave
+		submitted: map[uint]time.Time{1: kickoff, 2: kickoff.Add(-2 * time.Hour), 3: kickoff, 4: kickoff.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name          string
+		total         *int
+		expectedNames []string
+		expectedRanks []int
+	}{
+		{"closest total", &total, []string{"Alice", "Bob", "Dave", "Carol", "Erin", "Frank"}, []int{1, 2, 3, 4, 5, 5}},
+		// Without a result the predictions cannot be compared
+		{"before the result", nil, []string{"Alice", "Carol", "Bob", "Dave", "Erin", "Frank"}, []int{1, 2, 3, 4, 5, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standings := slices.Clone(ranked)
+			stats.total = tt.total
+			breakTies(standings, defaultTiebreakers, stats)
+
+			for i, standing := range standings {
+				if standing.PlayerName != tt.expectedNames[i] || standing.Rank != tt.expectedRanks[i] {
+					t.Errorf("Standing %d = %s ranked %d, want %s ranked %d", i, standing.PlayerName, standing.Rank, tt.expectedNames[i], tt.expectedRanks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestService_WeeklyStandings(t *testing.T) {
+	db, service := setupSeasonTest(t)
+	gormDB := db.GetDB()
+
+	// Carol makes no picks and ties Bob without points, but only Bob predicts the total
+	gormDB.Create(&database.User{Name: "Carol", Email: "carol@test.com", Password: "password", Role: "user"})
+	gormDB.Create(&database.Tiebreaker{UserID: 2, Season: 2025, Week: 1, PredictedTotal: 41})
+	gormDB.Create(&database.Result{GameID: 1, FavoriteScore: 24, UnderdogScore: 14, Outcome: scoring.SideFavorite})
+	if err := service.UpdateWeek(2025, 1); err != nil {
+		t.Fatalf("UpdateWeek() error = %v", err)
+	}
+
+	standings, err := service.WeeklyStandings(2025, 1)
+	if err != nil {
+		t.Fatalf("WeeklyStandings() error = %v", err)
+	}
+	if len(standings) != 3 || standings[0].PlayerName != "Alice" || standings[0].Rank != 1 || standings[0].PredictedTotal != nil {
+		t.Fatalf("Unexpected weekly standings: %+v", standings)
+	}
+	if standings[1].PlayerName != "Bob" || standings[1].Rank != 2 || standings[1].PredictedTotal == nil || *standings[1].PredictedTotal != 41 {
+		t.Errorf("Unexpected standing of Bob: %+v", standings[1])
+	}
+	if standings[2].PlayerName != "Carol" || standings[2].Rank != 3 {
+		t.Errorf("Unexpected standing of Carol: %+v", standings[2])
+	}
+}
+
+func TestLoadTiebreakStats(t *testing.T) {
+	db, _ := setupSeasonTest(t)
+	gormDB := db.GetDB()
+	kickoff := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+
+	// Sheets are timed by the application clock rather than when their rows last changed
+	gormDB.Model(&database.Pick{}).Where("user_id = ?", 1).Update("submitted_at", kickoff.Add(-2*time.Hour))
+	gormDB.Model(&database.Pick{}).Where("user_id = ?", 2).Update("submitted_at", kickoff.Add(-3*time.Hour))
+	gormDB.Create(&database.Tiebreaker{UserID: 1, Season: 2025, Week: 1, PredictedTotal: 41, SubmittedAt: kickoff.Add(-time.Hour)})
+
+	stats, err := loadTiebreakStats(gormDB, 2025, 1)
+	if err != nil {
+		t.Fatalf("loadTiebreakStats() error = %v", err)
+	}
+	if submitted := stats.submitted[1]; !submitted.Equal(kickoff.Add(-time.Hour)) {
+		t.Errorf("Expected Alice's sheet to be submitted with her prediction at %v, got %v", kickoff.Add(-time.Hour), submitted)
+	}
+	if submitted := stats.submitted[2]; !submitted.Equal(kickoff.Add(-3 * time.Hour)) {
+		t.Errorf("Expected Bob's sheet to be submitted with his picks at %v, got %v", kickoff.Add(-3*time.Hour), submitted)
+	}
+	if prediction, ok := stats.predictions[1]; !ok || prediction != 41 {
+		t.Errorf("Expected Alice's prediction of 41, got %v", stats.predictions)
+	}
+}
@@ -9,26 +9,89 @@ import (
 	"time"
 
 	"github.com/dhpollack/football-pool/internal/auth"
+	"github.com/dhpollack/football-pool/internal/clock"
 	"github.com/dhpollack/football-pool/internal/config"
 	"github.com/dhpollack/football-pool/internal/database"
+	espnsync "github.com/dhpollack/football-pool/internal/espn-sync"
 	"github.com/dhpollack/football-pool/internal/handlers"
+	"github.com/dhpollack/football-pool/internal/jobs"
+	"github.com/dhpollack/football-pool/internal/seasons"
+	weeklifecycle "github.com/dhpollack/football-pool/internal/week-lifecycle"
 	"github.com/rs/cors"
 )
 
 // Server represents the HTTP server with database and authentication components.
 type Server struct {
-	db   *database.Database
-	auth *auth.Auth
-	cfg  *config.Config
+	db        *database.Database
+	auth      *auth.Auth
+	cfg       *config.Config
+	scheduler *jobs.Scheduler
+
+	// clock is the application clock. It is adjustable through the admin endpoints during
+	// E2E tests, and the system clock otherwise.
+	clock clock.Clock
+
+	// standings materializes the leaderboards whenever results change.
+	standings *seasons.Service
+
+	// lifecycle moves the weeks through their lifecycle on the application clock.
+	lifecycle *weeklifecycle.Service
+
+	// syncService is nil when the ESPN sync service failed to initialize.
+	syncService *espnsync.SyncService
 }
 
-// NewServer creates a new Server instance with the provided database connection.
-func NewServer(db *database.Database, cfg *config.Config) *Server {
-	return &Server{
-		db:   db,
-		auth: auth.NewAuth(db),
-		cfg:  cfg,
+// NewServer creates a new Server instance with the provided database connection. It fails
+// when the pool's scoring rules or tiebreakers are invalid.
+func NewServer(db *database.Database, cfg *config.Config) (*Server, error) {
+	var appClock clock.Clock = clock.Real{}
+	if cfg.E2E.Test {
+		appClock = clock.NewAdjustable()
+	}
+
+	standings, err := seasons.NewServiceWithTimeProvider(db, cfg, appClock)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pool configuration: %w", err)
 	}
+
+	// Standings are materialized when a week locks and brought up to date when it is final
+	lifecycle := weeklifecycle.NewServiceWithTimeProvider(db, cfg, appClock)
+	lifecycle.Subscribe(standings.WeekChanged)
+
+	return &Server{
+		db:        db,
+		auth:      auth.NewAuth(db),
+		cfg:       cfg,
+		scheduler: jobs.NewSchedulerWithTimeProvider(db, cfg, appClock),
+		clock:     appClock,
+		standings: standings,
+		lifecycle: lifecycle,
+	}, nil
+}
+
+// Clock returns the application clock, which the background services should share.
+func (s *Server) Clock() clock.Clock {
+	return s.clock
+}
+
+// Seasons returns the season service that keeps the materialized standings up to date.
+func (s *Server) Seasons() *seasons.Service {
+	return s.standings
+}
+
+// Lifecycle returns the week lifecycle service, whose jobs are registered with the scheduler.
+func (s *Server) Lifecycle() *weeklifecycle.Service {
+	return s.lifecycle
+}
+
+// Scheduler returns the background job scheduler managed through the admin endpoints.
+func (s *Server) Scheduler() *jobs.Scheduler {
+	return s.scheduler
+}
+
+// SetSyncService sets the ESPN sync service managed through the admin endpoints.
+func (s *Server) SetSyncService(syncService *espnsync.SyncService) {
+	s.syncService = syncService
 }
 
 // NewRouter creates and configures the HTTP router with all application routes.
@@ -69,6 +132,14 @@ func (s *Server) NewRouter() http.Handler {
 	mux.Handle("PUT /api/users/me/update", s.auth.Middleware(handlers.UpdateProfile(s.db.GetDB())))
 
 	mux.HandleFunc("GET /api/games", handlers.GetGames(s.db.GetDB()))
+	mux.HandleFunc("GET /api/games/{id}/spreads", handlers.GetGameSpreads(s.db.GetDB()))
+
+	mux.HandleFunc("GET /api/teams", handlers.ListTeams(s.db.GetDB()))
+	mux.Handle("POST /api/admin/teams/{id}/aliases", s.auth.Middleware(s.auth.AdminMiddleware(handlers.AddTeamAlias(s.db.GetDB()))))
+
+	// Schedule changes detected by the ESPN sync
+	mux.Handle("GET /api/admin/schedule-changes", s.auth.Middleware(s.auth.AdminMiddleware(handlers.ListScheduleChanges(s.db.GetDB()))))
+	mux.Handle("POST /api/admin/schedule-changes/{id}/acknowledge", s.auth.Middleware(s.auth.AdminMiddleware(handlers.AcknowledgeScheduleChange(s.db.GetDB(), s.clock))))
 
 	// Admin game management endpoints
 	mux.Handle("GET /api/admin/games", s.auth.Middleware(s.auth.AdminMiddleware(handlers.AdminListGames(s.db.GetDB()))))
@@ -77,8 +148,10 @@ func (s *Server) NewRouter() http.Handler {
 	mux.Handle("DELETE /api/admin/games/{id}", s.auth.Middleware(s.auth.AdminMiddleware(handlers.DeleteGame(s.db.GetDB()))))
 
 	mux.Handle("GET /api/picks", s.auth.Middleware(handlers.GetPicks(s.db.GetDB())))
-	mux.Handle("POST /api/picks/submit", s.auth.Middleware(handlers.SubmitPicks(s.db.GetDB())))
-	mux.Handle("POST /api/admin/picks/submit", s.auth.Middleware(s.auth.AdminMiddleware(handlers.AdminSubmitPicks(s.db.GetDB()))))
+	mux.Handle("POST /api/picks/submit", s.auth.Middleware(handlers.SubmitPicks(s.db.GetDB(), s.clock)))
+	mux.Handle("GET /api/picks/tiebreaker", s.auth.Middleware(handlers.GetTiebreaker(s.db.GetDB(), s.cfg.ESPN.SeasonYear)))
+	mux.Handle("POST /api/picks/tiebreaker/submit", s.auth.Middleware(handlers.SubmitTiebreaker(s.db.GetDB(), s.cfg.ESPN.SeasonYear, s.clock)))
+	mux.Handle("POST /api/admin/picks/submit", s.auth.Middleware(s.auth.AdminMiddleware(handlers.AdminSubmitPicks(s.db.GetDB(), s.clock))))
 
 	// Admin pick management endpoints
 	mux.Handle("GET /api/admin/picks", s.auth.Middleware(s.auth.AdminMiddleware(handlers.AdminListPicks(s.db.GetDB()))))
@@ -86,13 +159,22 @@ func (s *Server) NewRouter() http.Handler {
 	mux.Handle("GET /api/admin/picks/user/{userID}", s.auth.Middleware(s.auth.AdminMiddleware(handlers.AdminGetPicksByUser(s.db.GetDB()))))
 	mux.Handle("DELETE /api/admin/picks/{id}", s.auth.Middleware(s.auth.AdminMiddleware(handlers.AdminDeletePick(s.db.GetDB()))))
 
-	mux.HandleFunc("GET /api/results/week", handlers.GetWeeklyResults(s.db.GetDB()))
-	mux.HandleFunc("GET /api/results/season", handlers.GetSeasonResults(s.db.GetDB()))
+	mux.HandleFunc("GET /api/results/week", handlers.GetWeeklyResults(s.db.GetDB(), s.standings))
+	mux.HandleFunc("GET /api/results/week/live", handlers.GetLiveWeeklyResults(s.standings))
+	mux.HandleFunc("GET /api/results/season", handlers.GetSeasonResults(s.db.GetDB(), s.cfg.Pool.PlayoffMode))
+	// The playoff pool only exists when playoffs are scored separately
+	if s.cfg.Pool.PlayoffMode == config.PlayoffModeSeparate {
+		mux.HandleFunc("GET /api/results/playoffs", handlers.GetPlayoffResults(s.db.GetDB(), s.cfg.Pool.PlayoffMode))
+	}
+
+	mux.Handle("POST /api/results", s.auth.Middleware(s.auth.AdminMiddleware(handlers.SubmitResult(s.db.GetDB(), s.standings))))
 
-	mux.Handle("POST /api/results", s.auth.Middleware(s.auth.AdminMiddleware(handlers.SubmitResult(s.db.GetDB()))))
+	mux.Handle("GET /api/survivor/picks", s.auth.Middleware(handlers.GetSurvivorPicks(s.db.GetDB(), s.cfg.ESPN.SeasonYear)))
+	mux.Handle("POST /api/survivor/picks/submit", s.auth.Middleware(handlers.SubmitSurvivorPick(s.db.GetDB(), s.cfg.ESPN.SeasonYear)))
 
-	mux.Handle("GET /api/survivor/picks", s.auth.Middleware(handlers.GetSurvivorPicks(s.db.GetDB())))
-	mux.Handle("POST /api/survivor/picks/submit", s.auth.Middleware(handlers.SubmitSurvivorPick(s.db.GetDB())))
+	mux.Handle("GET /api/seasons", s.auth.Middleware(handlers.ListSeasons(s.db.GetDB(), s.cfg.ESPN.SeasonYear)))
+	mux.Handle("GET /api/seasons/{year}/standings", s.auth.Middleware(handlers.GetSeasonStandings(s.db.GetDB(), s.cfg.Pool.PlayoffMode)))
+	mux.Handle("GET /api/seasons/{year}/survivor", s.auth.Middleware(handlers.GetSeasonSurvivorResults(s.db.GetDB())))
 
 	mux.Handle("DELETE /api/admin/users/{id}", s.auth.Middleware(s.auth.AdminMiddleware(handlers.DeleteUser(s.db.GetDB()))))
 	mux.Handle("DELETE /api/admin/users/delete", s.auth.Middleware(s.auth.AdminMiddleware(handlers.DeleteUserByEmail(s.db.GetDB()))))
@@ -109,6 +191,28 @@ func (s *Server) NewRouter() http.Handler {
 	mux.Handle("PUT /api/admin/weeks/{id}", s.auth.Middleware(s.auth.AdminMiddleware(handlers.UpdateWeek(s.db.GetDB()))))
 	mux.Handle("DELETE /api/admin/weeks/{id}", s.auth.Middleware(s.auth.AdminMiddleware(handlers.DeleteWeek(s.db.GetDB()))))
 	mux.Handle("POST /api/admin/weeks/{id}/activate", s.auth.Middleware(s.auth.AdminMiddleware(handlers.ActivateWeek(s.db.GetDB()))))
+	mux.Handle("PUT /api/admin/weeks/{season}/{week}/spreads", s.auth.Middleware(s.auth.AdminMiddleware(handlers.BulkUpdateWeekSpreads(s.db.GetDB(), s.clock))))
+
+	// Background job endpoints
+	mux.Handle("GET /api/admin/jobs", s.auth.Middleware(s.auth.AdminMiddleware(handlers.ListJobs(s.scheduler))))
+	mux.Handle("GET /api/admin/jobs/{name}/runs", s.auth.Middleware(s.auth.AdminMiddleware(handlers.ListJobRuns(s.scheduler))))
+	mux.Handle("POST /api/admin/jobs/{name}/run", s.auth.Middleware(s.auth.AdminMiddleware(handlers.TriggerJob(s.scheduler))))
+
+	// ESPN sync endpoints
+	mux.Handle("GET /api/admin/sync/status", s.auth.Middleware(s.auth.AdminMiddleware(handlers.GetSyncStatus(s.syncService))))
+	mux.Handle("POST /api/admin/sync/weeks/{season}/{week}", s.auth.Middleware(s.auth.AdminMiddleware(handlers.SyncWeek(s.syncService))))
+	mux.Handle("POST /api/admin/sync/weeks/{season}/{week}/spreads", s.auth.Middleware(s.auth.AdminMiddleware(handlers.RefreshWeekSpreads(s.db.GetDB(), s.syncService))))
+	mux.Handle("GET /api/admin/sync/weeks/{season}/{week}/spreads/unmatched", s.auth.Middleware(s.auth.AdminMiddleware(handlers.ListUnmatchedSpreads(s.db.GetDB()))))
+	mux.Handle("GET /api/admin/sync/cache", s.auth.Middleware(s.auth.AdminMiddleware(handlers.GetSyncCache(s.syncService, s.clock))))
+	mux.Handle("DELETE /api/admin/sync/cache/{season}/{week}", s.auth.Middleware(s.auth.AdminMiddleware(handlers.DeleteSyncCacheEntry(s.syncService))))
+	mux.Handle("DELETE /api/admin/sync/cache", s.auth.Middleware(s.auth.AdminMiddleware(handlers.ClearSyncCache(s.syncService))))
+
+	// Application clock endpoints, only served during E2E tests
+	if appClock, ok := s.clock.(*clock.Adjustable); ok {
+		mux.Handle("GET /api/admin/e2e/clock", s.auth.Middleware(s.auth.AdminMiddleware(handlers.GetClock(appClock))))
+		mux.Handle("PUT /api/admin/e2e/clock", s.auth.Middleware(s.auth.AdminMiddleware(handlers.SetClock(appClock))))
+		mux.Handle("DELETE /api/admin/e2e/clock", s.auth.Middleware(s.auth.AdminMiddleware(handlers.ResetClock(appClock))))
+	}
 
 	return c.Handler(mux)
 }
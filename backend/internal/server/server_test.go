@@ -1,12 +1,16 @@
 package server
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/dhpollack/football-pool/internal/clock"
 	"github.com/dhpollack/football-pool/internal/config"
 	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/scoring"
 )
 
 func TestStart(t *testing.T) {
@@ -23,7 +27,10 @@ func TestStart(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to connect to database: %v", err)
 	}
-	server := NewServer(db, cfg)
+	server, err := NewServer(db, cfg)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
 
 	// Test that the router can be created without errors
 	router := server.NewRouter()
@@ -61,7 +68,10 @@ func TestStartWithPortFromEnv(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to connect to database: %v", err)
 	}
-	server := NewServer(db, cfg)
+	server, err := NewServer(db, cfg)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
 
 	// Test that the router can be created without errors
 	router := server.NewRouter()
@@ -89,3 +99,203 @@ func TestStartWithPortFromEnv(t *testing.T) {
 		t.Errorf("Expected port 8081 from environment variable, got %s", cfg.Server.Port)
 	}
 }
+
+func TestPlayoffResultsOnlyInSeparateMode(t *testing.T) {
+	t.Setenv("FOOTBALL_POOL_ENV", "test")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Failed to load configuration: %v", err)
+	}
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	tests := []struct {
+		playoffMode string
+		expected    int
+	}{
+		{config.PlayoffModeSeparate, http.StatusOK},
+		{config.PlayoffModeConfidence, http.StatusNotFound},
+		{config.PlayoffModeNone, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.playoffMode, func(t *testing.T) {
+			cfg.Pool.PlayoffMode = tt.playoffMode
+			server, err := NewServer(db, cfg)
+			if err != nil {
+				t.Fatalf("NewServer() error = %v", err)
+			}
+
+			recorder := httptest.NewRecorder()
+			server.NewRouter().ServeHTTP(recorder, httptest.NewRequest("GET", "/api/results/playoffs?season=2025", nil))
+			if recorder.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestNewServerRejectsInvalidPoolConfiguration(t *testing.T) {
+	t.Setenv("FOOTBALL_POOL_ENV", "test")
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(cfg *config.Config)
+	}{
+		{"scoring rules", func(cfg *config.Config) { cfg.Pool.Scoring.Rules = "most_points" }},
+		{"tiebreakers", func(cfg *config.Config) { cfg.Pool.Tiebreakers = []string{"coin_toss"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				t.Fatalf("Failed to load configuration: %v", err)
+			}
+			tt.modify(cfg)
+			if _, err := NewServer(db, cfg); err == nil {
+				t.Error("Expected NewServer() to fail")
+			}
+		})
+	}
+}
+
+func TestClockEndpointsOnlyDuringE2ETests(t *testing.T) {
+	t.Setenv("FOOTBALL_POOL_ENV", "test")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Failed to load configuration: %v", err)
+	}
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	for _, e2e := range []bool{false, true} {
+		cfg.E2E.Test = e2e
+		server, err := NewServer(db, cfg)
+		if err != nil {
+			t.Fatalf("NewServer() error = %v", err)
+		}
+
+		_, adjustable := server.Clock().(*clock.Adjustable)
+		if adjustable != e2e {
+			t.Errorf("Expected an adjustable clock to be %v with E2E tests %v, got %v", e2e, e2e, adjustable)
+		}
+
+		// Unauthenticated requests reach the auth middleware only when the route exists
+		recorder := httptest.NewRecorder()
+		server.NewRouter().ServeHTTP(recorder, httptest.NewRequest("GET", "/api/admin/e2e/clock", nil))
+		want := http.StatusNotFound
+		if e2e {
+			want = http.StatusUnauthorized
+		}
+		if recorder.Code != want {
+			t.Errorf("Expected status %d with E2E tests %v, got %d", want, e2e, recorder.Code)
+		}
+	}
+}
+
+func TestAdjustableClockMovesWeekLifecycle(t *testing.T) {
+	t.Setenv("FOOTBALL_POOL_ENV", "test")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Failed to load configuration: %v", err)
+	}
+	cfg.ESPN.SeasonYear = 2025
+	cfg.E2E.Test = true
+	db, err := database.New("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	gormDB := db.GetDB()
+
+	user := database.User{Name: "Alice", Email: "alice@test.com", Password: "password", Role: "user"}
+	if err := gormDB.Create(&user).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	week := database.Week{WeekNumber: 1, Season: 2025, WeekStartTime: time.Date(2025, 9, 3, 8, 0, 0, 0, time.UTC), WeekEndTime: time.Date(2025, 9, 10, 7, 59, 0, 0, time.UTC)}
+	if err := gormDB.Create(&week).Error; err != nil {
+		t.Fatalf("Failed to create week: %v", err)
+	}
+	games := []database.Game{
+		{Week: 1, Season: 2025, SeasonType: database.SeasonTypeRegular, HomeTeam: "Philadelphia Eagles", AwayTeam: "Dallas Cowboys", Spread: 7, StartTime: time.Date(2025, 9, 5, 0, 20, 0, 0, time.UTC)},
+		{Week: 1, Season: 2025, SeasonType: database.SeasonTypeRegular, HomeTeam: "Buffalo Bills", AwayTeam: "Baltimore Ravens", Spread: 1, StartTime: time.Date(2025, 9, 8, 0, 20, 0, 0, time.UTC)},
+	}
+	if err := gormDB.Create(&games).Error; err != nil {
+		t.Fatalf("Failed to create games: %v", err)
+	}
+	picks := []database.Pick{
+		{UserID: user.ID, GameID: games[0].ID, Picked: scoring.SideFavorite, Rank: 2},
+		{UserID: user.ID, GameID: games[1].ID, Picked: scoring.SideUnderdog, Rank: 1},
+	}
+	if err := gormDB.Create(&picks).Error; err != nil {
+		t.Fatalf("Failed to create picks: %v", err)
+	}
+
+	server, err := NewServer(db, cfg)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	appClock, ok := server.Clock().(*clock.Adjustable)
+	if !ok {
+		t.Fatalf("Expected an adjustable clock, got %T", server.Clock())
+	}
+
+	// Moving the clock past the first kickoff locks the week and materializes its scores
+	appClock.Set(time.Date(2025, 9, 6, 0, 0, 0, 0, time.UTC))
+	if err := server.Lifecycle().Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertWeekStatus(t, db, database.WeekStatusLocked)
+	score := loadWeeklyScore(t, db, user.ID)
+	if score.Pending != 2 || score.Points != 0 {
+		t.Errorf("Expected 2 pending picks and no points once locked, got %d pending and %v points", score.Pending, score.Points)
+	}
+
+	// Once every game has kicked off and has a result, the week is final with its scores
+	results := []database.Result{
+		{GameID: games[0].ID, FavoriteScore: 24, UnderdogScore: 14, Outcome: scoring.SideFavorite},
+		{GameID: games[1].ID, FavoriteScore: 20, UnderdogScore: 27, Outcome: scoring.SideUnderdog},
+	}
+	if err := gormDB.Create(&results).Error; err != nil {
+		t.Fatalf("Failed to create results: %v", err)
+	}
+	appClock.Set(time.Date(2025, 9, 8, 4, 0, 0, 0, time.UTC))
+	if err := server.Lifecycle().Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertWeekStatus(t, db, database.WeekStatusFinal)
+	score = loadWeeklyScore(t, db, user.ID)
+	if score.Pending != 0 || score.Correct != 2 || score.Points != 3 {
+		t.Errorf("Expected 2 correct picks for 3 points once final, got %d correct, %d pending and %v points", score.Correct, score.Pending, score.Points)
+	}
+}
+
+// assertWeekStatus checks the status of week 1 of the 2025 season.
+func assertWeekStatus(t *testing.T, db *database.Database, want string) {
+	t.Helper()
+	var week database.Week
+	if err := db.GetDB().Where("season = ? AND week_number = ?", 2025, 1).First(&week).Error; err != nil {
+		t.Fatalf("Failed to load week: %v", err)
+	}
+	if week.Status != want {
+		t.Errorf("Expected week status %s, got %s", want, week.Status)
+	}
+}
+
+// loadWeeklyScore loads a player's score for week 1 of the 2025 season.
+func loadWeeklyScore(t *testing.T, db *database.Database, userID uint) database.WeeklyScore {
+	t.Helper()
+	var score database.WeeklyScore
+	if err := db.GetDB().Where("season = ? AND week = ? AND user_id = ?", 2025, 1, userID).First(&score).Error; err != nil {
+		t.Fatalf("Failed to load weekly score: %v", err)
+	}
+	return score
+}
@@ -0,0 +1,68 @@
+package upstream
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	b := newBreaker(2, time.Minute)
+
+	b.failure(now)
+	if !b.allow(now) || b.current() != StateClosed {
+		t.Fatalf("Expected breaker to stay closed after one failure, got %s", b.current())
+	}
+
+	// A success resets the consecutive failure count
+	b.success()
+	b.failure(now)
+	if b.current() != StateClosed {
+		t.Fatalf("Expected breaker to stay closed, got %s", b.current())
+	}
+
+	b.failure(now)
+	if b.allow(now.Add(30*time.Second)) || b.current() != StateOpen {
+		t.Fatalf("Expected breaker to open, got %s", b.current())
+	}
+
+	// Only one trial request is let through after the cooldown
+	if !b.allow(now.Add(time.Minute)) || b.current() != StateHalfOpen {
+		t.Fatalf("Expected a trial request, got %s", b.current())
+	}
+	if b.allow(now.Add(time.Minute)) {
+		t.Error("Expected concurrent requests to be rejected during the trial")
+	}
+
+	// A failed trial opens the breaker again
+	b.failure(now.Add(time.Minute))
+	if b.allow(now.Add(90*time.Second)) || b.current() != StateOpen {
+		t.Fatalf("Expected breaker to reopen, got %s", b.current())
+	}
+
+	// An abandoned trial lets another one through
+	if !b.allow(now.Add(2 * time.Minute)) {
+		t.Fatal("Expected a trial request")
+	}
+	b.abandon()
+	if !b.allow(now.Add(2 * time.Minute)) {
+		t.Fatal("Expected another trial request after the first was abandoned")
+	}
+
+	b.success()
+	if b.current() != StateClosed {
+		t.Errorf("Expected breaker to close, got %s", b.current())
+	}
+}
+
+func TestBreaker_Disabled(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	b := newBreaker(0, time.Minute)
+
+	for range 10 {
+		b.failure(now)
+	}
+	if !b.allow(now) || b.current() != StateClosed {
+		t.Errorf("Expected disabled breaker to stay closed, got %s", b.current())
+	}
+}
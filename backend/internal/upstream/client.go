@@ -0,0 +1,243 @@
+// Package upstream provides a resilient HTTP client for calling third-party APIs.
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+// ErrCircuitOpen is returned when a request is rejected because the upstream's circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Doer sends HTTP requests. It matches the HttpRequestDoer interface of the generated API clients.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client wraps an HTTP client with retries and a circuit breaker for a single upstream.
+// Transport errors, 5xx and 429 responses are retried with jittered exponential backoff,
+// honoring the Retry-After header. Client implements Doer, so it can be passed to the
+// generated clients with their WithHTTPClient option.
+type Client struct {
+	name         string
+	doer         Doer
+	maxRetries   int
+	baseDelay    time.Duration
+	maxDelay     time.Duration
+	breaker      *breaker
+	timeProvider clock.Clock
+
+	// sleep waits between attempts, returning early if the context is done
+	sleep func(ctx context.Context, d time.Duration) error
+
+	requests atomic.Int64
+	attempts atomic.Int64
+	retries  atomic.Int64
+	failures atomic.Int64
+	rejected atomic.Int64
+}
+
+// Stats is a snapshot of a client's circuit breaker state and request counters.
+type Stats struct {
+	Name  string
+	State string
+	// Requests counts calls to Do, Attempts every request sent including retries.
+	Requests int64
+	Attempts int64
+	Retries  int64
+	// Failures counts attempts that ended in a transport error, 5xx or 429 response.
+	Failures int64
+	// Rejected counts requests refused by the open circuit breaker.
+	Rejected int64
+}
+
+// NewClient creates a new Client for the named upstream using the configured timeout, retries and breaker.
+// When a fixture mode is configured, the upstream's responses are recorded to or replayed from
+// its subdirectory of the fixtures directory.
+func NewClient(name string, cfg *config.Config) (*Client, error) {
+	var doer Doer = &http.Client{Timeout: cfg.Upstream.Timeout}
+	if mode := cfg.Upstream.FixtureMode; mode != "" && mode != config.FixtureModeOff {
+		fixtures, err := NewFixtureDoer(filepath.Join(cfg.Upstream.FixturesDir, name), mode, doer)
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("Using upstream fixtures", "upstream", name, "mode", mode, "dir", fixtures.dir)
+		doer = fixtures
+	}
+	return NewClientWithDoer(name, cfg, doer, clock.Real{}), nil
+}
+
+// NewClientWithDoer creates a new Client that sends requests through doer with a custom time provider.
+// This is primarily for testing purposes.
+func NewClientWithDoer(name string, cfg *config.Config, doer Doer, timeProvider clock.Clock) *Client {
+	return &Client{
+		name:         name,
+		doer:         doer,
+		maxRetries:   cfg.Upstream.MaxRetries,
+		baseDelay:    cfg.Upstream.RetryBaseDelay,
+		maxDelay:     cfg.Upstream.RetryMaxDelay,
+		breaker:      newBreaker(cfg.Upstream.BreakerThreshold, cfg.Upstream.BreakerCooldown),
+		timeProvider: timeProvider,
+		sleep:        sleep,
+	}
+}
+
+// Do sends the request, retrying transient failures. If every attempt fails with a
+// retryable response, the last response is returned for the caller to handle.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	ctx := req.Context()
+
+	// Requests with a body can only be retried if the body can be recreated
+	maxRetries := c.maxRetries
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		if !c.breaker.allow(c.timeProvider.Now()) {
+			c.rejected.Add(1)
+			return nil, fmt.Errorf("%s: %w", c.name, ErrCircuitOpen)
+		}
+
+		attemptReq, err := c.prepare(req, attempt)
+		if err != nil {
+			c.breaker.abandon()
+			return nil, err
+		}
+
+		c.attempts.Add(1)
+		resp, err := c.doer.Do(attemptReq)
+
+		switch {
+		case err != nil && ctx.Err() != nil:
+			// The caller gave up, which says nothing about the upstream
+			c.breaker.abandon()
+			return nil, err
+		case err == nil && !retryable(resp.StatusCode):
+			c.breaker.success()
+			return resp, nil
+		}
+
+		c.failures.Add(1)
+		c.breaker.failure(c.timeProvider.Now())
+
+		if attempt >= maxRetries {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt)
+		if err == nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), c.timeProvider.Now()); ok {
+				if c.maxDelay > 0 && retryAfter > c.maxDelay {
+					slog.Warn("Upstream asked to retry later than allowed, giving up", "upstream", c.name, "retry_after", retryAfter)
+					return resp, nil
+				}
+				delay = retryAfter
+			}
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			slog.Warn("Upstream request failed, retrying", "upstream", c.name, "status", resp.StatusCode, "attempt", attempt+1, "delay", delay)
+		} else {
+			slog.Warn("Upstream request failed, retrying", "upstream", c.name, "error", err, "attempt", attempt+1, "delay", delay)
+		}
+
+		c.retries.Add(1)
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Stats returns a snapshot of the client's breaker state and counters.
+func (c *Client) Stats() Stats {
+	return Stats{
+		Name:     c.name,
+		State:    c.breaker.current(),
+		Requests: c.requests.Load(),
+		Attempts: c.attempts.Load(),
+		Retries:  c.retries.Load(),
+		Failures: c.failures.Load(),
+		Rejected: c.rejected.Load(),
+	}
+}
+
+// prepare returns the request to send for an attempt, recreating the body for retries.
+func (c *Client) prepare(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 {
+		return req, nil
+	}
+
+	attemptReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to recreate request body: %w", err)
+		}
+		attemptReq.Body = body
+	}
+	return attemptReq, nil
+}
+
+// backoff returns the jittered delay before the retry following the given attempt.
+// The delay doubles with every attempt up to the maximum, and is then drawn at random
+// from its upper half so that clients retrying together spread out.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.baseDelay << attempt
+	if delay <= 0 || (c.maxDelay > 0 && delay > c.maxDelay) {
+		delay = c.maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// retryable reports whether a response status indicates a transient upstream failure.
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for the duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,308 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// respond returns a response with the given status and headers.
+func respond(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("{}")),
+	}
+	for key, value := range headers {
+		resp.Header.Set(key, value)
+	}
+	return resp
+}
+
+// setupClient creates a client sending requests through doer, recording the delays it sleeps for.
+func setupClient(doer Doer) (*Client, *clock.Adjustable, *[]time.Duration) {
+	cfg := &config.Config{}
+	cfg.Upstream.MaxRetries = 3
+	cfg.Upstream.RetryBaseDelay = 100 * time.Millisecond
+	cfg.Upstream.RetryMaxDelay = 10 * time.Second
+	cfg.Upstream.BreakerThreshold = 5
+	cfg.Upstream.BreakerCooldown = time.Minute
+
+	breakerClock := clock.NewAdjustable()
+	breakerClock.Set(time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC))
+	client := NewClientWithDoer("espn", cfg, doer, breakerClock)
+
+	var delays []time.Duration
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		breakerClock.Advance(d)
+		return nil
+	}
+	return client, breakerClock, &delays
+}
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	var calls int
+	client, _, delays := setupClient(DoerFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		switch calls {
+		case 1:
+			return nil, errors.New("connection reset")
+		case 2:
+			return respond(http.StatusServiceUnavailable, nil), nil
+		default:
+			return respond(http.StatusOK, nil), nil
+		}
+	}))
+
+	resp, err := client.Do(httptest.NewRequest("GET", "http://espn.test/scoreboard", nil))
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	// Delays double from the base delay and are jittered within their upper half
+	if len(*delays) != 2 {
+		t.Fatalf("Expected 2 delays, got %v", *delays)
+	}
+	for i, delay := range *delays {
+		upper := 100 * time.Millisecond << i
+		if delay < upper/2 || delay > upper {
+			t.Errorf("Delay %d = %v, want between %v and %v", i, delay, upper/2, upper)
+		}
+	}
+
+	stats := client.Stats()
+	if stats.Requests != 1 || stats.Attempts != 3 || stats.Retries != 2 || stats.Failures != 2 || stats.State != StateClosed {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls int
+	client, _, _ := setupClient(DoerFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return respond(http.StatusBadGateway, nil), nil
+	}))
+
+	resp, err := client.Do(httptest.NewRequest("GET", "http://espn.test/scoreboard", nil))
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	// The last response is handed back to the caller
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected status %d, got %d", http.StatusBadGateway, resp.StatusCode)
+	}
+	if calls != 4 {
+		t.Errorf("Expected 4 attempts, got %d", calls)
+	}
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int
+	client, _, _ := setupClient(DoerFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return respond(http.StatusNotFound, nil), nil
+	}))
+
+	resp, err := client.Do(httptest.NewRequest("GET", "http://espn.test/scoreboard", nil))
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound || calls != 1 {
+		t.Errorf("Expected a single 404, got status %d after %d attempts", resp.StatusCode, calls)
+	}
+}
+
+func TestClient_HonorsRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter func(now time.Time) string
+		expected   time.Duration
+	}{
+		{name: "seconds", retryAfter: func(time.Time) string { return "3" }, expected: 3 * time.Second},
+		{name: "http date", retryAfter: func(now time.Time) string { return now.Add(5 * time.Second).Format(http.TimeFormat) }, expected: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			var breakerClock *clock.Adjustable
+			client, breakerClock, delays := setupClient(DoerFunc(func(*http.Request) (*http.Response, error) {
+				calls++
+				if calls == 1 {
+					return respond(http.StatusTooManyRequests, map[string]string{"Retry-After": tt.retryAfter(breakerClock.Now())}), nil
+				}
+				return respond(http.StatusOK, nil), nil
+			}))
+
+			if _, err := client.Do(httptest.NewRequest("GET", "http://espn.test/scoreboard", nil)); err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			// The clock keeps running while an HTTP date only has whole seconds
+			if len(*delays) != 1 || (*delays)[0] > tt.expected || (*delays)[0] <= tt.expected-time.Second {
+				t.Errorf("Expected delay %v, got %v", tt.expected, *delays)
+			}
+		})
+	}
+}
+
+func TestClient_RetryAfterBeyondMaxDelay(t *testing.T) {
+	var calls int
+	client, _, delays := setupClient(DoerFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return respond(http.StatusTooManyRequests, map[string]string{"Retry-After": "3600"}), nil
+	}))
+
+	resp, err := client.Do(httptest.NewRequest("GET", "http://espn.test/scoreboard", nil))
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || calls != 1 || len(*delays) != 0 {
+		t.Errorf("Expected to give up immediately, got status %d after %d attempts", resp.StatusCode, calls)
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	healthy := false
+	var calls int
+	client, breakerClock, _ := setupClient(DoerFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		if healthy {
+			return respond(http.StatusOK, nil), nil
+		}
+		return respond(http.StatusInternalServerError, nil), nil
+	}))
+
+	// The fifth consecutive failure opens the breaker mid-request
+	_, err := client.Do(httptest.NewRequest("GET", "http://espn.test/scoreboard", nil))
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_, err = client.Do(httptest.NewRequest("GET", "http://espn.test/scoreboard", nil))
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Do() error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if calls != 5 {
+		t.Errorf("Expected 5 attempts before opening, got %d", calls)
+	}
+
+	// Requests are rejected without reaching the upstream while open
+	if _, err := client.Do(httptest.NewRequest("GET", "http://espn.test/scoreboard", nil)); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Do() error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if calls != 5 {
+		t.Errorf("Expected no attempts while open, got %d", calls)
+	}
+
+	// After the cooldown a trial request closes the breaker again
+	healthy = true
+	breakerClock.Advance(time.Minute)
+	resp, err := client.Do(httptest.NewRequest("GET", "http://espn.test/scoreboard", nil))
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	stats := client.Stats()
+	if stats.State != StateClosed || stats.Rejected != 2 || stats.Failures != 5 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestClient_RetriesRequestBody(t *testing.T) {
+	var bodies []string
+	client, _, _ := setupClient(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return respond(http.StatusServiceUnavailable, nil), nil
+		}
+		return respond(http.StatusOK, nil), nil
+	}))
+
+	req, err := http.NewRequest("POST", "http://espn.test/scoreboard", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if _, err := client.Do(req); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if len(bodies) != 2 || bodies[1] != "payload" {
+		t.Errorf("Expected the body to be resent, got %q", bodies)
+	}
+}
+
+func TestClient_Timeout(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	cfg := &config.Config{}
+	cfg.Upstream.Timeout = 20 * time.Millisecond
+	client, err := NewClient("espn", cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	req, err := http.NewRequest("GET", upstream.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if _, err := client.Do(req); err == nil {
+		t.Error("Do() expected timeout error")
+	}
+	if stats := client.Stats(); stats.Failures != 1 {
+		t.Errorf("Expected the timeout to count as a failure, got %+v", stats)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{value: "", ok: false},
+		{value: "120", expected: 2 * time.Minute, ok: true},
+		{value: "-1", ok: false},
+		{value: "Mon, 01 Sep 2025 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{value: "Mon, 01 Sep 2025 11:00:00 GMT", expected: 0, ok: true},
+		{value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			delay, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.ok || delay != tt.expected {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, delay, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package upstream
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+// ErrFixtureNotFound is returned in replay mode when no fixture was recorded for a request.
+var ErrFixtureNotFound = errors.New("fixture not found")
+
+// redactedParams are query parameters that are never written to fixtures, so that API keys
+// stay out of the repository. They are also left out when matching requests to fixtures.
+var redactedParams = []string{"apiKey"}
+
+// Fixture is a recorded upstream response.
+type Fixture struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	// Body holds JSON responses as they were received, and Text any other body
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+// FixtureDoer records the responses of an upstream to fixture files, or replays them
+// without using the network. Each request is stored in a file named after its method,
+// path and query, so replaying the same requests returns the same responses.
+type FixtureDoer struct {
+	dir  string
+	mode string
+	doer Doer
+}
+
+// NewFixtureDoer creates a FixtureDoer for the fixtures in dir. In record mode requests are
+// sent through doer, which is not used in replay mode.
+func NewFixtureDoer(dir, mode string, doer Doer) (*FixtureDoer, error) {
+	if mode != config.FixtureModeRecord && mode != config.FixtureModeReplay {
+		return nil, fmt.Errorf("unknown fixture mode %q", mode)
+	}
+	return &FixtureDoer{dir: dir, mode: mode, doer: doer}, nil
+}
+
+// Do replays the fixture of the request, or sends it and records the response.
+func (f *FixtureDoer) Do(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(f.dir, FixtureName(req))
+	if f.mode == config.FixtureModeReplay {
+		return f.replay(req, path)
+	}
+	return f.record(req, path)
+}
+
+// replay reads the fixture at path and returns its response.
+func (f *FixtureDoer) replay(req *http.Request, path string) (*http.Response, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s %s: %w", req.Method, redactURL(req.URL), ErrFixtureNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+
+	body := fixture.Text
+	if len(fixture.Body) > 0 {
+		body = string(fixture.Body)
+	}
+	header := fixture.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	slog.Debug("Replaying fixture", "method", req.Method, "url", redactURL(req.URL), "path", path)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Status, http.StatusText(fixture.Status)),
+		StatusCode:    fixture.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// record sends the request and saves its response to path. Conditional headers are removed
+// so that complete responses are recorded, and transient failures are not recorded.
+func (f *FixtureDoer) record(req *http.Request, path string) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Del("If-None-Match")
+	req.Header.Del("If-Modified-Since")
+
+	resp, err := f.doer.Do(req)
+	if err != nil || retryable(resp.StatusCode) {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	fixture := Fixture{
+		Method: req.Method,
+		URL:    redactURL(req.URL),
+		Status: resp.StatusCode,
+		Header: recordedHeader(resp.Header),
+	}
+	if json.Valid(data) {
+		fixture.Body = data
+	} else {
+		fixture.Text = string(data)
+	}
+
+	if err := writeFixture(path, fixture); err != nil {
+		slog.Warn("Failed to record fixture", "url", fixture.URL, "path", path, "error", err)
+	} else {
+		slog.Info("Recorded fixture", "method", req.Method, "url", fixture.URL, "path", path)
+	}
+	return resp, nil
+}
+
+// writeFixture writes a fixture as indented JSON, creating its directory if needed.
+func writeFixture(path string, fixture Fixture) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// recordedHeader returns the response headers worth keeping in a fixture. Headers that
+// describe the connection or the transfer are dropped, as they do not apply to replays.
+func recordedHeader(header http.Header) http.Header {
+	recorded := make(http.Header)
+	for key, values := range header {
+		switch http.CanonicalHeaderKey(key) {
+		case "Connection", "Content-Length", "Content-Encoding", "Date", "Set-Cookie", "Transfer-Encoding":
+			continue
+		}
+		recorded[key] = values
+	}
+	return recorded
+}
+
+// FixtureName returns the file name of the fixture of a request: the last segment of its
+// path followed by a hash of its method, path and query.
+func FixtureName(req *http.Request) string {
+	base := path.Base(req.URL.Path)
+	if base == "/" || base == "." {
+		base = "root"
+	}
+	hash := sha256.Sum256([]byte(req.Method + " " + req.URL.Path + "?" + redactQuery(req.URL.Query()).Encode()))
+	return fmt.Sprintf("%s-%x.json", base, hash[:8])
+}
+
+// redactURL returns the URL without its redacted query parameters.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = redactQuery(u.Query()).Encode()
+	return redacted.String()
+}
+
+// redactQuery removes the redacted parameters from a query.
+func redactQuery(query url.Values) url.Values {
+	for _, param := range redactedParams {
+		query.Del(param)
+	}
+	return query
+}
@@ -0,0 +1,128 @@
+package upstream
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dhpollack/football-pool/internal/config"
+)
+
+func TestFixtureDoer_RecordAndReplay(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if r.URL.Query().Get("week") == "99" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Requests-Remaining", "480")
+		_, _ = w.Write([]byte(`{"week": "` + r.URL.Query().Get("week") + `"}`))
+	}))
+
+	dir := t.TempDir()
+	recorder, err := NewFixtureDoer(dir, config.FixtureModeRecord, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("NewFixtureDoer() error = %v", err)
+	}
+
+	get := func(doer Doer, query string) (*http.Response, error) {
+		req, err := http.NewRequest("GET", server.URL+"/v4/sports/nfl/odds?"+query, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("If-None-Match", `"v1"`)
+		return doer.Do(req)
+	}
+
+	resp, err := get(recorder, "week=1&apiKey=secret")
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != `{"week": "1"}` {
+		t.Errorf("Recorded response body = %s", body)
+	}
+	if requests[0].Header.Get("If-None-Match") != "" {
+		t.Error("Expected conditional headers to be removed while recording")
+	}
+
+	// Transient failures are passed through without being recorded
+	if resp, err := get(recorder, "week=99"); err != nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Do() = %v, %v", resp, err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Expected 1 fixture, got %v (%v)", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("Expected the API key to be redacted, got %s", data)
+	}
+	if !strings.HasPrefix(filepath.Base(files[0]), "odds-") {
+		t.Errorf("Unexpected fixture name %s", files[0])
+	}
+
+	// Replays do not touch the network, and ignore the API key
+	server.Close()
+	replayer, err := NewFixtureDoer(dir, config.FixtureModeReplay, nil)
+	if err != nil {
+		t.Fatalf("NewFixtureDoer() error = %v", err)
+	}
+	resp, err = get(replayer, "apiKey=other&week=1")
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"week": "1"`) {
+		t.Errorf("Replayed response = %d %s", resp.StatusCode, body)
+	}
+	if resp.Header.Get("X-Requests-Remaining") != "480" || resp.Header.Get("Date") != "" {
+		t.Errorf("Unexpected replayed headers: %v", resp.Header)
+	}
+
+	if _, err := get(replayer, "week=2"); !errors.Is(err, ErrFixtureNotFound) {
+		t.Errorf("Do() error = %v, want %v", err, ErrFixtureNotFound)
+	}
+}
+
+func TestNewClient_Fixtures(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Upstream.FixturesDir = t.TempDir()
+
+	cfg.Upstream.FixtureMode = "rewind"
+	if _, err := NewClient("espn", cfg); err == nil {
+		t.Error("NewClient() expected error for unknown fixture mode")
+	}
+
+	fixture := Fixture{Method: "GET", URL: "https://espn.test/scoreboard", Status: http.StatusOK, Text: "ok"}
+	req, err := http.NewRequest("GET", fixture.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if err := writeFixture(filepath.Join(cfg.Upstream.FixturesDir, "espn", FixtureName(req)), fixture); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	cfg.Upstream.FixtureMode = config.FixtureModeReplay
+	client, err := NewClient("espn", cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Errorf("Replayed response body = %s", body)
+	}
+}
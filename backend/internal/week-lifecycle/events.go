@@ -0,0 +1,55 @@
+package weeklifecycle
+
+import (
+	"context"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/database"
+)
+
+// EventType identifies a lifecycle event.
+type EventType string
+
+// Lifecycle events emitted by the service.
+const (
+	// EventWeekOpened is emitted when a week opens for picks.
+	EventWeekOpened EventType = "week.opened"
+	// EventWeekLocked is emitted when the first game of a week kicks off and pick sheets lock.
+	EventWeekLocked EventType = "week.locked"
+	// EventWeekScoring is emitted when every game of a week has kicked off.
+	EventWeekScoring EventType = "week.scoring"
+	// EventWeekFinal is emitted when every game of a week has a result.
+	EventWeekFinal EventType = "week.final"
+	// EventWeekActivated is emitted when a week becomes the active week.
+	EventWeekActivated EventType = "week.activated"
+)
+
+// Event describes a change to a week.
+type Event struct {
+	Type           EventType
+	WeekID         uint
+	Season         int
+	Week           int
+	Status         string
+	PreviousStatus string
+	Time           time.Time
+}
+
+// Handler reacts to lifecycle events.
+// Handlers are called synchronously, so long running work should be started in a goroutine.
+type Handler func(ctx context.Context, event Event)
+
+// eventForStatus returns the event emitted when a week enters the given status.
+func eventForStatus(status string) (EventType, bool) {
+	switch status {
+	case database.WeekStatusOpen:
+		return EventWeekOpened, true
+	case database.WeekStatusLocked:
+		return EventWeekLocked, true
+	case database.WeekStatusScoring:
+		return EventWeekScoring, true
+	case database.WeekStatusFinal:
+		return EventWeekFinal, true
+	}
+	return "", false
+}
@@ -0,0 +1,271 @@
+// Package weeklifecycle moves pool weeks through their lifecycle and notifies the rest of the application.
+package weeklifecycle
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/jobs"
+)
+
+// TimeProvider is the application clock. It is injected so that tests and E2E runs can
+// control the time.
+type TimeProvider = clock.Clock
+
+// Service transitions weeks through upcoming, open, locked, scoring and final
+// based on the week boundaries, game kickoffs and submitted results.
+type Service struct {
+	db           *database.Database
+	config       *config.Config
+	timeProvider TimeProvider
+
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+// NewService creates a new Service instance.
+func NewService(db *database.Database, config *config.Config) *Service {
+	return NewServiceWithTimeProvider(db, config, clock.Real{})
+}
+
+// NewServiceWithTimeProvider creates a new Service instance with a custom time provider.
+// This is primarily for testing purposes.
+func NewServiceWithTimeProvider(db *database.Database, config *config.Config, timeProvider TimeProvider) *Service {
+	return &Service{
+		db:           db,
+		config:       config,
+		timeProvider: timeProvider,
+	}
+}
+
+// Subscribe registers a handler that is called for every lifecycle event.
+func (s *Service) Subscribe(handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+// JobAdvance is the name of the background job that advances the week lifecycle.
+const JobAdvance = "week-lifecycle"
+
+// RegisterJobs registers the lifecycle job with the scheduler.
+func (s *Service) RegisterJobs(scheduler *jobs.Scheduler) error {
+	if !s.config.Lifecycle.Enabled {
+		slog.Info("Week lifecycle service is disabled")
+		return nil
+	}
+
+	return scheduler.Register(jobs.Job{
+		Name:        JobAdvance,
+		Description: "Move weeks through their lifecycle and activate the current week",
+		Schedule:    "@every " + s.config.Lifecycle.Interval.String(),
+		RunOnStart:  true,
+		Run:         s.Advance,
+	})
+}
+
+// Advance updates the status of every week in the configured season and activates
+// the week that most recently opened for picks. If no week is active, the earliest
+// week that is in progress is activated instead.
+func (s *Service) Advance(ctx context.Context) error {
+	now := s.timeProvider.Now()
+	season, err := database.CurrentSeason(s.db.GetDB(), s.config.ESPN.SeasonYear)
+	if err != nil {
+		return fmt.Errorf("failed to load current season: %w", err)
+	}
+
+	var weeks []database.Week
+	if err := s.db.GetDB().Where("season = ?", season).Order("week_number ASC").Find(&weeks).Error; err != nil {
+		return fmt.Errorf("failed to load weeks: %w", err)
+	}
+
+	hasActive := false
+	var activate *database.Week
+	for i := range weeks {
+		week := &weeks[i]
+		if week.IsActive {
+			hasActive = true
+		}
+
+		status, err := s.weekStatus(week, now)
+		if err != nil {
+			return err
+		}
+		if status == week.Status {
+			continue
+		}
+
+		if err := s.db.GetDB().Model(week).Update("status", status).Error; err != nil {
+			return fmt.Errorf("failed to update status of week %d: %w", week.WeekNumber, err)
+		}
+
+		previous := week.Status
+		week.Status = status
+		slog.Info("Week status changed", "season", season, "week", week.WeekNumber, "from", previous, "to", status)
+
+		if eventType, ok := eventForStatus(status); ok {
+			s.emit(ctx, Event{
+				Type:           eventType,
+				WeekID:         week.ID,
+				Season:         week.Season,
+				Week:           week.WeekNumber,
+				Status:         status,
+				PreviousStatus: previous,
+				Time:           now,
+			})
+		}
+
+		if status == database.WeekStatusOpen && !week.IsActive {
+			activate = week
+		}
+	}
+
+	if err := s.startSeason(season, weeks); err != nil {
+		return err
+	}
+
+	if activate == nil && !hasActive {
+		for i := range weeks {
+			if inProgress(weeks[i].Status) {
+				activate = &weeks[i]
+				break
+			}
+		}
+	}
+
+	if activate != nil {
+		return s.activateWeek(ctx, activate, now)
+	}
+	return nil
+}
+
+// startSeason marks an upcoming season active once any of its weeks has opened.
+func (s *Service) startSeason(season int, weeks []database.Week) error {
+	started := slices.ContainsFunc(weeks, func(week database.Week) bool {
+		return week.Status != database.WeekStatusUpcoming
+	})
+	if !started {
+		return nil
+	}
+
+	result := s.db.GetDB().Model(&database.Season{}).
+		Where("year = ? AND state = ?", season, database.SeasonStateUpcoming).
+		Update("state", database.SeasonStateActive)
+	if result.Error != nil {
+		return fmt.Errorf("failed to start season %d: %w", season, result.Error)
+	}
+	if result.RowsAffected > 0 {
+		slog.Info("Season started", "season", season)
+	}
+	return nil
+}
+
+// activateWeek makes the given week the only active week of its season. Weeks of other
+// seasons are left alone.
+func (s *Service) activateWeek(ctx context.Context, week *database.Week, now time.Time) error {
+	tx := s.db.GetDB().Begin()
+	if err := tx.Model(&database.Week{}).Where("season = ? AND is_active = ?", week.Season, true).Update("is_active", false).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to deactivate weeks: %w", err)
+	}
+	if err := tx.Model(week).Update("is_active", true).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to activate week %d: %w", week.WeekNumber, err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to activate week %d: %w", week.WeekNumber, err)
+	}
+
+	week.IsActive = true
+	slog.Info("Activated week", "season", week.Season, "week", week.WeekNumber)
+
+	s.emit(ctx, Event{
+		Type:   EventWeekActivated,
+		WeekID: week.ID,
+		Season: week.Season,
+		Week:   week.WeekNumber,
+		Status: week.Status,
+		Time:   now,
+	})
+	return nil
+}
+
+// weekStatus loads the games and results of a week and works out its status.
+func (s *Service) weekStatus(week *database.Week, now time.Time) (string, error) {
+	var games []database.Game
+	if err := s.db.GetDB().Where("season = ? AND week = ?", week.Season, week.WeekNumber).Find(&games).Error; err != nil {
+		return "", fmt.Errorf("failed to load games for week %d: %w", week.WeekNumber, err)
+	}
+
+	var results int64
+	if len(games) > 0 {
+		gameIDs := make([]uint, len(games))
+		for i, game := range games {
+			gameIDs[i] = game.ID
+		}
+		if err := s.db.GetDB().Model(&database.Result{}).Where("game_id IN ?", gameIDs).Count(&results).Error; err != nil {
+			return "", fmt.Errorf("failed to count results for week %d: %w", week.WeekNumber, err)
+		}
+	}
+
+	return statusAt(week, games, int(results), now), nil
+}
+
+// statusAt returns the status of a week at the given time.
+// Weeks without games follow the week boundaries alone.
+func statusAt(week *database.Week, games []database.Game, results int, now time.Time) string {
+	if now.Before(week.WeekStartTime) {
+		return database.WeekStatusUpcoming
+	}
+
+	if len(games) == 0 {
+		if now.Before(week.WeekEndTime) {
+			return database.WeekStatusOpen
+		}
+		return database.WeekStatusFinal
+	}
+
+	firstKickoff, lastKickoff := games[0].StartTime, games[0].StartTime
+	for _, game := range games[1:] {
+		if game.StartTime.Before(firstKickoff) {
+			firstKickoff = game.StartTime
+		}
+		if game.StartTime.After(lastKickoff) {
+			lastKickoff = game.StartTime
+		}
+	}
+
+	switch {
+	case now.Before(firstKickoff):
+		return database.WeekStatusOpen
+	case now.Before(lastKickoff):
+		return database.WeekStatusLocked
+	case results < len(games):
+		return database.WeekStatusScoring
+	default:
+		return database.WeekStatusFinal
+	}
+}
+
+// inProgress reports whether a week has opened but is not yet final.
+func inProgress(status string) bool {
+	return status == database.WeekStatusOpen || status == database.WeekStatusLocked || status == database.WeekStatusScoring
+}
+
+// emit calls every subscribed handler with the event.
+func (s *Service) emit(ctx context.Context, event Event) {
+	s.mu.RLock()
+	handlers := append([]Handler(nil), s.handlers...)
+	s.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+}
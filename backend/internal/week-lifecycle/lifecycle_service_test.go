@@ -0,0 +1,273 @@
+package weeklifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dhpollack/football-pool/internal/clock"
+	"github.com/dhpollack/football-pool/internal/config"
+	"github.com/dhpollack/football-pool/internal/database"
+	"github.com/dhpollack/football-pool/internal/jobs"
+)
+
+// setupLifecycleTest creates a database with two weeks of games and a service using a mock clock.
+func setupLifecycleTest(t *testing.T) (*database.Database, *Service, *clock.Adjustable) {
+	db, err := database.New("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.ESPN.SeasonYear = 2025
+	cfg.Lifecycle.Enabled = true
+
+	weeks := []database.Week{
+		{WeekNumber: 1, Season: 2025, WeekStartTime: time.Date(2025, 9, 3, 8, 0, 0, 0, time.UTC), WeekEndTime: time.Date(2025, 9, 10, 7, 59, 0, 0, time.UTC)},
+		{WeekNumber: 2, Season: 2025, WeekStartTime: time.Date(2025, 9, 10, 8, 0, 0, 0, time.UTC), WeekEndTime: time.Date(2025, 9, 17, 7, 59, 0, 0, time.UTC)},
+	}
+	if err := db.GetDB().Create(&weeks).Error; err != nil {
+		t.Fatalf("Failed to create weeks: %v", err)
+	}
+
+	games := []database.Game{
+		{Week: 1, Season: 2025, HomeTeam: "Eagles", AwayTeam: "Cowboys", StartTime: time.Date(2025, 9, 5, 0, 20, 0, 0, time.UTC)},
+		{Week: 1, Season: 2025, HomeTeam: "Bills", AwayTeam: "Ravens", StartTime: time.Date(2025, 9, 8, 0, 20, 0, 0, time.UTC)},
+		{Week: 2, Season: 2025, HomeTeam: "Packers", AwayTeam: "Commanders", StartTime: time.Date(2025, 9, 12, 0, 15, 0, 0, time.UTC)},
+	}
+	if err := db.GetDB().Create(&games).Error; err != nil {
+		t.Fatalf("Failed to create games: %v", err)
+	}
+
+	appClock := clock.NewAdjustable()
+	return db, NewServiceWithTimeProvider(db, cfg, appClock), appClock
+}
+
+func TestStatusAt(t *testing.T) {
+	week := &database.Week{
+		WeekStartTime: time.Date(2025, 9, 3, 8, 0, 0, 0, time.UTC),
+		WeekEndTime:   time.Date(2025, 9, 10, 7, 59, 0, 0, time.UTC),
+	}
+	games := []database.Game{
+		{StartTime: time.Date(2025, 9, 8, 0, 20, 0, 0, time.UTC)},
+		{StartTime: time.Date(2025, 9, 5, 0, 20, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name     string
+		games    []database.Game
+		results  int
+		now      time.Time
+		expected string
+	}{
+		{name: "before week start", games: games, now: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), expected: database.WeekStatusUpcoming},
+		{name: "before first kickoff", games: games, now: time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC), expected: database.WeekStatusOpen},
+		{name: "after first kickoff", games: games, now: time.Date(2025, 9, 6, 0, 0, 0, 0, time.UTC), expected: database.WeekStatusLocked},
+		{name: "all games kicked off", games: games, results: 1, now: time.Date(2025, 9, 8, 1, 0, 0, 0, time.UTC), expected: database.WeekStatusScoring},
+		{name: "all results in", games: games, results: 2, now: time.Date(2025, 9, 8, 4, 0, 0, 0, time.UTC), expected: database.WeekStatusFinal},
+		{name: "no games during week", now: time.Date(2025, 9, 6, 0, 0, 0, 0, time.UTC), expected: database.WeekStatusOpen},
+		{name: "no games after week", now: time.Date(2025, 9, 11, 0, 0, 0, 0, time.UTC), expected: database.WeekStatusFinal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := statusAt(week, tt.games, tt.results, tt.now); status != tt.expected {
+				t.Errorf("statusAt() = %s, want %s", status, tt.expected)
+			}
+		})
+	}
+}
+
+func TestService_Advance(t *testing.T) {
+	db, service, clock := setupLifecycleTest(t)
+
+	var events []Event
+	service.Subscribe(func(_ context.Context, event Event) {
+		events = append(events, event)
+	})
+
+	// Week 1 opens for picks and is activated
+	clock.Set(time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC))
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertWeek(t, db, 1, database.WeekStatusOpen, true)
+	assertWeek(t, db, 2, database.WeekStatusUpcoming, false)
+	assertEvents(t, events, EventWeekOpened, EventWeekActivated)
+
+	// Advancing again without a change emits nothing
+	events = nil
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertEvents(t, events)
+
+	// The first kickoff locks the week
+	clock.Set(time.Date(2025, 9, 6, 0, 0, 0, 0, time.UTC))
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertWeek(t, db, 1, database.WeekStatusLocked, true)
+	assertEvents(t, events, EventWeekLocked)
+
+	// Week 2 opens and becomes active while week 1 waits for results
+	events = nil
+	clock.Set(time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC))
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertWeek(t, db, 1, database.WeekStatusScoring, false)
+	assertWeek(t, db, 2, database.WeekStatusOpen, true)
+	assertEvents(t, events, EventWeekScoring, EventWeekOpened, EventWeekActivated)
+
+	// Week 1 is final once every game has a result
+	var games []database.Game
+	if err := db.GetDB().Where("week = ?", 1).Find(&games).Error; err != nil {
+		t.Fatalf("Failed to load games: %v", err)
+	}
+	for _, game := range games {
+		if err := db.GetDB().Create(&database.Result{GameID: game.ID, Outcome: "Favorite"}).Error; err != nil {
+			t.Fatalf("Failed to create result: %v", err)
+		}
+	}
+
+	events = nil
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertWeek(t, db, 1, database.WeekStatusFinal, false)
+	assertEvents(t, events, EventWeekFinal)
+}
+
+func TestService_AdvanceActivatesInProgressWeek(t *testing.T) {
+	db, service, clock := setupLifecycleTest(t)
+
+	// Starting up mid-week activates the locked week when no week is active
+	clock.Set(time.Date(2025, 9, 6, 0, 0, 0, 0, time.UTC))
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertWeek(t, db, 1, database.WeekStatusLocked, true)
+}
+
+func TestService_AdvanceKeepsManualActivation(t *testing.T) {
+	db, service, clock := setupLifecycleTest(t)
+
+	clock.Set(time.Date(2025, 9, 6, 0, 0, 0, 0, time.UTC))
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+
+	// An admin activates week 2 ahead of time
+	if err := db.GetDB().Model(&database.Week{}).Where("week_number = ?", 1).Update("is_active", false).Error; err != nil {
+		t.Fatalf("Failed to deactivate week: %v", err)
+	}
+	if err := db.GetDB().Model(&database.Week{}).Where("week_number = ?", 2).Update("is_active", true).Error; err != nil {
+		t.Fatalf("Failed to activate week: %v", err)
+	}
+
+	clock.Set(time.Date(2025, 9, 7, 0, 0, 0, 0, time.UTC))
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertWeek(t, db, 1, database.WeekStatusLocked, false)
+	assertWeek(t, db, 2, database.WeekStatusUpcoming, true)
+}
+
+func TestService_AdvanceKeepsOtherSeasons(t *testing.T) {
+	db, service, clock := setupLifecycleTest(t)
+
+	// The last week of the previous season stays active in its own season
+	previous := database.Week{WeekNumber: 18, Season: 2024, WeekStartTime: time.Date(2024, 12, 31, 8, 0, 0, 0, time.UTC), WeekEndTime: time.Date(2025, 1, 7, 7, 59, 0, 0, time.UTC), IsActive: true}
+	if err := db.GetDB().Create(&previous).Error; err != nil {
+		t.Fatalf("Failed to create week: %v", err)
+	}
+
+	clock.Set(time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC))
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertWeek(t, db, 1, database.WeekStatusOpen, true)
+
+	if err := db.GetDB().First(&previous, previous.ID).Error; err != nil {
+		t.Fatalf("Failed to load week: %v", err)
+	}
+	if !previous.IsActive {
+		t.Error("Expected the week of the previous season to stay active")
+	}
+}
+
+func TestService_AdvanceStartsSeason(t *testing.T) {
+	db, service, clock := setupLifecycleTest(t)
+	if _, err := db.EnsureSeason(2025); err != nil {
+		t.Fatalf("Failed to create season: %v", err)
+	}
+
+	// The season stays upcoming until a week opens
+	clock.Set(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC))
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertSeason(t, db, database.SeasonStateUpcoming)
+
+	clock.Set(time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC))
+	if err := service.Advance(context.Background()); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	assertSeason(t, db, database.SeasonStateActive)
+}
+
+func TestService_RegisterJobs(t *testing.T) {
+	db, service, _ := setupLifecycleTest(t)
+	service.config.Lifecycle.Interval = time.Minute
+
+	scheduler := jobs.NewScheduler(db, service.config)
+	if err := service.RegisterJobs(scheduler); err != nil {
+		t.Fatalf("RegisterJobs() error = %v", err)
+	}
+
+	registered, err := scheduler.Jobs()
+	if err != nil {
+		t.Fatalf("Jobs() error = %v", err)
+	}
+	if len(registered) != 1 || registered[0].Name != JobAdvance || registered[0].Schedule != "@every 1m0s" {
+		t.Errorf("Unexpected jobs: %+v", registered)
+	}
+}
+
+func assertWeek(t *testing.T, db *database.Database, weekNumber int, status string, active bool) {
+	t.Helper()
+	week, err := db.GetWeek(2025, weekNumber)
+	if err != nil {
+		t.Fatalf("Failed to load week %d: %v", weekNumber, err)
+	}
+	if week.Status != status {
+		t.Errorf("Week %d status = %s, want %s", weekNumber, week.Status, status)
+	}
+	if week.IsActive != active {
+		t.Errorf("Week %d active = %v, want %v", weekNumber, week.IsActive, active)
+	}
+}
+
+func assertEvents(t *testing.T, events []Event, expected ...EventType) {
+	t.Helper()
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(expected), len(events), events)
+	}
+	for i, event := range events {
+		if event.Type != expected[i] {
+			t.Errorf("Event %d type = %s, want %s", i, event.Type, expected[i])
+		}
+	}
+}
+
+func assertSeason(t *testing.T, db *database.Database, state string) {
+	t.Helper()
+	season, err := db.GetSeason(2025)
+	if err != nil {
+		t.Fatalf("Failed to get season: %v", err)
+	}
+	if season.State != state {
+		t.Errorf("Season state = %s, want %s", season.State, state)
+	}
+}